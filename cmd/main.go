@@ -15,6 +15,7 @@ func main() {
 	var noNerdFonts bool
 	var configPath string
 	var colorsFlag []string
+	var composeFile string
 
 	rootCmd := &cobra.Command{
 		Use:   "containertui",
@@ -35,6 +36,10 @@ func main() {
 				cfg.NoNerdFonts = true
 			}
 
+			if composeFile != "" {
+				cfg.ComposeFile = composeFile
+			}
+
 			if len(colorsFlag) > 0 {
 				colorOverrides, err := colors.ParseColors(colorsFlag)
 				if err != nil {
@@ -92,6 +97,7 @@ func main() {
 
 	rootCmd.Flags().BoolVar(&noNerdFonts, "no-nerd-fonts", false, "disable nerd fonts")
 	rootCmd.Flags().StringVar(&configPath, "config", "", "path to config file")
+	rootCmd.Flags().StringVarP(&composeFile, "compose-file", "f", "", "path to a Compose file or its directory, opened in the Compose tab")
 	rootCmd.Flags().StringSliceVar(&colorsFlag, "colors", nil, "color overrides (format: --colors 'primary=#b4befe' --colors 'warning=#f9e2af,success=#a6e3a1')")
 
 	if err := rootCmd.Execute(); err != nil {
@@ -0,0 +1,54 @@
+package client
+
+import (
+	"context"
+	"sort"
+
+	"github.com/docker/docker/api/types/checkpoint"
+	"github.com/docker/docker/api/types/container"
+)
+
+// CheckpointsSupported reports whether the daemon is an experimental build,
+// which checkpoints require, along with CRIU on the daemon's host.
+func (clientWrapper *ClientWrapper) CheckpointsSupported() (bool, error) {
+	info, err := clientWrapper.client.Info(context.Background())
+	if err != nil {
+		return false, err
+	}
+	return info.ExperimentalBuild, nil
+}
+
+// GetCheckpoints lists the names of the checkpoints of a container, sorted.
+func (clientWrapper *ClientWrapper) GetCheckpoints(containerID string) ([]string, error) {
+	checkpoints, err := clientWrapper.client.CheckpointList(context.Background(), containerID, checkpoint.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(checkpoints))
+	for _, checkpointItem := range checkpoints {
+		names = append(names, checkpointItem.Name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// CreateCheckpoint checkpoints a running container under a name. The
+// container is stopped once checkpointed, unless leaveRunning.
+func (clientWrapper *ClientWrapper) CreateCheckpoint(containerID, name string, leaveRunning bool) error {
+	return clientWrapper.client.CheckpointCreate(context.Background(), containerID, checkpoint.CreateOptions{
+		CheckpointID: name,
+		Exit:         !leaveRunning,
+	})
+}
+
+// RemoveCheckpoint deletes a checkpoint of a container.
+func (clientWrapper *ClientWrapper) RemoveCheckpoint(containerID, name string) error {
+	return clientWrapper.client.CheckpointDelete(context.Background(), containerID, checkpoint.DeleteOptions{CheckpointID: name})
+}
+
+// StartContainerFromCheckpoint starts a stopped container, restoring the
+// state saved in one of its checkpoints.
+func (clientWrapper *ClientWrapper) StartContainerFromCheckpoint(containerID, name string) error {
+	return clientWrapper.client.ContainerStart(context.Background(), containerID, container.StartOptions{CheckpointID: name})
+}
@@ -2,15 +2,24 @@
 package client
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/network"
 	"github.com/docker/docker/api/types/volume"
 	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
 )
 
 // ContainerStats represents the CPU and memory usage of a container.
@@ -25,10 +34,11 @@ type ContainerStats struct {
 // Container represents a Docker container with essential details.
 type Container struct {
 	container.Config
-	ID    string `json:"Id"`
-	Name  string `json:"Name"`
-	Image string `json:"Image"`
-	State string `json:"State"`
+	ID     string `json:"Id"`
+	Name   string `json:"Name"`
+	Image  string `json:"Image"`
+	State  string `json:"State"`
+	Health string `json:"Health"` // One of "starting", "healthy" or "unhealthy", empty without a health check.
 }
 
 // Image represents a Docker image.
@@ -41,17 +51,68 @@ type Image struct {
 
 // Network represents a Docker network.
 type Network struct {
-	ID     string `json:"Id"`
-	Name   string `json:"Name"`
-	Driver string `json:"Driver"`
-	Scope  string `json:"Scope"`
+	ID      string   `json:"Id"`
+	Name    string   `json:"Name"`
+	Driver  string   `json:"Driver"`
+	Scope   string   `json:"Scope"`
+	Subnets []string `json:"Subnets"`
 }
 
 // Volume represents a Docker volume.
 type Volume struct {
-	Name       string `json:"Name"`
-	Driver     string `json:"Driver"`
-	Mountpoint string `json:"Mountpoint"`
+	Name       string            `json:"Name"`
+	Driver     string            `json:"Driver"`
+	Mountpoint string            `json:"Mountpoint"`
+	Scope      string            `json:"Scope"`
+	Labels     map[string]string `json:"Labels"`
+	CreatedAt  time.Time         `json:"CreatedAt"` // Zero if unknown.
+	Size       int64             `json:"Size"`      // In bytes, -1 if unknown.
+	RefCount   int64             `json:"RefCount"`  // Number of containers using the volume, -1 if unknown.
+}
+
+// DiskUsageItem represents a single object contributing to disk usage.
+type DiskUsageItem struct {
+	ID     string
+	Name   string
+	Size   int64
+	Active bool
+}
+
+// DiskUsageCategory summarizes the disk usage of one kind of Docker object.
+type DiskUsageCategory struct {
+	Active      int
+	Total       int
+	Size        int64
+	Reclaimable int64
+	Items       []DiskUsageItem // Sorted by size, largest first.
+}
+
+// DiskUsage represents the disk usage of the Docker daemon, as reported by `docker system df`.
+type DiskUsage struct {
+	Images     DiskUsageCategory
+	Containers DiskUsageCategory
+	Volumes    DiskUsageCategory
+	BuildCache DiskUsageCategory
+}
+
+// BuildCacheRecord represents a BuildKit build cache record.
+type BuildCacheRecord struct {
+	ID          string
+	Type        string
+	Description string
+	Size        int64
+	CreatedAt   time.Time
+	LastUsedAt  time.Time // Zero if the record was never used.
+	UsageCount  int
+	InUse       bool
+	Shared      bool
+}
+
+// BuildCachePruneOptions restricts which build cache records are pruned.
+type BuildCachePruneOptions struct {
+	UnusedFor   time.Duration // Only prune records unused for longer than this.
+	KeepStorage int64         // Amount of disk space to keep, in bytes.
+	IDs         []string      // Only prune the records with these IDs.
 }
 
 // ClientWrapper wraps the Docker client to provide container management functionalities.
@@ -83,20 +144,46 @@ func (clientWrapper *ClientWrapper) GetContainers() ([]Container, error) {
 	if err != nil {
 		return nil, err
 	}
+	health, err := clientWrapper.getContainerHealth(listOptions.Filters)
+	if err != nil {
+		return nil, err
+	}
 
 	dockerContainers := make([]Container, 0, len(containers))
 	for _, containerItem := range containers {
 		dockerContainers = append(dockerContainers, Container{
-			ID:    containerItem.ID,
-			Name:  containerItem.Names[0][1:],
-			Image: containerItem.Image,
-			State: containerItem.State,
+			ID:     containerItem.ID,
+			Name:   containerItem.Names[0][1:],
+			Image:  containerItem.Image,
+			State:  containerItem.State,
+			Health: health[containerItem.ID],
 		})
 	}
 
 	return dockerContainers, nil
 }
 
+// getContainerHealth returns the health status of the containers matching the
+// filters by ID, using the health filter of the daemon. Containers without a
+// health check are left out.
+func (clientWrapper *ClientWrapper) getContainerHealth(listFilters filters.Args) (map[string]string, error) {
+	health := make(map[string]string)
+	for _, status := range []string{"starting", "healthy", "unhealthy"} {
+		healthFilters := listFilters.Clone()
+		healthFilters.Add("health", status)
+
+		containers, err := clientWrapper.client.ContainerList(context.Background(), container.ListOptions{All: true, Filters: healthFilters})
+		if err != nil {
+			return nil, err
+		}
+		for _, containerItem := range containers {
+			health[containerItem.ID] = status
+		}
+	}
+
+	return health, nil
+}
+
 // GetImages retrieves a list of all Docker images.
 func (clientWrapper *ClientWrapper) GetImages() ([]Image, error) {
 	listOptions := types.ImageListOptions{
@@ -132,18 +219,314 @@ func (clientWrapper *ClientWrapper) GetNetworks() ([]Network, error) {
 
 	dockerNetworks := make([]Network, 0, len(networks))
 	for _, networkItem := range networks {
-		dockerNetworks = append(dockerNetworks, Network{
-			ID:     networkItem.ID,
-			Name:   networkItem.Name,
-			Driver: networkItem.Driver,
-			Scope:  networkItem.Scope,
-		})
+		dockerNetworks = append(dockerNetworks, newNetwork(networkItem))
 	}
 
 	return dockerNetworks, nil
 }
 
-// GetVolumes retrieves a list of all Docker volumes.
+func newNetwork(networkItem types.NetworkResource) Network {
+	var subnets []string
+	for _, ipamConfig := range networkItem.IPAM.Config {
+		if ipamConfig.Subnet != "" {
+			subnets = append(subnets, ipamConfig.Subnet)
+		}
+	}
+
+	return Network{
+		ID:      networkItem.ID,
+		Name:    networkItem.Name,
+		Driver:  networkItem.Driver,
+		Scope:   networkItem.Scope,
+		Subnets: subnets,
+	}
+}
+
+// IsBuiltin reports whether the network is one of those the daemon creates
+// itself, which cannot be removed.
+func (network Network) IsBuiltin() bool {
+	switch network.Name {
+	case "bridge", "host", "none":
+		return true
+	}
+	return false
+}
+
+// IPAMConfig is an address pool of a network.
+type IPAMConfig struct {
+	Subnet       string
+	Gateway      string
+	IPRange      string
+	AuxAddresses map[string]string
+}
+
+// NetworkEndpoint is a container connected to a network, as reported by InspectNetwork.
+type NetworkEndpoint struct {
+	ContainerID   string
+	ContainerName string
+	IPv4Address   string // In CIDR notation, empty if none.
+	IPv6Address   string
+	MacAddress    string
+}
+
+// NetworkDetails is the full configuration of a network.
+type NetworkDetails struct {
+	Network
+	Created    time.Time
+	IPAMDriver string
+	IPAM       []IPAMConfig
+	Options    map[string]string
+	Labels     map[string]string
+	EnableIPv6 bool
+	Internal   bool
+	Attachable bool
+	Ingress    bool
+	Endpoints  []NetworkEndpoint // Sorted by container name.
+}
+
+// InspectNetwork retrieves the configuration of a network and its connected containers.
+func (clientWrapper *ClientWrapper) InspectNetwork(networkID string) (NetworkDetails, error) {
+	networkResource, err := clientWrapper.client.NetworkInspect(context.Background(), networkID, types.NetworkInspectOptions{})
+	if err != nil {
+		return NetworkDetails{}, err
+	}
+
+	return newNetworkDetails(networkResource), nil
+}
+
+func newNetworkDetails(networkResource types.NetworkResource) NetworkDetails {
+	details := NetworkDetails{
+		Network:    newNetwork(networkResource),
+		Created:    networkResource.Created,
+		IPAMDriver: networkResource.IPAM.Driver,
+		Options:    networkResource.Options,
+		Labels:     networkResource.Labels,
+		EnableIPv6: networkResource.EnableIPv6,
+		Internal:   networkResource.Internal,
+		Attachable: networkResource.Attachable,
+		Ingress:    networkResource.Ingress,
+	}
+
+	for _, ipamConfig := range networkResource.IPAM.Config {
+		details.IPAM = append(details.IPAM, IPAMConfig{
+			Subnet:       ipamConfig.Subnet,
+			Gateway:      ipamConfig.Gateway,
+			IPRange:      ipamConfig.IPRange,
+			AuxAddresses: ipamConfig.AuxAddress,
+		})
+	}
+
+	for containerID, endpoint := range networkResource.Containers {
+		details.Endpoints = append(details.Endpoints, NetworkEndpoint{
+			ContainerID:   containerID,
+			ContainerName: endpoint.Name,
+			IPv4Address:   endpoint.IPv4Address,
+			IPv6Address:   endpoint.IPv6Address,
+			MacAddress:    endpoint.MacAddress,
+		})
+	}
+	sort.Slice(details.Endpoints, func(i, j int) bool {
+		return details.Endpoints[i].ContainerName < details.Endpoints[j].ContainerName
+	})
+
+	return details
+}
+
+// CreateNetworkOptions holds the settings for a new network.
+type CreateNetworkOptions struct {
+	Name       string
+	Driver     string // Defaults to "bridge" when empty.
+	Subnet     string // In CIDR notation, allocated by the daemon when empty.
+	Gateway    string
+	IPRange    string // Sub-range of Subnet to allocate container addresses from.
+	Internal   bool   // Restricts external access to the network.
+	Attachable bool   // Allows standalone containers to attach to swarm networks.
+	EnableIPv6 bool
+	Labels     map[string]string
+}
+
+// CreateNetwork creates a Docker network and returns it.
+func (clientWrapper *ClientWrapper) CreateNetwork(options CreateNetworkOptions) (Network, error) {
+	createOptions := types.NetworkCreate{
+		Driver:     options.Driver,
+		Internal:   options.Internal,
+		Attachable: options.Attachable,
+		EnableIPv6: options.EnableIPv6,
+		Labels:     options.Labels,
+	}
+	if options.Subnet != "" || options.Gateway != "" || options.IPRange != "" {
+		createOptions.IPAM = &network.IPAM{
+			Config: []network.IPAMConfig{{
+				Subnet:  options.Subnet,
+				Gateway: options.Gateway,
+				IPRange: options.IPRange,
+			}},
+		}
+	}
+
+	response, err := clientWrapper.client.NetworkCreate(context.Background(), options.Name, createOptions)
+	if err != nil {
+		return Network{}, err
+	}
+
+	networkResource, err := clientWrapper.client.NetworkInspect(context.Background(), response.ID, types.NetworkInspectOptions{})
+	if err != nil {
+		return Network{}, err
+	}
+
+	return newNetwork(networkResource), nil
+}
+
+// ConnectContainerOptions configures the endpoint of a container on a network.
+type ConnectContainerOptions struct {
+	IPAddress string // Static IPv4 or IPv6 address, allocated by the daemon when empty.
+	Aliases   []string
+}
+
+// ConnectContainer attaches a container to a network.
+func (clientWrapper *ClientWrapper) ConnectContainer(networkID, containerID string, options ConnectContainerOptions) error {
+	endpointSettings := &network.EndpointSettings{
+		Aliases: options.Aliases,
+	}
+
+	if options.IPAddress != "" {
+		ip := net.ParseIP(options.IPAddress)
+		if ip == nil {
+			return fmt.Errorf("invalid IP address %q", options.IPAddress)
+		}
+		if ip.To4() != nil {
+			endpointSettings.IPAMConfig = &network.EndpointIPAMConfig{IPv4Address: options.IPAddress}
+		} else {
+			endpointSettings.IPAMConfig = &network.EndpointIPAMConfig{IPv6Address: options.IPAddress}
+		}
+	}
+
+	return clientWrapper.client.NetworkConnect(context.Background(), networkID, containerID, endpointSettings)
+}
+
+// DisconnectContainer detaches a container from a network.
+func (clientWrapper *ClientWrapper) DisconnectContainer(networkID, containerID string, force bool) error {
+	return clientWrapper.client.NetworkDisconnect(context.Background(), networkID, containerID, force)
+}
+
+// Endpoint is the attachment of a container to a network.
+type Endpoint struct {
+	NetworkID   string
+	NetworkName string
+	IPv4Address string // Empty if none was assigned, e.g. while the container is stopped.
+	IPv6Address string
+	Aliases     []string
+}
+
+// IsShortIDAlias reports whether a network alias is the 12-character short ID
+// of the container, which the daemon adds to each of its endpoints.
+func IsShortIDAlias(containerID, alias string) bool {
+	return len(containerID) >= 12 && alias == containerID[:12]
+}
+
+// PortBinding is an exposed port of a container, published on the host when HostPort is set.
+type PortBinding struct {
+	HostIP        string
+	HostPort      uint16 // 0 if the port is not published.
+	ContainerPort uint16
+	Protocol      string
+}
+
+// String formats the binding like `docker ps`, e.g. "0.0.0.0:8080->80/tcp".
+func (binding PortBinding) String() string {
+	containerPort := fmt.Sprintf("%d/%s", binding.ContainerPort, binding.Protocol)
+	if binding.HostPort == 0 {
+		return containerPort
+	}
+	return fmt.Sprintf("%s->%s", net.JoinHostPort(binding.HostIP, strconv.Itoa(int(binding.HostPort))), containerPort)
+}
+
+// Overlaps reports whether both bindings publish the same host port and
+// protocol on addresses which intersect, e.g. 0.0.0.0 and 127.0.0.1.
+func (binding PortBinding) Overlaps(other PortBinding) bool {
+	if binding.HostPort == 0 || binding.HostPort != other.HostPort || binding.Protocol != other.Protocol {
+		return false
+	}
+
+	ip, otherIP := net.ParseIP(binding.HostIP), net.ParseIP(other.HostIP)
+	if ip == nil || otherIP == nil || ip.Equal(otherIP) {
+		return true // An empty or unparsable address is taken to mean all addresses.
+	}
+	if (ip.To4() == nil) != (otherIP.To4() == nil) {
+		return false // Different address families.
+	}
+	return ip.IsUnspecified() || otherIP.IsUnspecified()
+}
+
+// ContainerEndpoints is a container along with its network attachments and ports.
+type ContainerEndpoints struct {
+	Container
+	Endpoints []Endpoint // Sorted by network name.
+	Ports     []PortBinding
+}
+
+// GetContainerEndpoints retrieves all containers with the networks they are attached to.
+func (clientWrapper *ClientWrapper) GetContainerEndpoints() ([]ContainerEndpoints, error) {
+	containers, err := clientWrapper.client.ContainerList(context.Background(), container.ListOptions{All: true})
+	if err != nil {
+		return nil, err
+	}
+	health, err := clientWrapper.getContainerHealth(filters.Args{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]ContainerEndpoints, 0, len(containers))
+	for _, containerItem := range containers {
+		entry := ContainerEndpoints{
+			Container: Container{
+				ID:     containerItem.ID,
+				Name:   strings.TrimPrefix(containerItem.Names[0], "/"),
+				Image:  containerItem.Image,
+				State:  containerItem.State,
+				Health: health[containerItem.ID],
+			},
+		}
+
+		if containerItem.NetworkSettings != nil {
+			for networkName, settings := range containerItem.NetworkSettings.Networks {
+				if settings == nil {
+					continue
+				}
+				entry.Endpoints = append(entry.Endpoints, Endpoint{
+					NetworkID:   settings.NetworkID,
+					NetworkName: networkName,
+					IPv4Address: settings.IPAddress,
+					IPv6Address: settings.GlobalIPv6Address,
+					Aliases:     settings.Aliases,
+				})
+			}
+		}
+		sort.Slice(entry.Endpoints, func(i, j int) bool {
+			return entry.Endpoints[i].NetworkName < entry.Endpoints[j].NetworkName
+		})
+
+		for _, port := range containerItem.Ports {
+			entry.Ports = append(entry.Ports, PortBinding{
+				HostIP:        port.IP,
+				HostPort:      port.PublicPort,
+				ContainerPort: port.PrivatePort,
+				Protocol:      port.Type,
+			})
+		}
+
+		result = append(result, entry)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result, nil
+}
+
+// GetVolumes retrieves a list of all Docker volumes. Their size and reference
+// count are unknown unless the daemon reports them, see GetVolumeUsage.
 func (clientWrapper *ClientWrapper) GetVolumes() ([]Volume, error) {
 	listOptions := volume.ListOptions{}
 
@@ -154,16 +537,88 @@ func (clientWrapper *ClientWrapper) GetVolumes() ([]Volume, error) {
 
 	dockerVolumes := make([]Volume, 0, len(volumes.Volumes))
 	for _, volumeItem := range volumes.Volumes {
-		dockerVolumes = append(dockerVolumes, Volume{
-			Name:       volumeItem.Name,
-			Driver:     volumeItem.Driver,
-			Mountpoint: volumeItem.Mountpoint,
-		})
+		dockerVolumes = append(dockerVolumes, newVolume(*volumeItem))
 	}
 
 	return dockerVolumes, nil
 }
 
+// VolumeUsage is the disk usage of a volume.
+type VolumeUsage struct {
+	Size     int64 // In bytes.
+	RefCount int64 // Number of containers using the volume.
+}
+
+// GetVolumeUsage returns the usage of the volumes by name, from the disk usage
+// API. The daemon measures every volume on disk for it, which can be slow.
+func (clientWrapper *ClientWrapper) GetVolumeUsage() (map[string]VolumeUsage, error) {
+	usage, err := clientWrapper.client.DiskUsage(context.Background(), types.DiskUsageOptions{
+		Types: []types.DiskUsageObject{types.VolumeObject},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	usageByName := make(map[string]VolumeUsage, len(usage.Volumes))
+	for _, volumeItem := range usage.Volumes {
+		if volumeItem != nil && volumeItem.UsageData != nil {
+			usageByName[volumeItem.Name] = VolumeUsage{Size: volumeItem.UsageData.Size, RefCount: volumeItem.UsageData.RefCount}
+		}
+	}
+
+	return usageByName, nil
+}
+
+// newVolume converts a volume from the Docker API.
+func newVolume(volumeItem volume.Volume) Volume {
+	createdAt, _ := time.Parse(time.RFC3339, volumeItem.CreatedAt)
+
+	size, refCount := int64(-1), int64(-1)
+	if volumeItem.UsageData != nil {
+		size = volumeItem.UsageData.Size
+		refCount = volumeItem.UsageData.RefCount
+	}
+
+	return Volume{
+		Name:       volumeItem.Name,
+		Driver:     volumeItem.Driver,
+		Mountpoint: volumeItem.Mountpoint,
+		Scope:      volumeItem.Scope,
+		Labels:     volumeItem.Labels,
+		CreatedAt:  createdAt,
+		Size:       size,
+		RefCount:   refCount,
+	}
+}
+
+// CreateVolumeOptions holds the settings for a new volume.
+type CreateVolumeOptions struct {
+	Name       string // Generated by the daemon when empty.
+	Driver     string // Defaults to "local" when empty.
+	DriverOpts map[string]string
+	Labels     map[string]string
+}
+
+// CreateVolume creates a Docker volume and returns it.
+func (clientWrapper *ClientWrapper) CreateVolume(options CreateVolumeOptions) (Volume, error) {
+	createOptions := volume.CreateOptions{
+		Name:       options.Name,
+		Driver:     options.Driver,
+		DriverOpts: options.DriverOpts,
+		Labels:     options.Labels,
+	}
+
+	volumeItem, err := clientWrapper.client.VolumeCreate(context.Background(), createOptions)
+	if err != nil {
+		return Volume{}, err
+	}
+
+	createdVolume := newVolume(volumeItem)
+	createdVolume.RefCount = 0 // A new volume is not used by any container yet.
+
+	return createdVolume, nil
+}
+
 // GetContainerState retrieves the current state of a specific Docker container by its ID.
 func (clientWrapper *ClientWrapper) GetContainerState(containerID string) (string, error) {
 	inspectResponse, err := clientWrapper.client.ContainerInspect(context.Background(), containerID)
@@ -261,13 +716,22 @@ func (clientWrapper *ClientWrapper) RemoveContainers(containerIDs []string) erro
 // Logs represents the response from Moby's ContainerLogs.
 type Logs io.ReadCloser
 
-// OpenLogs streams logs from a Docker container.
-func (clientWrapper *ClientWrapper) OpenLogs(containerID string) (Logs, error) {
+// OpenLogs streams logs from a Docker container. Each line is prefixed with
+// the timestamp the daemon recorded it with, so that the logs of several
+// containers can be merged.
+func (clientWrapper *ClientWrapper) OpenLogs(containerID string, options LogOptions) (Logs, error) {
+	tail := options.Tail
+	if tail == "" {
+		tail = "all"
+	}
 	logsOptions := container.LogsOptions{
 		ShowStdout: true,
 		ShowStderr: true,
-		Follow:     true,
-		Tail:       "all",
+		Follow:     options.Follow,
+		Tail:       tail,
+		Since:      options.Since,
+		Until:      options.Until,
+		Timestamps: true,
 	}
 
 	reader, err := clientWrapper.client.ContainerLogs(context.Background(), containerID, logsOptions)
@@ -306,6 +770,146 @@ func (clientWrapper *ClientWrapper) ExecShell(containerID string, shell []string
 	return attachResp.Conn, nil // Attaches to socket, full duplex.
 }
 
+// ExecOptions configure a command run in a container.
+type ExecOptions struct {
+	Cmd        []string
+	User       string   // The user of the container when empty.
+	WorkingDir string   // The working directory of the container when empty.
+	Env        []string // KEY=value, added to the environment of the container.
+}
+
+// ExecOutputLimit is the number of bytes of each stream kept of the output of a command.
+const ExecOutputLimit = 1 << 20
+
+// ExecResult is the output of a command run in a container.
+type ExecResult struct {
+	Stdout    string
+	Stderr    string
+	ExitCode  int
+	Truncated bool // Whether a stream exceeded ExecOutputLimit and was cut.
+}
+
+// limitedBuffer keeps the first limit bytes written to it and discards the
+// rest, so that a command cannot fill the memory with its output.
+type limitedBuffer struct {
+	bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+func (buffer *limitedBuffer) Write(data []byte) (int, error) {
+	if room := buffer.limit - buffer.Len(); len(data) > room {
+		buffer.truncated = true
+		buffer.Buffer.Write(data[:max(room, 0)])
+		return len(data), nil // Reports everything written, to keep reading.
+	}
+	return buffer.Buffer.Write(data)
+}
+
+// RunCommand runs a command in a running container without a TTY or stdin,
+// and waits for it to exit. Cancelling ctx stops waiting, but not the
+// command: the daemon cannot stop an exec, which runs until it exits or the
+// container stops. Each stream is cut at ExecOutputLimit bytes.
+func (clientWrapper *ClientWrapper) RunCommand(ctx context.Context, containerID string, options ExecOptions) (ExecResult, error) {
+	execConfig := types.ExecConfig{
+		Cmd:          options.Cmd,
+		User:         options.User,
+		WorkingDir:   options.WorkingDir,
+		Env:          options.Env,
+		AttachStdout: true,
+		AttachStderr: true,
+	}
+
+	execResp, err := clientWrapper.client.ContainerExecCreate(ctx, containerID, execConfig)
+	if err != nil {
+		return ExecResult{}, err
+	}
+
+	attachResp, err := clientWrapper.client.ContainerExecAttach(ctx, execResp.ID, types.ExecStartCheck{})
+	if err != nil {
+		return ExecResult{}, err
+	}
+	defer attachResp.Close()
+
+	// The connection does not follow ctx once hijacked, so it is closed on cancellation.
+	copied := make(chan error, 1)
+	stdout := limitedBuffer{limit: ExecOutputLimit}
+	stderr := limitedBuffer{limit: ExecOutputLimit}
+	go func() {
+		_, err := stdcopy.StdCopy(&stdout, &stderr, attachResp.Reader)
+		copied <- err
+	}()
+	select {
+	case err = <-copied:
+	case <-ctx.Done():
+		attachResp.Close()
+		<-copied
+		err = ctx.Err()
+	}
+	if err != nil {
+		return ExecResult{}, err
+	}
+
+	inspection, err := clientWrapper.client.ContainerExecInspect(ctx, execResp.ID)
+	if err != nil {
+		return ExecResult{}, err
+	}
+
+	return ExecResult{
+		Stdout:    stdout.String(),
+		Stderr:    stderr.String(),
+		ExitCode:  inspection.ExitCode,
+		Truncated: stdout.truncated || stderr.truncated,
+	}, nil
+}
+
+// DetachKeys is the key sequence which detaches from a container without stopping it, as in the Docker CLI.
+const DetachKeys = "ctrl-p,ctrl-q"
+
+// Attachment is a connection to the standard streams of the main process of a container.
+type Attachment struct {
+	types.HijackedResponse
+	Tty       bool // Without a TTY, stdout and stderr are multiplexed, see stdcopy.
+	OpenStdin bool // Whether the container reads its stdin, as started with -i.
+}
+
+// AttachContainer attaches to the stdin, stdout and stderr of the main process of a running container.
+func (clientWrapper *ClientWrapper) AttachContainer(containerID string) (Attachment, error) {
+	inspection, err := clientWrapper.client.ContainerInspect(context.Background(), containerID)
+	if err != nil {
+		return Attachment{}, err
+	}
+	if inspection.State == nil || !inspection.State.Running {
+		return Attachment{}, fmt.Errorf("container %s is not running", strings.TrimPrefix(inspection.Name, "/"))
+	}
+
+	options := container.AttachOptions{
+		Stream:     true,
+		Stdin:      inspection.Config.OpenStdin,
+		Stdout:     true,
+		Stderr:     true,
+		DetachKeys: DetachKeys,
+	}
+	response, err := clientWrapper.client.ContainerAttach(context.Background(), containerID, options)
+	if err != nil {
+		return Attachment{}, err
+	}
+
+	return Attachment{
+		HijackedResponse: response,
+		Tty:              inspection.Config.Tty,
+		OpenStdin:        inspection.Config.OpenStdin,
+	}, nil
+}
+
+// ResizeContainerTTY resizes the TTY of the main process of a container.
+func (clientWrapper *ClientWrapper) ResizeContainerTTY(containerID string, width, height uint) error {
+	return clientWrapper.client.ContainerResize(context.Background(), containerID, container.ResizeOptions{
+		Width:  width,
+		Height: height,
+	})
+}
+
 // RemoveImage removes a specific Docker image by its ID.
 func (clientWrapper *ClientWrapper) RemoveImage(imageID string) error {
 	options := types.ImageRemoveOptions{
@@ -351,6 +955,249 @@ func (clientWrapper *ClientWrapper) PruneNetworks() error {
 	return err
 }
 
+// PruneUnusedImages removes all images which are not used by a container, not only dangling ones.
+func (clientWrapper *ClientWrapper) PruneUnusedImages() (uint64, error) {
+	pruneFilters := filters.NewArgs(filters.Arg("dangling", "false"))
+
+	report, err := clientWrapper.client.ImagesPrune(context.Background(), pruneFilters)
+	if err != nil {
+		return 0, err
+	}
+	return report.SpaceReclaimed, nil
+}
+
+// PruneUnusedVolumes removes all volumes which are not used by a container, including named ones.
+func (clientWrapper *ClientWrapper) PruneUnusedVolumes() (uint64, error) {
+	pruneFilters := filters.NewArgs(filters.Arg("all", "true"))
+
+	report, err := clientWrapper.client.VolumesPrune(context.Background(), pruneFilters)
+	if err != nil {
+		return 0, err
+	}
+	return report.SpaceReclaimed, nil
+}
+
+// PruneContainers removes all stopped containers.
+func (clientWrapper *ClientWrapper) PruneContainers() (uint64, error) {
+	report, err := clientWrapper.client.ContainersPrune(context.Background(), filters.Args{})
+	if err != nil {
+		return 0, err
+	}
+	return report.SpaceReclaimed, nil
+}
+
+// GetBuildCache retrieves all BuildKit build cache records.
+func (clientWrapper *ClientWrapper) GetBuildCache() ([]BuildCacheRecord, error) {
+	diskUsageOptions := types.DiskUsageOptions{
+		Types: []types.DiskUsageObject{types.BuildCacheObject},
+	}
+
+	usage, err := clientWrapper.client.DiskUsage(context.Background(), diskUsageOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]BuildCacheRecord, 0, len(usage.BuildCache))
+	for _, buildCacheItem := range usage.BuildCache {
+		if buildCacheItem == nil {
+			continue
+		}
+
+		record := BuildCacheRecord{
+			ID:          buildCacheItem.ID,
+			Type:        buildCacheItem.Type,
+			Description: buildCacheItem.Description,
+			Size:        buildCacheItem.Size,
+			CreatedAt:   buildCacheItem.CreatedAt,
+			UsageCount:  buildCacheItem.UsageCount,
+			InUse:       buildCacheItem.InUse,
+			Shared:      buildCacheItem.Shared,
+		}
+		if buildCacheItem.LastUsedAt != nil {
+			record.LastUsedAt = *buildCacheItem.LastUsedAt
+		}
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// PruneBuildCache removes build cache records which are not in use, as restricted by the given options.
+func (clientWrapper *ClientWrapper) PruneBuildCache(options BuildCachePruneOptions) (uint64, error) {
+	pruneFilters := filters.NewArgs()
+	if options.UnusedFor > 0 {
+		pruneFilters.Add("until", options.UnusedFor.String())
+	}
+	if len(options.IDs) == 0 {
+		return clientWrapper.pruneBuildCache(options.KeepStorage, pruneFilters)
+	}
+
+	// The daemon accepts a single value per filter, so records are pruned one at a time.
+	var spaceReclaimed uint64
+	for _, id := range options.IDs {
+		recordFilters := pruneFilters.Clone()
+		recordFilters.Add("id", id)
+		reclaimed, err := clientWrapper.pruneBuildCache(options.KeepStorage, recordFilters)
+		spaceReclaimed += reclaimed
+		if err != nil {
+			return spaceReclaimed, err
+		}
+	}
+	return spaceReclaimed, nil
+}
+
+func (clientWrapper *ClientWrapper) pruneBuildCache(keepStorage int64, pruneFilters filters.Args) (uint64, error) {
+	report, err := clientWrapper.client.BuildCachePrune(context.Background(), types.BuildCachePruneOptions{
+		All:         true,
+		KeepStorage: keepStorage,
+		Filters:     pruneFilters,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return report.SpaceReclaimed, nil
+}
+
+// DiskUsage retrieves the disk usage of images, containers, volumes and the build cache.
+func (clientWrapper *ClientWrapper) DiskUsage() (DiskUsage, error) {
+	usage, err := clientWrapper.client.DiskUsage(context.Background(), types.DiskUsageOptions{})
+	if err != nil {
+		return DiskUsage{}, err
+	}
+
+	return newDiskUsage(usage), nil
+}
+
+// newDiskUsage summarizes a raw disk usage report the same way the Docker CLI does.
+func newDiskUsage(usage types.DiskUsage) DiskUsage {
+	var diskUsage DiskUsage
+
+	diskUsage.Images.Size = usage.LayersSize
+	var usedImageSize int64
+	for _, imageItem := range usage.Images {
+		if imageItem == nil {
+			continue
+		}
+
+		name := "<none>"
+		if len(imageItem.RepoTags) > 0 && imageItem.RepoTags[0] != "<none>:<none>" {
+			name = imageItem.RepoTags[0]
+		}
+
+		active := imageItem.Containers > 0
+		if active {
+			diskUsage.Images.Active++
+			if imageItem.Size != -1 && imageItem.SharedSize != -1 {
+				usedImageSize += imageItem.Size - imageItem.SharedSize
+			}
+		}
+
+		diskUsage.Images.Total++
+		diskUsage.Images.Items = append(diskUsage.Images.Items, DiskUsageItem{
+			ID:     imageItem.ID,
+			Name:   name,
+			Size:   imageItem.Size,
+			Active: active,
+		})
+	}
+	diskUsage.Images.Reclaimable = diskUsage.Images.Size - usedImageSize
+
+	for _, containerItem := range usage.Containers {
+		if containerItem == nil {
+			continue
+		}
+
+		name := containerItem.ID
+		if len(containerItem.Names) > 0 {
+			name = strings.TrimPrefix(containerItem.Names[0], "/")
+		}
+
+		active := containerItem.State == "running" || containerItem.State == "paused" || containerItem.State == "restarting"
+		if active {
+			diskUsage.Containers.Active++
+		} else {
+			diskUsage.Containers.Reclaimable += containerItem.SizeRw
+		}
+
+		diskUsage.Containers.Total++
+		diskUsage.Containers.Size += containerItem.SizeRw
+		diskUsage.Containers.Items = append(diskUsage.Containers.Items, DiskUsageItem{
+			ID:     containerItem.ID,
+			Name:   name,
+			Size:   containerItem.SizeRw,
+			Active: active,
+		})
+	}
+
+	for _, volumeItem := range usage.Volumes {
+		if volumeItem == nil {
+			continue
+		}
+
+		var size int64
+		active := false
+		if volumeItem.UsageData != nil {
+			if volumeItem.UsageData.Size != -1 {
+				size = volumeItem.UsageData.Size
+			}
+			active = volumeItem.UsageData.RefCount > 0
+		}
+
+		if active {
+			diskUsage.Volumes.Active++
+		} else {
+			diskUsage.Volumes.Reclaimable += size
+		}
+
+		diskUsage.Volumes.Total++
+		diskUsage.Volumes.Size += size
+		diskUsage.Volumes.Items = append(diskUsage.Volumes.Items, DiskUsageItem{
+			ID:     volumeItem.Name,
+			Name:   volumeItem.Name,
+			Size:   size,
+			Active: active,
+		})
+	}
+
+	for _, buildCacheItem := range usage.BuildCache {
+		if buildCacheItem == nil {
+			continue
+		}
+
+		if buildCacheItem.InUse {
+			diskUsage.BuildCache.Active++
+		}
+		if !buildCacheItem.Shared {
+			diskUsage.BuildCache.Size += buildCacheItem.Size
+			if !buildCacheItem.InUse {
+				diskUsage.BuildCache.Reclaimable += buildCacheItem.Size
+			}
+		}
+
+		name := buildCacheItem.Description
+		if name == "" {
+			name = buildCacheItem.Type
+		}
+
+		diskUsage.BuildCache.Total++
+		diskUsage.BuildCache.Items = append(diskUsage.BuildCache.Items, DiskUsageItem{
+			ID:     buildCacheItem.ID,
+			Name:   name,
+			Size:   buildCacheItem.Size,
+			Active: buildCacheItem.InUse,
+		})
+	}
+
+	for _, category := range []*DiskUsageCategory{&diskUsage.Images, &diskUsage.Containers, &diskUsage.Volumes, &diskUsage.BuildCache} {
+		sort.SliceStable(category.Items, func(i, j int) bool {
+			return category.Items[i].Size > category.Items[j].Size
+		})
+	}
+
+	return diskUsage
+}
+
 // GetContainersUsingImage returns a list of container names that are using the specified image ID.
 func (clientWrapper *ClientWrapper) GetContainersUsingImage(imageID string) ([]string, error) {
 	containers, err := clientWrapper.client.ContainerList(context.Background(), container.ListOptions{All: true})
@@ -473,3 +1320,83 @@ func (clientWrapper *ClientWrapper) GetContainerStats(containerID string) (Conta
 func (clientWrapper *ClientWrapper) InspectContainer(containerID string) (types.ContainerJSON, error) {
 	return clientWrapper.client.ContainerInspect(context.Background(), containerID)
 }
+
+// InspectImageConfig returns the configuration an image gives its containers,
+// e.g. its environment, labels and default command.
+func (clientWrapper *ClientWrapper) InspectImageConfig(imageName string) (*container.Config, error) {
+	imageInspect, _, err := clientWrapper.client.ImageInspectWithRaw(context.Background(), imageName)
+	if err != nil {
+		return nil, err
+	}
+	if imageInspect.Config == nil {
+		return &container.Config{}, nil
+	}
+	return imageInspect.Config, nil
+}
+
+// ContainerResources are the settings of a container which can be changed
+// without recreating it.
+type ContainerResources struct {
+	RestartPolicy     string // One of "no", "always", "unless-stopped" or "on-failure".
+	MaximumRetryCount int    // Only used with the "on-failure" restart policy.
+	CPUShares         int64
+	CPUPeriod         int64 // In microseconds.
+	CPUQuota          int64 // In microseconds per CPUPeriod.
+	CpusetCpus        string
+	Memory            int64 // In bytes, 0 if unlimited.
+	MemorySwap        int64 // Memory plus swap in bytes, -1 if unlimited.
+	PidsLimit         int64 // 0 or -1 if unlimited.
+}
+
+// NewContainerResources extracts the updatable settings from the host configuration of a container.
+func NewContainerResources(hostConfig *container.HostConfig) ContainerResources {
+	if hostConfig == nil {
+		return ContainerResources{}
+	}
+
+	resources := ContainerResources{
+		RestartPolicy:     string(hostConfig.RestartPolicy.Name),
+		MaximumRetryCount: hostConfig.RestartPolicy.MaximumRetryCount,
+		CPUShares:         hostConfig.CPUShares,
+		CPUPeriod:         hostConfig.CPUPeriod,
+		CPUQuota:          hostConfig.CPUQuota,
+		CpusetCpus:        hostConfig.CpusetCpus,
+		Memory:            hostConfig.Memory,
+		MemorySwap:        hostConfig.MemorySwap,
+	}
+	if resources.RestartPolicy == "" {
+		resources.RestartPolicy = string(container.RestartPolicyDisabled)
+	}
+	if hostConfig.PidsLimit != nil {
+		resources.PidsLimit = *hostConfig.PidsLimit
+	}
+
+	return resources
+}
+
+// UpdateContainer changes the restart policy and resource limits of a
+// container, running or not, and returns the warnings of the daemon.
+func (clientWrapper *ClientWrapper) UpdateContainer(containerID string, resources ContainerResources) ([]string, error) {
+	pidsLimit := resources.PidsLimit
+	updateConfig := container.UpdateConfig{
+		Resources: container.Resources{
+			CPUShares:  resources.CPUShares,
+			CPUPeriod:  resources.CPUPeriod,
+			CPUQuota:   resources.CPUQuota,
+			CpusetCpus: resources.CpusetCpus,
+			Memory:     resources.Memory,
+			MemorySwap: resources.MemorySwap,
+			PidsLimit:  &pidsLimit,
+		},
+		RestartPolicy: container.RestartPolicy{
+			Name:              container.RestartPolicyMode(resources.RestartPolicy),
+			MaximumRetryCount: resources.MaximumRetryCount,
+		},
+	}
+
+	response, err := clientWrapper.client.ContainerUpdate(context.Background(), containerID, updateConfig)
+	if err != nil {
+		return nil, err
+	}
+	return response.Warnings, nil
+}
@@ -1,7 +1,27 @@
 package client
 
 import (
+	"archive/tar"
+	"bytes"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/registry"
+	"github.com/docker/docker/api/types/system"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/docker/go-connections/nat"
+	"github.com/givensuman/containertui/internal/compose"
 )
 
 func TestNewClient(t *testing.T) {
@@ -80,3 +100,825 @@ func TestGetContainerState(t *testing.T) {
 		t.Errorf("expected 'unknown' for nonexistent container, got %s", state)
 	}
 }
+
+func TestNewDiskUsage(t *testing.T) {
+	lastUsed := time.Now()
+	usage := types.DiskUsage{
+		LayersSize: 300,
+		Images: []*image.Summary{
+			{ID: "sha256:used", RepoTags: []string{"alpine:latest"}, Size: 100, SharedSize: 20, Containers: 1},
+			{ID: "sha256:unused", RepoTags: []string{"<none>:<none>"}, Size: 200, SharedSize: 0, Containers: 0},
+		},
+		Containers: []*types.Container{
+			{ID: "running", Names: []string{"/web"}, State: "running", SizeRw: 10},
+			{ID: "exited", Names: []string{"/job"}, State: "exited", SizeRw: 30},
+		},
+		Volumes: []*volume.Volume{
+			{Name: "data", UsageData: &volume.UsageData{RefCount: 1, Size: 50}},
+			{Name: "orphan", UsageData: &volume.UsageData{RefCount: 0, Size: 70}},
+			{Name: "remote", UsageData: &volume.UsageData{RefCount: 0, Size: -1}},
+		},
+		BuildCache: []*types.BuildCache{
+			{ID: "busy", Type: "regular", InUse: true, Size: 5, LastUsedAt: &lastUsed},
+			{ID: "idle", Type: "regular", Description: "RUN make", Size: 15},
+			{ID: "shared", Type: "regular", Shared: true, Size: 100},
+		},
+	}
+
+	diskUsage := newDiskUsage(usage)
+
+	tests := []struct {
+		name     string
+		category DiskUsageCategory
+		active   int
+		total    int
+		size     int64
+		reclaim  int64
+		largest  string
+	}{
+		{"images", diskUsage.Images, 1, 2, 300, 220, "<none>"},
+		{"containers", diskUsage.Containers, 1, 2, 40, 30, "job"},
+		{"volumes", diskUsage.Volumes, 1, 3, 120, 70, "orphan"},
+		{"build cache", diskUsage.BuildCache, 1, 3, 20, 15, "regular"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.category.Active != tt.active || tt.category.Total != tt.total {
+				t.Errorf("active/total = %d/%d; want %d/%d", tt.category.Active, tt.category.Total, tt.active, tt.total)
+			}
+			if tt.category.Size != tt.size {
+				t.Errorf("size = %d; want %d", tt.category.Size, tt.size)
+			}
+			if tt.category.Reclaimable != tt.reclaim {
+				t.Errorf("reclaimable = %d; want %d", tt.category.Reclaimable, tt.reclaim)
+			}
+			if len(tt.category.Items) == 0 || tt.category.Items[0].Name != tt.largest {
+				t.Errorf("largest item = %v; want %s", tt.category.Items, tt.largest)
+			}
+		})
+	}
+}
+
+func TestNewDaemonInfo(t *testing.T) {
+	_, cidr, _ := net.ParseCIDR("10.0.0.0/8")
+	info := system.Info{
+		ServerVersion: "25.0.3",
+		Driver:        "overlay2",
+		CgroupDriver:  "systemd",
+		CgroupVersion: "2",
+		LoggingDriver: "json-file",
+		Runtimes:      map[string]system.RuntimeWithStatus{"runc": {}, "io.containerd.runc.v2": {}},
+		SecurityOptions: []string{
+			"name=seccomp,profile=builtin",
+			"name=rootless",
+			"name=cgroupns",
+		},
+		NCPU:     4,
+		MemTotal: 8 << 30,
+		RegistryConfig: &registry.ServiceConfig{
+			Mirrors:               []string{"https://mirror.example.com/"},
+			InsecureRegistryCIDRs: []*registry.NetIPNet{(*registry.NetIPNet)(cidr)},
+			IndexConfigs: map[string]*registry.IndexInfo{
+				"docker.io":         {Name: "docker.io", Secure: true},
+				"registry.lan:5000": {Name: "registry.lan:5000", Secure: false},
+			},
+		},
+		Warnings: []string{"WARNING: No swap limit support"},
+	}
+
+	daemonInfo := newDaemonInfo(info, types.Version{APIVersion: "1.44", MinAPIVersion: "1.24"})
+
+	if daemonInfo.EngineVersion != "25.0.3" {
+		t.Errorf("EngineVersion = %q; want the version of Info when Version has none", daemonInfo.EngineVersion)
+	}
+	if !reflect.DeepEqual(daemonInfo.Runtimes, []string{"io.containerd.runc.v2", "runc"}) {
+		t.Errorf("Runtimes = %v; want them sorted", daemonInfo.Runtimes)
+	}
+	if !reflect.DeepEqual(daemonInfo.SecurityOptions, []string{"seccomp (profile=builtin)", "rootless", "cgroupns"}) {
+		t.Errorf("SecurityOptions = %v", daemonInfo.SecurityOptions)
+	}
+	if !daemonInfo.Rootless {
+		t.Error("Rootless = false; want true")
+	}
+	if !reflect.DeepEqual(daemonInfo.InsecureRegistries, []string{"10.0.0.0/8", "registry.lan:5000"}) {
+		t.Errorf("InsecureRegistries = %v", daemonInfo.InsecureRegistries)
+	}
+	if !reflect.DeepEqual(daemonInfo.RegistryMirrors, []string{"https://mirror.example.com/"}) {
+		t.Errorf("RegistryMirrors = %v", daemonInfo.RegistryMirrors)
+	}
+}
+
+func TestParseStatOutput(t *testing.T) {
+	output := "41ed 4096 1700000000 /volume/config\n" +
+		"81a4 12 1700000100 /volume/notes with spaces.txt\n" +
+		"a1ff 7 1700000200 /volume/latest\n" +
+		"garbage\n"
+
+	files := parseStatOutput(output)
+	if len(files) != 3 {
+		t.Fatalf("expected 3 files, got %d: %+v", len(files), files)
+	}
+
+	if files[0].Name != "config" || !files[0].Mode.IsDir() || files[0].Mode.Perm() != 0o755 {
+		t.Errorf("unexpected directory entry: %+v", files[0])
+	}
+	if files[1].Name != "notes with spaces.txt" || files[1].Path != "/volume/notes with spaces.txt" || files[1].Size != 12 {
+		t.Errorf("unexpected file entry: %+v", files[1])
+	}
+	if !files[1].Mode.IsRegular() || files[1].Mode.Perm() != 0o644 {
+		t.Errorf("expected regular file with mode 0644, got %v", files[1].Mode)
+	}
+	if files[2].Mode&os.ModeSymlink == 0 {
+		t.Errorf("expected symlink, got %v", files[2].Mode)
+	}
+	if !files[2].ModTime.Equal(time.Unix(1700000200, 0)) {
+		t.Errorf("unexpected modification time %v", files[2].ModTime)
+	}
+}
+
+func TestRebaseEntryName(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"volume", "."},
+		{"volume/", "./"},
+		{"volume/data/db.sqlite", "./data/db.sqlite"},
+		{"other/file", "other/file"},
+		{"volumes/file", "volumes/file"},
+	}
+
+	for _, tt := range tests {
+		if result := rebaseEntryName(tt.name, "volume"); result != tt.expected {
+			t.Errorf("rebaseEntryName(%q) = %q; want %q", tt.name, result, tt.expected)
+		}
+	}
+}
+
+func TestNewVolume(t *testing.T) {
+	withoutUsage := newVolume(volume.Volume{Name: "data", CreatedAt: "2024-01-02T03:04:05Z", Scope: "local"})
+	if withoutUsage.Size != -1 || withoutUsage.RefCount != -1 {
+		t.Errorf("expected unknown size and reference count, got %d and %d", withoutUsage.Size, withoutUsage.RefCount)
+	}
+	if !withoutUsage.CreatedAt.Equal(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)) {
+		t.Errorf("unexpected creation time %v", withoutUsage.CreatedAt)
+	}
+
+	withUsage := newVolume(volume.Volume{Name: "cache", UsageData: &volume.UsageData{Size: 2048, RefCount: 2}})
+	if withUsage.Size != 2048 || withUsage.RefCount != 2 {
+		t.Errorf("expected size 2048 and reference count 2, got %d and %d", withUsage.Size, withUsage.RefCount)
+	}
+	if !withUsage.CreatedAt.IsZero() {
+		t.Errorf("expected zero creation time, got %v", withUsage.CreatedAt)
+	}
+}
+
+func TestPortBindingString(t *testing.T) {
+	tests := []struct {
+		binding  PortBinding
+		expected string
+	}{
+		{PortBinding{ContainerPort: 80, Protocol: "tcp"}, "80/tcp"},
+		{PortBinding{HostIP: "0.0.0.0", HostPort: 8080, ContainerPort: 80, Protocol: "tcp"}, "0.0.0.0:8080->80/tcp"},
+		{PortBinding{HostIP: "::", HostPort: 5353, ContainerPort: 53, Protocol: "udp"}, "[::]:5353->53/udp"},
+	}
+
+	for _, tt := range tests {
+		if result := tt.binding.String(); result != tt.expected {
+			t.Errorf("String() = %q; want %q", result, tt.expected)
+		}
+	}
+}
+
+func TestNewNetworkDetails(t *testing.T) {
+	details := newNetworkDetails(types.NetworkResource{
+		ID:       "net1",
+		Name:     "bridge",
+		Driver:   "bridge",
+		Internal: true,
+		IPAM: network.IPAM{Config: []network.IPAMConfig{
+			{Subnet: "172.17.0.0/16", Gateway: "172.17.0.1"},
+		}},
+		Containers: map[string]types.EndpointResource{
+			"c2": {Name: "web", IPv4Address: "172.17.0.3/16"},
+			"c1": {Name: "db", IPv4Address: "172.17.0.2/16", MacAddress: "02:42:ac:11:00:02"},
+		},
+	})
+
+	if !details.IsBuiltin() || !details.Internal {
+		t.Errorf("expected a built-in internal network, got %+v", details)
+	}
+	if len(details.Subnets) != 1 || details.Subnets[0] != "172.17.0.0/16" {
+		t.Errorf("unexpected subnets %v", details.Subnets)
+	}
+	if len(details.IPAM) != 1 || details.IPAM[0].Gateway != "172.17.0.1" {
+		t.Errorf("unexpected IPAM config %+v", details.IPAM)
+	}
+	if len(details.Endpoints) != 2 || details.Endpoints[0].ContainerName != "db" || details.Endpoints[0].ContainerID != "c1" {
+		t.Errorf("expected endpoints sorted by container name, got %+v", details.Endpoints)
+	}
+}
+
+func TestPortBindingOverlaps(t *testing.T) {
+	binding := func(hostIP string, hostPort uint16, protocol string) PortBinding {
+		return PortBinding{HostIP: hostIP, HostPort: hostPort, ContainerPort: 80, Protocol: protocol}
+	}
+
+	tests := []struct {
+		a, b     PortBinding
+		expected bool
+	}{
+		{binding("0.0.0.0", 8080, "tcp"), binding("0.0.0.0", 8080, "tcp"), true},
+		{binding("0.0.0.0", 8080, "tcp"), binding("127.0.0.1", 8080, "tcp"), true},
+		{binding("127.0.0.1", 8080, "tcp"), binding("192.168.1.2", 8080, "tcp"), false},
+		{binding("0.0.0.0", 8080, "tcp"), binding("0.0.0.0", 8081, "tcp"), false},
+		{binding("0.0.0.0", 8080, "tcp"), binding("0.0.0.0", 8080, "udp"), false},
+		{binding("0.0.0.0", 8080, "tcp"), binding("::", 8080, "tcp"), false},
+		{binding("::", 8080, "tcp"), binding("::1", 8080, "tcp"), true},
+		{binding("", 0, "tcp"), binding("", 0, "tcp"), false},
+	}
+
+	for _, tt := range tests {
+		if result := tt.a.Overlaps(tt.b); result != tt.expected {
+			t.Errorf("%s overlaps %s = %t; want %t", tt.a, tt.b, result, tt.expected)
+		}
+	}
+}
+
+func TestNewRecreateOptions(t *testing.T) {
+	inspection := types.ContainerJSON{
+		ContainerJSONBase: &types.ContainerJSONBase{
+			ID: "0123456789abcdef",
+			HostConfig: &container.HostConfig{
+				NetworkMode: "app",
+				PortBindings: nat.PortMap{
+					"443/tcp": {{HostIP: "127.0.0.1", HostPort: "8443"}},
+					"80/tcp":  {{HostPort: "8080"}},
+					"53/udp":  {},
+				},
+			},
+		},
+		Config: &container.Config{Image: "nginx:1.25", Cmd: []string{"nginx", "-g", "daemon off;"}},
+		Mounts: []types.MountPoint{
+			{Type: mount.TypeVolume, Name: "data", Source: "/var/lib/docker/volumes/data/_data", Destination: "/data", RW: true},
+			{Type: mount.TypeBind, Source: "/etc/nginx", Destination: "/etc/nginx", RW: false},
+			{Type: mount.TypeTmpfs, Destination: "/tmp"},
+		},
+		NetworkSettings: &types.NetworkSettings{
+			Networks: map[string]*network.EndpointSettings{"zeta": {}, "app": {}},
+		},
+	}
+
+	options := NewRecreateOptions(inspection, nil)
+
+	if want := []string{"53/udp", "8080:80/tcp", "127.0.0.1:8443:443/tcp"}; !reflect.DeepEqual(options.Ports, want) {
+		t.Errorf("Ports = %q; want %q", options.Ports, want)
+	}
+	if want := []string{"data:/data", "/etc/nginx:/etc/nginx:ro"}; !reflect.DeepEqual(options.Mounts, want) {
+		t.Errorf("Mounts = %q; want %q", options.Mounts, want)
+	}
+	if want := []string{"app", "zeta"}; !reflect.DeepEqual(options.Networks, want) {
+		t.Errorf("Networks = %q; want %q", options.Networks, want)
+	}
+
+	// Settings inherited from the image are left to the image of the new container.
+	inspection.Config.Env = []string{"PATH=/usr/bin", "NGINX_VERSION=1.25.0", "MODE=production"}
+	inspection.Config.Labels = map[string]string{"maintainer": "nginx", "version": "custom", "team": "web"}
+	imageConfig := &container.Config{
+		Env:    []string{"PATH=/usr/bin", "NGINX_VERSION=1.25.0"},
+		Labels: map[string]string{"maintainer": "nginx", "version": "1.25"},
+		Cmd:    []string{"nginx", "-g", "daemon off;"},
+	}
+	options = NewRecreateOptions(inspection, imageConfig)
+	if len(options.Command) != 0 {
+		t.Errorf("Command = %q; want the image's default", options.Command)
+	}
+	if want := []string{"MODE=production"}; !reflect.DeepEqual(options.Env, want) {
+		t.Errorf("Env = %q; want %q", options.Env, want)
+	}
+	if want := map[string]string{"version": "custom", "team": "web"}; !reflect.DeepEqual(options.Labels, want) {
+		t.Errorf("Labels = %v; want %v", options.Labels, want)
+	}
+}
+
+func TestNewRecreateConfig(t *testing.T) {
+	old := types.ContainerJSON{
+		ContainerJSONBase: &types.ContainerJSONBase{
+			ID: "0123456789abcdef",
+			HostConfig: &container.HostConfig{
+				NetworkMode: "app",
+				Mounts:      []mount.Mount{{Type: mount.TypeTmpfs, Target: "/tmp"}, {Type: mount.TypeVolume, Source: "old", Target: "/old"}},
+			},
+		},
+		Config: &container.Config{Hostname: "0123456789ab", Image: "nginx:1.25", ExposedPorts: nat.PortSet{"80/tcp": {}}},
+		NetworkSettings: &types.NetworkSettings{
+			Networks: map[string]*network.EndpointSettings{"app": {Aliases: []string{"web", "0123456789ab", "abc"}}},
+		},
+	}
+
+	options := RecreateOptions{
+		Image:    "nginx:1.26",
+		Ports:    []string{"8443:443"},
+		Mounts:   []string{"data:/data"},
+		Networks: []string{"app", "zeta"},
+	}
+	config, hostConfig, networkingConfig, err := newRecreateConfig(old, nil, options)
+	if err != nil {
+		t.Fatalf("newRecreateConfig returned error: %v", err)
+	}
+
+	if config.Image != "nginx:1.26" || config.Hostname != "" {
+		t.Errorf("config image %q, hostname %q; want nginx:1.26 and no hostname", config.Image, config.Hostname)
+	}
+	if _, ok := config.ExposedPorts["80/tcp"]; !ok {
+		t.Error("exposed port 80/tcp of the old container is missing")
+	}
+	if bindings := hostConfig.PortBindings["443/tcp"]; len(bindings) != 1 || bindings[0].HostPort != "8443" {
+		t.Errorf("PortBindings[443/tcp] = %v; want host port 8443", bindings)
+	}
+	if len(hostConfig.Mounts) != 1 || hostConfig.Mounts[0].Type != mount.TypeTmpfs {
+		t.Errorf("Mounts = %v; want only the tmpfs mount", hostConfig.Mounts)
+	}
+	if aliases := networkingConfig.EndpointsConfig["app"].Aliases; !reflect.DeepEqual(aliases, []string{"web", "abc"}) {
+		t.Errorf("app aliases = %q; want [web abc]", aliases)
+	}
+	if _, ok := networkingConfig.EndpointsConfig["zeta"]; !ok {
+		t.Error("endpoint for the new network zeta is missing")
+	}
+
+	if _, _, _, err := newRecreateConfig(old, nil, RecreateOptions{Image: "nginx", Networks: []string{"host", "app"}}); err == nil {
+		t.Error("expected an error combining host networking with another network")
+	}
+
+	// Changing the image tag leaves the command and entrypoint to the new image,
+	// unless they were set for the container.
+	oldImageConfig := &container.Config{Entrypoint: []string{"/docker-entrypoint.sh"}, Cmd: []string{"nginx", "-g", "daemon off;"}}
+	old.Config.Entrypoint = oldImageConfig.Entrypoint
+	old.Config.Cmd = oldImageConfig.Cmd
+	upgrade := NewRecreateOptions(old, oldImageConfig)
+	upgrade.Image = "nginx:1.27"
+	config, _, _, err = newRecreateConfig(old, oldImageConfig, upgrade)
+	if err != nil {
+		t.Fatalf("newRecreateConfig returned error: %v", err)
+	}
+	if len(config.Entrypoint) != 0 || len(config.Cmd) != 0 {
+		t.Errorf("entrypoint %q, cmd %q; want those of the new image", config.Entrypoint, config.Cmd)
+	}
+
+	old.Config.Entrypoint = []string{"/custom-entrypoint.sh"}
+	old.Config.Cmd = []string{"nginx-debug", "-g", "daemon off;"}
+	config, _, _, err = newRecreateConfig(old, oldImageConfig, NewRecreateOptions(old, oldImageConfig))
+	if err != nil {
+		t.Fatalf("newRecreateConfig returned error: %v", err)
+	}
+	if !reflect.DeepEqual(config.Entrypoint, old.Config.Entrypoint) || !reflect.DeepEqual(config.Cmd, old.Config.Cmd) {
+		t.Errorf("entrypoint %q, cmd %q; want the ones set for the container", config.Entrypoint, config.Cmd)
+	}
+}
+
+func TestSplitNetworkingConfig(t *testing.T) {
+	hostConfig := &container.HostConfig{NetworkMode: "app"}
+	networkingConfig := &network.NetworkingConfig{EndpointsConfig: map[string]*network.EndpointSettings{
+		"app":  {Aliases: []string{"web"}},
+		"zeta": {},
+		"beta": {},
+	}}
+
+	primary, others := splitNetworkingConfig(hostConfig, networkingConfig)
+	if len(primary.EndpointsConfig) != 1 || primary.EndpointsConfig["app"] == nil || primary.EndpointsConfig["app"].Aliases[0] != "web" {
+		t.Errorf("primary = %v; want only app", primary.EndpointsConfig)
+	}
+	if len(others) != 2 || others["zeta"] == nil || others["beta"] == nil {
+		t.Errorf("others = %v; want beta and zeta", others)
+	}
+
+	single := &network.NetworkingConfig{EndpointsConfig: map[string]*network.EndpointSettings{"app": {}}}
+	if primary, others := splitNetworkingConfig(hostConfig, single); primary != single || others != nil {
+		t.Errorf("expected a single network to be left as is, got %v, %v", primary, others)
+	}
+	if primary, others := splitNetworkingConfig(&container.HostConfig{NetworkMode: "host"}, nil); primary != nil || others != nil {
+		t.Errorf("expected no networking config, got %v, %v", primary, others)
+	}
+}
+
+func TestLogOptionsValidate(t *testing.T) {
+	tests := []struct {
+		options LogOptions
+		isValid bool
+	}{
+		{LogOptions{}, true},
+		{LogOptions{Tail: "all", Since: "15m", Until: "5m"}, true},
+		{LogOptions{Tail: "100", Since: "2024-01-02T15:04:05Z"}, true},
+		{LogOptions{Tail: "-1"}, false},
+		{LogOptions{Tail: "lots"}, false},
+		{LogOptions{Since: "yesterday"}, false},
+		{LogOptions{Until: "15 minutes"}, false},
+	}
+
+	for _, tt := range tests {
+		if err := tt.options.Validate(); (err == nil) != tt.isValid {
+			t.Errorf("%+v.Validate() = %v; want valid %v", tt.options, err, tt.isValid)
+		}
+	}
+}
+
+func TestEntryRelativePath(t *testing.T) {
+	tests := []struct {
+		name     string
+		base     string
+		expected string
+	}{
+		{"nginx", "nginx", ""},
+		{"nginx/", "nginx", ""},
+		{"nginx/conf.d/default.conf", "nginx", "conf.d/default.conf"},
+		{"./bin/sh", ".", "bin/sh"},
+		{"etc/hosts", "/", "etc/hosts"},
+	}
+
+	for _, tt := range tests {
+		if result := entryRelativePath(tt.name, tt.base); result != tt.expected {
+			t.Errorf("entryRelativePath(%q, %q) = %q; want %q", tt.name, tt.base, result, tt.expected)
+		}
+	}
+}
+
+func TestArchiverOptions(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "run.sh"), []byte("#!/bin/sh\n"), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("run.sh", filepath.Join(dir, "start")); err != nil {
+		t.Fatal(err)
+	}
+
+	archive := func(options CopyOptions) map[string]*tar.Header {
+		var buffer bytes.Buffer
+		tarWriter := tar.NewWriter(&buffer)
+		archive := &archiver{tarWriter: tarWriter, options: options, counter: &progressReader{}}
+		if err := archive.add(dir, "app", 0); err != nil {
+			t.Fatalf("add returned error: %v", err)
+		}
+		tarWriter.Close()
+
+		headers := make(map[string]*tar.Header)
+		tarReader := tar.NewReader(&buffer)
+		for {
+			header, err := tarReader.Next()
+			if err != nil {
+				break
+			}
+			headers[header.Name] = header
+		}
+		return headers
+	}
+
+	preserved := archive(CopyOptions{PreserveModes: true, PreserveSymlinks: true})
+	if header := preserved["app/run.sh"]; header == nil || header.Mode&0o777 != 0o750 {
+		t.Errorf("expected app/run.sh with mode 0750, got %+v", header)
+	}
+	if header := preserved["app/start"]; header == nil || header.Typeflag != tar.TypeSymlink || header.Linkname != "run.sh" {
+		t.Errorf("expected app/start to be a symlink to run.sh, got %+v", header)
+	}
+
+	followed := archive(CopyOptions{})
+	if header := followed["app/run.sh"]; header == nil || header.Mode != 0o644 {
+		t.Errorf("expected app/run.sh with mode 0644, got %+v", header)
+	}
+	if header := followed["app/start"]; header == nil || header.Typeflag != tar.TypeReg || header.Size != int64(len("#!/bin/sh\n")) {
+		t.Errorf("expected app/start to be a copy of run.sh, got %+v", header)
+	}
+	if header := followed["app/"]; header == nil || header.Mode != 0o755 {
+		t.Errorf("expected app/ with mode 0755, got %+v", header)
+	}
+}
+
+func TestExtractionHostPath(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.Symlink(os.TempDir(), filepath.Join(dir, "elsewhere")); err != nil {
+		t.Fatal(err)
+	}
+	extraction := &extraction{hostDir: dir}
+
+	if target, err := extraction.hostPath("app/conf/default.conf"); err != nil || target != filepath.Join(dir, "app", "conf", "default.conf") {
+		t.Errorf("hostPath = %q, %v; want a path in %s", target, err, dir)
+	}
+	for _, name := range []string{"../outside", "app/../../outside", "elsewhere/file"} {
+		if _, err := extraction.hostPath(name); err == nil {
+			t.Errorf("hostPath(%q) expected error, got nil", name)
+		}
+	}
+}
+
+func TestExtractArchive(t *testing.T) {
+	var buffer bytes.Buffer
+	tarWriter := tar.NewWriter(&buffer)
+	for _, header := range []*tar.Header{
+		{Name: "bin/", Typeflag: tar.TypeDir, Mode: 0o755},
+		{Name: "bin/gzip", Typeflag: tar.TypeReg, Mode: 0o755, Size: int64(len("#!/bin/sh\n"))},
+		{Name: "bin/gunzip", Typeflag: tar.TypeLink, Linkname: "bin/gzip"},
+	} {
+		if err := tarWriter.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+		if header.Typeflag == tar.TypeReg {
+			tarWriter.Write([]byte("#!/bin/sh\n"))
+		}
+	}
+	tarWriter.Close()
+
+	dir := t.TempDir()
+	extraction := &extraction{hostDir: dir, counter: &progressReader{}}
+	if _, err := extraction.extractArchive(&buffer, "/usr/bin", "bin", "bin"); err != nil {
+		t.Fatalf("extractArchive returned error: %v", err)
+	}
+	original, err := os.Stat(filepath.Join(dir, "bin", "gzip"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	link, err := os.Stat(filepath.Join(dir, "bin", "gunzip"))
+	if err != nil {
+		t.Fatalf("expected bin/gunzip to be extracted: %v", err)
+	}
+	if !os.SameFile(original, link) {
+		t.Error("expected bin/gunzip to be a hard link to bin/gzip")
+	}
+
+	buffer.Reset()
+	tarWriter = tar.NewWriter(&buffer)
+	tarWriter.WriteHeader(&tar.Header{Name: "null", Typeflag: tar.TypeChar, Mode: 0o666, Devmajor: 1, Devminor: 3})
+	tarWriter.Close()
+	if _, err := extraction.extractArchive(&buffer, "/dev/null", "null", "null"); err == nil {
+		t.Error("expected an error for a character device")
+	}
+}
+
+func TestNewEvent(t *testing.T) {
+	died := newEvent(events.Message{
+		Type:     events.ContainerEventType,
+		Action:   events.ActionDie,
+		Actor:    events.Actor{ID: strings.Repeat("a", 64), Attributes: map[string]string{"name": "web", "exitCode": "137"}},
+		Time:     1700000000,
+		TimeNano: 1700000000123456789,
+	})
+	if died.Type != "container" || died.Action != "die" || died.Name != "web" || died.Attributes["exitCode"] != "137" {
+		t.Errorf("unexpected event %+v", died)
+	}
+	if !died.Time.Equal(time.Unix(0, 1700000000123456789)) {
+		t.Errorf("expected the time in nanoseconds, got %v", died.Time)
+	}
+
+	deleted := newEvent(events.Message{Type: events.ImageEventType, Action: events.ActionDelete, Actor: events.Actor{ID: "sha256:" + strings.Repeat("b", 64)}, Time: 1700000000})
+	if deleted.Name != strings.Repeat("b", 12) {
+		t.Errorf("expected the short ID as name, got %q", deleted.Name)
+	}
+	if !deleted.Time.Equal(time.Unix(1700000000, 0)) {
+		t.Errorf("expected the time in seconds, got %v", deleted.Time)
+	}
+}
+
+func TestEventOptionsValidate(t *testing.T) {
+	tests := []struct {
+		options EventOptions
+		isValid bool
+	}{
+		{EventOptions{}, true},
+		{EventOptions{Since: "1h"}, true},
+		{EventOptions{Since: "2024-01-02T15:04:05"}, true},
+		{EventOptions{Since: "yesterday"}, false},
+	}
+
+	for _, tt := range tests {
+		if err := tt.options.Validate(); (err == nil) != tt.isValid {
+			t.Errorf("%+v.Validate() = %v; want valid %v", tt.options, err, tt.isValid)
+		}
+	}
+}
+
+func TestNewIgnoreMatcher(t *testing.T) {
+	tests := []struct {
+		name       string
+		patterns   []string
+		dockerfile string
+		path       string
+		ignored    bool
+	}{
+		{"no patterns", nil, "Dockerfile", "src/main.go", false},
+		{"plain match", []string{"*.log"}, "Dockerfile", "debug.log", true},
+		{"only at the root", []string{"*.log"}, "Dockerfile", "logs/debug.log", false},
+		{"any depth", []string{"**/*.log"}, "Dockerfile", "logs/2024/debug.log", true},
+		{"directory contents", []string{"node_modules"}, "Dockerfile", "node_modules/left-pad/index.js", true},
+		{"exception", []string{"*", "!src"}, "Dockerfile", "src/main.go", false},
+		{"excluded by wildcard", []string{"*", "!src"}, "Dockerfile", "README.md", true},
+		{"last pattern wins", []string{"docs", "!docs/api", "docs/api/internal"}, "Dockerfile", "docs/api/internal/x.md", true},
+		{"dockerfile always sent", []string{"*", "!src"}, "Dockerfile", "Dockerfile", false},
+		{"custom dockerfile always sent", []string{"*"}, "docker/app.Dockerfile", "docker/app.Dockerfile", false},
+		{"dockerignore always sent", []string{"*"}, "Dockerfile", ".dockerignore", false},
+		{"dockerfile outside of the context", []string{"*"}, "../Dockerfile", "Dockerfile", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matcher, err := newIgnoreMatcher(tt.patterns, tt.dockerfile)
+			if err != nil {
+				t.Fatalf("newIgnoreMatcher returned error: %v", err)
+			}
+			ignored, err := matcher.MatchesOrParentMatches(filepath.FromSlash(tt.path))
+			if err != nil {
+				t.Fatalf("MatchesOrParentMatches returned error: %v", err)
+			}
+			if ignored != tt.ignored {
+				t.Errorf("%q ignored = %v; want %v", tt.path, ignored, tt.ignored)
+			}
+		})
+	}
+}
+
+func TestParseComposeRestart(t *testing.T) {
+	tests := []struct {
+		restart string
+		want    container.RestartPolicy
+		wantErr bool
+	}{
+		{"", container.RestartPolicy{Name: container.RestartPolicyDisabled}, false},
+		{"no", container.RestartPolicy{Name: container.RestartPolicyDisabled}, false},
+		{"always", container.RestartPolicy{Name: container.RestartPolicyAlways}, false},
+		{"unless-stopped", container.RestartPolicy{Name: container.RestartPolicyUnlessStopped}, false},
+		{"on-failure", container.RestartPolicy{Name: container.RestartPolicyOnFailure}, false},
+		{"on-failure:5", container.RestartPolicy{Name: container.RestartPolicyOnFailure, MaximumRetryCount: 5}, false},
+		{"on-failure:many", container.RestartPolicy{}, true},
+		{"always:3", container.RestartPolicy{}, true},
+		{"sometimes", container.RestartPolicy{}, true},
+	}
+
+	for _, tt := range tests {
+		policy, err := parseComposeRestart(tt.restart)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseComposeRestart(%q) error = %v; wantErr %v", tt.restart, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && policy != tt.want {
+			t.Errorf("parseComposeRestart(%q) = %+v; want %+v", tt.restart, policy, tt.want)
+		}
+	}
+}
+
+func TestNewServiceConfig(t *testing.T) {
+	project := &compose.Project{
+		Name:       "shop",
+		Path:       "/srv/shop/compose.yaml",
+		WorkingDir: "/srv/shop",
+		Networks: map[string]compose.Network{
+			"default":  {Name: "shop_default"},
+			"backend":  {Name: "shop_backend"},
+			"frontend": {Name: "shop_frontend"},
+		},
+	}
+	web := compose.Service{
+		Name:        "web",
+		Image:       "nginx:1.26",
+		Command:     []string{"nginx", "-g", "daemon off;"},
+		Environment: []string{"MODE=production"},
+		Ports:       []string{"8080:80"},
+		Volumes:     []string{"/srv/shop/html:/usr/share/nginx/html:ro"},
+		Networks:    map[string]compose.ServiceNetwork{"frontend": {}, "backend": {Aliases: []string{"www"}}},
+		Restart:     "on-failure:3",
+		Labels:      map[string]string{"com.example.team": "shop"},
+	}
+	db := compose.Service{Name: "db", Image: "postgres:16", NetworkMode: "service:web"}
+	project.Services = []compose.Service{db, web}
+
+	tests := []struct {
+		name  string
+		check func(t *testing.T, config *container.Config, hostConfig *container.HostConfig, networkingConfig *network.NetworkingConfig)
+	}{
+		{"web", func(t *testing.T, config *container.Config, hostConfig *container.HostConfig, networkingConfig *network.NetworkingConfig) {
+			if config.Image != "nginx:1.26" || !reflect.DeepEqual([]string(config.Cmd), web.Command) || !reflect.DeepEqual(config.Env, web.Environment) {
+				t.Errorf("config image %q, cmd %q, env %q", config.Image, config.Cmd, config.Env)
+			}
+			if config.Labels[composeProjectLabel] != "shop" || config.Labels[composeServiceLabel] != "web" || config.Labels["com.example.team"] != "shop" || config.Labels[composeConfigHashLabel] == "" {
+				t.Errorf("Labels = %v", config.Labels)
+			}
+			if _, ok := config.ExposedPorts["80/tcp"]; !ok {
+				t.Error("port 80/tcp is not exposed")
+			}
+			if bindings := hostConfig.PortBindings["80/tcp"]; len(bindings) != 1 || bindings[0].HostPort != "8080" {
+				t.Errorf("PortBindings[80/tcp] = %v; want host port 8080", bindings)
+			}
+			if !reflect.DeepEqual(hostConfig.Binds, web.Volumes) {
+				t.Errorf("Binds = %q; want %q", hostConfig.Binds, web.Volumes)
+			}
+			if hostConfig.RestartPolicy != (container.RestartPolicy{Name: container.RestartPolicyOnFailure, MaximumRetryCount: 3}) {
+				t.Errorf("RestartPolicy = %+v", hostConfig.RestartPolicy)
+			}
+			// The first network in name order is the network mode.
+			if hostConfig.NetworkMode != "shop_backend" {
+				t.Errorf("NetworkMode = %q; want shop_backend", hostConfig.NetworkMode)
+			}
+			if aliases := networkingConfig.EndpointsConfig["shop_backend"].Aliases; !reflect.DeepEqual(aliases, []string{"web", "www"}) {
+				t.Errorf("backend aliases = %q; want [web www]", aliases)
+			}
+			if aliases := networkingConfig.EndpointsConfig["shop_frontend"].Aliases; !reflect.DeepEqual(aliases, []string{"web"}) {
+				t.Errorf("frontend aliases = %q; want [web]", aliases)
+			}
+		}},
+		{"db", func(t *testing.T, config *container.Config, hostConfig *container.HostConfig, networkingConfig *network.NetworkingConfig) {
+			if hostConfig.NetworkMode != "container:shop-web-1" || networkingConfig != nil {
+				t.Errorf("NetworkMode = %q, networking %v; want the network of the web container", hostConfig.NetworkMode, networkingConfig)
+			}
+			if hostConfig.RestartPolicy.Name != container.RestartPolicyDisabled {
+				t.Errorf("RestartPolicy = %+v; want disabled", hostConfig.RestartPolicy)
+			}
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, err := project.Service(tt.name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			config, hostConfig, networkingConfig, err := newServiceConfig(project, service)
+			if err != nil {
+				t.Fatalf("newServiceConfig returned error: %v", err)
+			}
+			tt.check(t, config, hostConfig, networkingConfig)
+		})
+	}
+
+	changed := web
+	changed.Image = "nginx:1.27"
+	before, _, _, _ := newServiceConfig(project, web)
+	after, _, _, err := newServiceConfig(project, changed)
+	if err != nil {
+		t.Fatalf("newServiceConfig returned error: %v", err)
+	}
+	if before.Labels[composeConfigHashLabel] == after.Labels[composeConfigHashLabel] {
+		t.Error("expected the config hash to change with the service")
+	}
+
+	if _, _, _, err := newServiceConfig(project, compose.Service{Name: "bad", Image: "nginx", Ports: []string{"80:http"}}); err == nil {
+		t.Error("expected an error for an invalid port")
+	}
+}
+
+func TestIsCurrentServiceContainer(t *testing.T) {
+	config := &container.Config{Labels: map[string]string{composeConfigHashLabel: "hash"}}
+
+	tests := []struct {
+		name     string
+		existing types.Container
+		expected bool
+	}{
+		{"same config and image", types.Container{ImageID: "sha256:new", Labels: map[string]string{composeConfigHashLabel: "hash"}}, true},
+		{"image pulled or built again", types.Container{ImageID: "sha256:old", Labels: map[string]string{composeConfigHashLabel: "hash"}}, false},
+		{"config changed", types.Container{ImageID: "sha256:new", Labels: map[string]string{composeConfigHashLabel: "old"}}, false},
+		{"not created by compose", types.Container{ImageID: "sha256:new"}, false},
+	}
+
+	for _, tt := range tests {
+		if current := isCurrentServiceContainer(tt.existing, config, "sha256:new"); current != tt.expected {
+			t.Errorf("%s: isCurrentServiceContainer = %v; want %v", tt.name, current, tt.expected)
+		}
+	}
+}
+
+func TestLimitedBuffer(t *testing.T) {
+	buffer := limitedBuffer{limit: 5}
+	for _, data := range []string{"abc", "defg", "hij"} {
+		if written, err := buffer.Write([]byte(data)); written != len(data) || err != nil {
+			t.Errorf("Write(%q) = %d, %v; want %d, nil", data, written, err, len(data))
+		}
+	}
+	if result := buffer.String(); result != "abcde" {
+		t.Errorf("String() = %q; want %q", result, "abcde")
+	}
+	if !buffer.truncated {
+		t.Error("truncated = false; want true")
+	}
+
+	untouched := limitedBuffer{limit: 5}
+	untouched.Write([]byte("abcde"))
+	if untouched.truncated {
+		t.Error("truncated = true for output within the limit; want false")
+	}
+}
+
+func TestIsShortIDAlias(t *testing.T) {
+	tests := []struct {
+		alias    string
+		expected bool
+	}{
+		{"0123456789ab", true},
+		{"0123", false},
+		{"cafe", false},
+		{"0123456789abcdef", false},
+		{"web", false},
+	}
+
+	for _, tt := range tests {
+		if result := IsShortIDAlias("0123456789abcdef", tt.alias); result != tt.expected {
+			t.Errorf("IsShortIDAlias(%q) = %v; want %v", tt.alias, result, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,629 @@
+package client
+
+import (
+	"archive/tar"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/client"
+	"github.com/docker/go-connections/nat"
+	"github.com/givensuman/containertui/internal/compose"
+	"github.com/moby/patternmatcher"
+	"github.com/moby/patternmatcher/ignorefile"
+)
+
+// The labels docker compose marks its resources with, so that projects can be
+// managed by either tool.
+const (
+	composeProjectLabel    = "com.docker.compose.project"
+	composeServiceLabel    = "com.docker.compose.service"
+	composeNumberLabel     = "com.docker.compose.container-number"
+	composeOneoffLabel     = "com.docker.compose.oneoff"
+	composeConfigHashLabel = "com.docker.compose.config-hash"
+	composeWorkingDirLabel = "com.docker.compose.project.working_dir"
+	composeConfigFileLabel = "com.docker.compose.project.config_files"
+	composeNetworkLabel    = "com.docker.compose.network"
+	composeVolumeLabel     = "com.docker.compose.volume"
+)
+
+// GetComposeContainers returns the containers of a project by service name.
+func (clientWrapper *ClientWrapper) GetComposeContainers(projectName string) (map[string][]Container, error) {
+	containers, err := clientWrapper.listComposeContainers(projectName)
+	if err != nil {
+		return nil, err
+	}
+	health, err := clientWrapper.getContainerHealth(filters.NewArgs(filters.Arg("label", composeProjectLabel+"="+projectName)))
+	if err != nil {
+		return nil, err
+	}
+
+	byService := make(map[string][]Container)
+	for _, containerItem := range containers {
+		service := containerItem.Labels[composeServiceLabel]
+		byService[service] = append(byService[service], Container{
+			ID:     containerItem.ID,
+			Name:   strings.TrimPrefix(containerItem.Names[0], "/"),
+			Image:  containerItem.Image,
+			State:  containerItem.State,
+			Health: health[containerItem.ID],
+		})
+	}
+
+	return byService, nil
+}
+
+func (clientWrapper *ClientWrapper) listComposeContainers(projectName string) ([]types.Container, error) {
+	return clientWrapper.client.ContainerList(context.Background(), container.ListOptions{
+		All:     true,
+		Filters: filters.NewArgs(filters.Arg("label", composeProjectLabel+"="+projectName)),
+	})
+}
+
+// ComposeUp creates and starts the containers of the services and of the
+// services they depend on, all services if none is given. Images are built or
+// pulled when missing, and containers whose configuration or image changed
+// are recreated.
+func (clientWrapper *ClientWrapper) ComposeUp(project *compose.Project, serviceNames []string) error {
+	ctx := context.Background()
+
+	services, err := project.Order(serviceNamesOrAll(project, serviceNames))
+	if err != nil {
+		return err
+	}
+	if err := clientWrapper.ensureComposeResources(project, services); err != nil {
+		return err
+	}
+
+	containers, err := clientWrapper.listComposeContainers(project.Name)
+	if err != nil {
+		return err
+	}
+
+	for _, service := range services {
+		imageID, err := clientWrapper.ensureServiceImage(project, service)
+		if err != nil {
+			return fmt.Errorf("%s: %w", service.Name, err)
+		}
+
+		config, hostConfig, networkingConfig, err := newServiceConfig(project, service)
+		if err != nil {
+			return fmt.Errorf("%s: %w", service.Name, err)
+		}
+
+		upToDate := false
+		for _, existing := range containers {
+			if existing.Labels[composeServiceLabel] != service.Name {
+				continue
+			}
+			if isCurrentServiceContainer(existing, config, imageID) && !upToDate {
+				upToDate = true
+				if existing.State != "running" {
+					if err := clientWrapper.client.ContainerStart(ctx, existing.ID, container.StartOptions{}); err != nil {
+						return fmt.Errorf("%s: %w", service.Name, err)
+					}
+				}
+				continue
+			}
+			if err := clientWrapper.client.ContainerRemove(ctx, existing.ID, container.RemoveOptions{Force: true}); err != nil {
+				return fmt.Errorf("%s: %w", service.Name, err)
+			}
+		}
+		if upToDate {
+			continue
+		}
+
+		created, err := clientWrapper.createContainer(ctx, config, hostConfig, networkingConfig, project.ContainerName(service))
+		if err != nil {
+			return fmt.Errorf("%s: %w", service.Name, err)
+		}
+		if err := clientWrapper.client.ContainerStart(ctx, created.ID, container.StartOptions{}); err != nil {
+			return fmt.Errorf("%s: %w", service.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// ComposeDown stops and removes the containers of the services. Without
+// services, it removes every container of the project, including those of
+// services which are no longer in the Compose file, and the project's networks.
+// Volumes are kept.
+func (clientWrapper *ClientWrapper) ComposeDown(project *compose.Project, serviceNames []string) error {
+	ctx := context.Background()
+
+	containers, err := clientWrapper.listComposeContainers(project.Name)
+	if err != nil {
+		return err
+	}
+	for _, existing := range containers {
+		if len(serviceNames) > 0 && !contains(serviceNames, existing.Labels[composeServiceLabel]) {
+			continue
+		}
+		if err := clientWrapper.client.ContainerRemove(ctx, existing.ID, container.RemoveOptions{Force: true}); err != nil {
+			return err
+		}
+	}
+	if len(serviceNames) > 0 {
+		return nil
+	}
+
+	networks, err := clientWrapper.client.NetworkList(ctx, types.NetworkListOptions{
+		Filters: filters.NewArgs(filters.Arg("label", composeProjectLabel+"="+project.Name)),
+	})
+	if err != nil {
+		return err
+	}
+	for _, networkItem := range networks {
+		if err := clientWrapper.client.NetworkRemove(ctx, networkItem.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ComposeRestart restarts the existing containers of the services, all services if none is given.
+func (clientWrapper *ClientWrapper) ComposeRestart(project *compose.Project, serviceNames []string) error {
+	ctx := context.Background()
+
+	services, err := project.Order(serviceNamesOrAll(project, serviceNames))
+	if err != nil {
+		return err
+	}
+	containers, err := clientWrapper.listComposeContainers(project.Name)
+	if err != nil {
+		return err
+	}
+
+	for _, service := range services {
+		for _, existing := range containers {
+			if existing.Labels[composeServiceLabel] != service.Name {
+				continue
+			}
+			if err := clientWrapper.client.ContainerRestart(ctx, existing.ID, container.StopOptions{}); err != nil {
+				return fmt.Errorf("%s: %w", service.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ComposePull pulls the images of the services which are not built, all services if none is given.
+func (clientWrapper *ClientWrapper) ComposePull(project *compose.Project, serviceNames []string) error {
+	for _, name := range serviceNamesOrAll(project, serviceNames) {
+		service, err := project.Service(name)
+		if err != nil {
+			return err
+		}
+		if service.Build != nil {
+			continue
+		}
+		if err := clientWrapper.pullImage(service.Image); err != nil {
+			return fmt.Errorf("%s: %w", service.Name, err)
+		}
+	}
+	return nil
+}
+
+// ComposeBuild builds the images of the services which have a build section, all services if none is given.
+func (clientWrapper *ClientWrapper) ComposeBuild(project *compose.Project, serviceNames []string) error {
+	built := 0
+	for _, name := range serviceNamesOrAll(project, serviceNames) {
+		service, err := project.Service(name)
+		if err != nil {
+			return err
+		}
+		if service.Build == nil {
+			continue
+		}
+		if err := clientWrapper.buildImage(service); err != nil {
+			return fmt.Errorf("%s: %w", service.Name, err)
+		}
+		built++
+	}
+	if built == 0 {
+		return errors.New("no service to build, they all use an image")
+	}
+	return nil
+}
+
+func serviceNamesOrAll(project *compose.Project, serviceNames []string) []string {
+	if len(serviceNames) == 0 {
+		return project.ServiceNames()
+	}
+	return serviceNames
+}
+
+func contains(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
+
+// ensureComposeResources creates the networks and volumes the services use
+// which do not exist yet.
+func (clientWrapper *ClientWrapper) ensureComposeResources(project *compose.Project, services []compose.Service) error {
+	ctx := context.Background()
+
+	for key, projectNetwork := range project.Networks {
+		used := false
+		for _, service := range services {
+			if _, ok := service.Networks[key]; ok {
+				used = true
+			}
+		}
+		if !used {
+			continue
+		}
+
+		_, err := clientWrapper.client.NetworkInspect(ctx, projectNetwork.Name, types.NetworkInspectOptions{})
+		if err == nil {
+			continue
+		}
+		if !client.IsErrNotFound(err) {
+			return err
+		}
+		if projectNetwork.External {
+			return fmt.Errorf("external network %s does not exist", projectNetwork.Name)
+		}
+
+		labels := map[string]string{composeProjectLabel: project.Name, composeNetworkLabel: key}
+		for label, value := range projectNetwork.Labels {
+			labels[label] = value
+		}
+		if _, err := clientWrapper.client.NetworkCreate(ctx, projectNetwork.Name, types.NetworkCreate{
+			Driver: projectNetwork.Driver,
+			Labels: labels,
+		}); err != nil {
+			return err
+		}
+	}
+
+	for key, projectVolume := range project.Volumes {
+		used := false
+		for _, service := range services {
+			for _, mount := range service.Volumes {
+				if strings.HasPrefix(mount, projectVolume.Name+":") {
+					used = true
+				}
+			}
+		}
+		if !used {
+			continue
+		}
+
+		_, err := clientWrapper.client.VolumeInspect(ctx, projectVolume.Name)
+		if err == nil {
+			continue
+		}
+		if !client.IsErrNotFound(err) {
+			return err
+		}
+		if projectVolume.External {
+			return fmt.Errorf("external volume %s does not exist", projectVolume.Name)
+		}
+
+		labels := map[string]string{composeProjectLabel: project.Name, composeVolumeLabel: key}
+		for label, value := range projectVolume.Labels {
+			labels[label] = value
+		}
+		if _, err := clientWrapper.client.VolumeCreate(ctx, volume.CreateOptions{
+			Name:   projectVolume.Name,
+			Driver: projectVolume.Driver,
+			Labels: labels,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// newServiceConfig derives the configuration of the container of a service.
+// It is labelled with a hash of the service, so that changes to the Compose
+// file can be detected.
+func newServiceConfig(project *compose.Project, service compose.Service) (*container.Config, *container.HostConfig, *network.NetworkingConfig, error) {
+	exposedPorts, portBindings, err := nat.ParsePortSpecs(service.Ports)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	restartPolicy, err := parseComposeRestart(service.Restart)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	serialized, err := json.Marshal(service)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	hash := sha256.Sum256(serialized)
+
+	labels := map[string]string{
+		composeProjectLabel:    project.Name,
+		composeServiceLabel:    service.Name,
+		composeNumberLabel:     "1",
+		composeOneoffLabel:     "False",
+		composeConfigHashLabel: hex.EncodeToString(hash[:]),
+		composeWorkingDirLabel: project.WorkingDir,
+		composeConfigFileLabel: project.Path,
+	}
+	for label, value := range service.Labels {
+		labels[label] = value
+	}
+
+	config := &container.Config{
+		Image:        service.Image,
+		Cmd:          service.Command,
+		Entrypoint:   service.Entrypoint,
+		Env:          service.Environment,
+		Labels:       labels,
+		WorkingDir:   service.WorkingDir,
+		User:         service.User,
+		Hostname:     service.Hostname,
+		Tty:          service.Tty,
+		OpenStdin:    service.StdinOpen,
+		ExposedPorts: exposedPorts,
+	}
+	hostConfig := &container.HostConfig{
+		Binds:         service.Volumes,
+		PortBindings:  portBindings,
+		RestartPolicy: restartPolicy,
+	}
+
+	if service.NetworkMode != "" {
+		hostConfig.NetworkMode = container.NetworkMode(service.NetworkMode)
+		if dependency, ok := strings.CutPrefix(service.NetworkMode, "service:"); ok {
+			dependencyService, err := project.Service(dependency)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			hostConfig.NetworkMode = container.NetworkMode("container:" + project.ContainerName(dependencyService))
+		}
+		return config, hostConfig, nil, nil
+	}
+
+	keys := make([]string, 0, len(service.Networks))
+	for key := range service.Networks {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	networkingConfig := &network.NetworkingConfig{EndpointsConfig: make(map[string]*network.EndpointSettings, len(keys))}
+	for _, key := range keys {
+		name := project.Networks[key].Name
+		if hostConfig.NetworkMode == "" {
+			hostConfig.NetworkMode = container.NetworkMode(name)
+		}
+		networkingConfig.EndpointsConfig[name] = &network.EndpointSettings{
+			Aliases: append([]string{service.Name}, service.Networks[key].Aliases...),
+		}
+	}
+
+	return config, hostConfig, networkingConfig, nil
+}
+
+func parseComposeRestart(restart string) (container.RestartPolicy, error) {
+	name, retries, hasRetries := strings.Cut(restart, ":")
+	policy := container.RestartPolicy{Name: container.RestartPolicyMode(name)}
+	switch name {
+	case "", "no":
+		policy.Name = container.RestartPolicyDisabled
+	case "always", "unless-stopped":
+	case "on-failure":
+		if hasRetries {
+			count, err := strconv.Atoi(retries)
+			if err != nil {
+				return policy, fmt.Errorf("invalid restart retry count %q", retries)
+			}
+			policy.MaximumRetryCount = count
+		}
+		return policy, nil
+	default:
+		return policy, fmt.Errorf("invalid restart policy %q", restart)
+	}
+	if hasRetries {
+		return policy, fmt.Errorf("only on-failure takes a retry count, got %q", restart)
+	}
+	return policy, nil
+}
+
+// isCurrentServiceContainer reports whether a container of a service was
+// created from its current configuration and image. The image name is part of
+// the configuration, but the image it refers to changes when it is pulled or
+// built again, so its ID is compared too.
+func isCurrentServiceContainer(existing types.Container, config *container.Config, imageID string) bool {
+	return existing.Labels[composeConfigHashLabel] == config.Labels[composeConfigHashLabel] && existing.ImageID == imageID
+}
+
+// ensureServiceImage builds or pulls the image of a service when it is
+// missing, and returns its ID.
+func (clientWrapper *ClientWrapper) ensureServiceImage(project *compose.Project, service compose.Service) (string, error) {
+	imageInspect, _, err := clientWrapper.client.ImageInspectWithRaw(context.Background(), service.Image)
+	if err == nil || !client.IsErrNotFound(err) {
+		return imageInspect.ID, err
+	}
+
+	if service.Build != nil {
+		err = clientWrapper.buildImage(service)
+	} else {
+		err = clientWrapper.pullImage(service.Image)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	imageInspect, _, err = clientWrapper.client.ImageInspectWithRaw(context.Background(), service.Image)
+	return imageInspect.ID, err
+}
+
+func (clientWrapper *ClientWrapper) pullImage(imageName string) error {
+	reader, err := clientWrapper.client.ImagePull(context.Background(), imageName, types.ImagePullOptions{})
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	return readJSONMessages(reader)
+}
+
+func (clientWrapper *ClientWrapper) buildImage(service compose.Service) error {
+	buildContext, err := newBuildContext(service.Build.Context, service.Build.Dockerfile)
+	if err != nil {
+		return err
+	}
+
+	buildArgs := make(map[string]*string, len(service.Build.Args))
+	for key, value := range service.Build.Args {
+		buildArgs[key] = &value
+	}
+
+	response, err := clientWrapper.client.ImageBuild(context.Background(), buildContext, types.ImageBuildOptions{
+		Tags:        []string{service.Image},
+		Dockerfile:  service.Build.Dockerfile,
+		BuildArgs:   buildArgs,
+		Target:      service.Build.Target,
+		Remove:      true,
+		ForceRemove: true,
+	})
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	return readJSONMessages(response.Body)
+}
+
+// readJSONMessages consumes the progress of a pull or build, returning the
+// error it reports, if any.
+func readJSONMessages(reader io.Reader) error {
+	decoder := json.NewDecoder(reader)
+	for {
+		var message struct {
+			Error string `json:"error"`
+		}
+		if err := decoder.Decode(&message); errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if message.Error != "" {
+			return errors.New(strings.TrimSpace(message.Error))
+		}
+	}
+}
+
+// newBuildContext archives a build context directory, leaving out the paths
+// matched by its .dockerignore file.
+func newBuildContext(dir, dockerfile string) (io.Reader, error) {
+	patterns, err := readDockerignore(filepath.Join(dir, ".dockerignore"))
+	if err != nil {
+		return nil, err
+	}
+	ignored, err := newIgnoreMatcher(patterns, dockerfile)
+	if err != nil {
+		return nil, err
+	}
+
+	reader, writer := io.Pipe()
+	go func() {
+		tarWriter := tar.NewWriter(writer)
+		err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			relative, err := filepath.Rel(dir, path)
+			if err != nil || relative == "." {
+				return err
+			}
+			isIgnored, err := ignored.MatchesOrParentMatches(relative)
+			if err != nil {
+				return err
+			}
+			if isIgnored {
+				// Exceptions may re-include paths of an ignored directory.
+				if info.IsDir() && !ignored.Exclusions() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			relative = filepath.ToSlash(relative)
+
+			link := ""
+			if info.Mode()&os.ModeSymlink != 0 {
+				if link, err = os.Readlink(path); err != nil {
+					return err
+				}
+			}
+			header, err := tar.FileInfoHeader(info, link)
+			if err != nil {
+				return err
+			}
+			header.Name = relative
+			if err := tarWriter.WriteHeader(header); err != nil {
+				return err
+			}
+			if !info.Mode().IsRegular() {
+				return nil
+			}
+
+			file, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			_, err = io.Copy(tarWriter, file)
+			return err
+		})
+		if err == nil {
+			err = tarWriter.Close()
+		}
+		writer.CloseWithError(err)
+	}()
+
+	return reader, nil
+}
+
+// readDockerignore reads the patterns of a .dockerignore file, if there is one.
+func readDockerignore(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return ignorefile.ReadAll(file)
+}
+
+// newIgnoreMatcher matches the paths left out of a build context. The
+// Dockerfile and .dockerignore are always sent, as the daemon reads them.
+func newIgnoreMatcher(patterns []string, dockerfile string) (*patternmatcher.PatternMatcher, error) {
+	if len(patterns) > 0 {
+		patterns = append(patterns, "!.dockerignore")
+		if dockerfile = filepath.ToSlash(filepath.Clean(dockerfile)); !filepath.IsAbs(dockerfile) && !strings.HasPrefix(dockerfile, "../") {
+			patterns = append(patterns, "!"+dockerfile)
+		}
+	}
+	return patternmatcher.New(patterns)
+}
@@ -0,0 +1,433 @@
+package client
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+)
+
+// maxSymlinkDepth bounds how many symlinks are followed in a row, which stops loops.
+const maxSymlinkDepth = 16
+
+// CopyOptions configure a copy of files between the host and a container.
+type CopyOptions struct {
+	PreserveModes    bool // Otherwise files are written 0644 and directories 0755.
+	PreserveSymlinks bool // Otherwise symlinks are replaced by the files they point to.
+}
+
+// ListContainerDirectory lists the entries of a directory of a container.
+// Running containers are listed with find and stat when they provide them,
+// others through an archive of the directory, which is slower.
+func (clientWrapper *ClientWrapper) ListContainerDirectory(containerID, dir string) ([]VolumeFile, error) {
+	if state, err := clientWrapper.GetContainerState(containerID); err == nil && state == "running" {
+		if files, err := clientWrapper.listDirectory(containerID, dir); err == nil {
+			return files, nil
+		}
+	}
+
+	reader, stat, err := clientWrapper.client.CopyFromContainer(context.Background(), containerID, dir)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	if !stat.Mode.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", dir)
+	}
+
+	var files []VolumeFile
+	tarReader := tar.NewReader(reader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return files, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		name := entryRelativePath(header.Name, stat.Name)
+		if name == "" || strings.Contains(name, "/") {
+			continue // The directory itself, or a deeper entry.
+		}
+		files = append(files, VolumeFile{
+			Name:    name,
+			Path:    path.Join(dir, name),
+			Size:    header.Size,
+			Mode:    header.FileInfo().Mode(),
+			ModTime: header.ModTime,
+		})
+	}
+}
+
+// entryRelativePath returns the path of an entry of an archive of a
+// container path relative to that path, which the daemon names the entries after.
+func entryRelativePath(name, base string) string {
+	name = strings.TrimSuffix(strings.TrimPrefix(name, "./"), "/")
+	switch {
+	case base == "" || base == "." || base == "/":
+		return name
+	case name == base:
+		return ""
+	case strings.HasPrefix(name, base+"/"):
+		return strings.TrimPrefix(name, base+"/")
+	}
+	return name
+}
+
+// CopyToContainer copies a file or a directory of the host into a directory
+// of a container, which must exist. Existing files are overwritten, and the
+// files belong to the root user of the container.
+func (clientWrapper *ClientWrapper) CopyToContainer(containerID, hostPath, containerDir string, options CopyOptions, progress TransferProgress) error {
+	hostPath = filepath.Clean(hostPath)
+
+	// Measured first, so that progress has a total.
+	measure := &archiver{options: options}
+	if err := measure.add(hostPath, filepath.Base(hostPath), 0); err != nil {
+		return err
+	}
+
+	reader, writer := io.Pipe()
+	archived := make(chan error, 1)
+	go func() {
+		tarWriter := tar.NewWriter(writer)
+		archive := &archiver{
+			tarWriter: tarWriter,
+			options:   options,
+			counter:   &progressReader{total: measure.size, progress: progress},
+		}
+		err := archive.add(hostPath, filepath.Base(hostPath), 0)
+		if err == nil {
+			err = tarWriter.Close()
+		}
+		writer.CloseWithError(err)
+		archived <- err
+	}()
+
+	err := clientWrapper.client.CopyToContainer(context.Background(), containerID, containerDir, reader, types.CopyToContainerOptions{})
+	reader.Close() // Stops the archiver if the daemon failed early.
+
+	// An error reading the host files is the cause of the error of the daemon, if any.
+	if archiveErr := <-archived; archiveErr != nil && !errors.Is(archiveErr, io.ErrClosedPipe) {
+		return archiveErr
+	}
+	return err
+}
+
+// archiver writes files of the host to a tar archive, or only measures them without a writer.
+type archiver struct {
+	tarWriter *tar.Writer
+	options   CopyOptions
+	counter   *progressReader // Reads the contents of the files, counting the bytes.
+	size      int64           // Total size of the regular files.
+}
+
+// add archives the file at hostPath under name, and the contents of
+// directories recursively. depth is the number of symlinks followed to reach it.
+func (archive *archiver) add(hostPath, name string, depth int) error {
+	info, err := os.Lstat(hostPath)
+	if err != nil {
+		return err
+	}
+
+	var link string
+	if info.Mode()&os.ModeSymlink != 0 {
+		if archive.options.PreserveSymlinks {
+			if link, err = os.Readlink(hostPath); err != nil {
+				return err
+			}
+		} else {
+			if depth >= maxSymlinkDepth {
+				return fmt.Errorf("%s: too many levels of symbolic links", hostPath)
+			}
+			if info, err = os.Stat(hostPath); err != nil {
+				return err
+			}
+			depth++
+		}
+	}
+
+	isRegular := info.Mode().IsRegular()
+	if !isRegular && !info.IsDir() && link == "" {
+		return nil // Sockets, devices and pipes are left out, as their contents cannot be copied.
+	}
+
+	if archive.tarWriter == nil {
+		if isRegular {
+			archive.size += info.Size()
+		}
+	} else {
+		if err := archive.writeHeader(info, name, link); err != nil {
+			return err
+		}
+		if isRegular {
+			return archive.writeContents(hostPath, info.Size())
+		}
+	}
+
+	if info.IsDir() {
+		entries, err := os.ReadDir(hostPath)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			if err := archive.add(filepath.Join(hostPath, entry.Name()), path.Join(name, entry.Name()), depth); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (archive *archiver) writeHeader(info os.FileInfo, name, link string) error {
+	header, err := tar.FileInfoHeader(info, link)
+	if err != nil {
+		return err
+	}
+	header.Name = filepath.ToSlash(name)
+	if info.IsDir() {
+		header.Name += "/"
+	}
+	header.Uname, header.Gname = "", ""
+	if !archive.options.PreserveModes {
+		header.Mode = 0o644
+		if info.IsDir() {
+			header.Mode = 0o755
+		}
+	}
+	return archive.tarWriter.WriteHeader(header)
+}
+
+func (archive *archiver) writeContents(hostPath string, size int64) error {
+	file, err := os.Open(hostPath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	archive.counter.reader = file
+	_, err = io.CopyN(archive.tarWriter, archive.counter, size)
+	return err
+}
+
+// CopyFromContainer copies a file or a directory of a container into a
+// directory of the host. Existing files are overwritten. Progress has a
+// total only for regular files, as the size of directories is unknown.
+func (clientWrapper *ClientWrapper) CopyFromContainer(containerID, containerPath, hostDir string, options CopyOptions, progress TransferProgress) error {
+	stat, err := clientWrapper.client.ContainerStatPath(context.Background(), containerID, containerPath)
+	if err != nil {
+		return err
+	}
+
+	counter := &progressReader{progress: progress}
+	if stat.Mode.IsRegular() {
+		counter.total = stat.Size
+	}
+
+	extraction := &extraction{
+		clientWrapper: clientWrapper,
+		containerID:   containerID,
+		hostDir:       filepath.Clean(hostDir),
+		options:       options,
+		counter:       counter,
+	}
+	if err := extraction.extract(containerPath, path.Base(containerPath), 0); err != nil {
+		return err
+	}
+	return extraction.applyDirectoryModes()
+}
+
+// extraction writes archives of a container to a directory of the host.
+type extraction struct {
+	clientWrapper *ClientWrapper
+	containerID   string
+	hostDir       string
+	options       CopyOptions
+	counter       *progressReader
+	directories   map[string]os.FileMode // Modes applied once the directories are filled.
+}
+
+// extract copies the container path to name in the host directory. depth
+// is the number of symlinks followed to reach it.
+func (extraction *extraction) extract(containerPath, name string, depth int) error {
+	reader, stat, err := extraction.clientWrapper.client.CopyFromContainer(context.Background(), extraction.containerID, containerPath)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	links, err := extraction.extractArchive(reader, containerPath, stat.Name, name)
+	if err != nil {
+		return err
+	}
+
+	// The targets of the links are copied in their place, once the archive is read.
+	for _, link := range links {
+		if depth >= maxSymlinkDepth {
+			return fmt.Errorf("%s: too many levels of symbolic links", link.containerPath)
+		}
+		linkStat, err := extraction.clientWrapper.client.ContainerStatPath(context.Background(), extraction.containerID, link.containerPath)
+		if err != nil {
+			return err
+		}
+		if linkStat.LinkTarget == "" {
+			return fmt.Errorf("%s: cannot resolve symbolic link", link.containerPath)
+		}
+		if err := extraction.extract(linkStat.LinkTarget, link.name, depth+1); err != nil {
+			return fmt.Errorf("%s: %w", link.containerPath, err)
+		}
+	}
+	return nil
+}
+
+// followedLink is a symbolic link whose target is copied in its place.
+type followedLink struct {
+	containerPath string
+	name          string
+}
+
+// extractArchive writes the entries of an archive of the container path,
+// whose entries are named after base, to name in the host directory. The
+// symbolic links to follow are returned rather than written.
+func (extraction *extraction) extractArchive(reader io.Reader, containerPath, base, name string) ([]followedLink, error) {
+	var links []followedLink
+
+	tarReader := tar.NewReader(reader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		relativePath := entryRelativePath(header.Name, base)
+		entryName := path.Join(name, relativePath)
+		target, err := extraction.hostPath(entryName)
+		if err != nil {
+			return nil, err
+		}
+
+		mode := header.FileInfo().Mode()
+		switch header.Typeflag {
+		case tar.TypeDir:
+			// A file or a symlink in place of the directory is replaced.
+			if info, err := os.Lstat(target); err == nil && !info.IsDir() {
+				if err := os.Remove(target); err != nil {
+					return nil, err
+				}
+			}
+			if err := os.MkdirAll(target, 0o755); err != nil {
+				return nil, err
+			}
+			if extraction.options.PreserveModes {
+				if extraction.directories == nil {
+					extraction.directories = make(map[string]os.FileMode)
+				}
+				extraction.directories[target] = mode
+			}
+
+		case tar.TypeReg:
+			if err := extraction.writeFile(target, tarReader, mode); err != nil {
+				return nil, err
+			}
+
+		case tar.TypeSymlink:
+			if !extraction.options.PreserveSymlinks {
+				links = append(links, followedLink{containerPath: path.Join(path.Dir(containerPath), base, relativePath), name: entryName})
+				continue
+			}
+			if err := os.Remove(target); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return nil, err
+			}
+			if err := os.Symlink(header.Linkname, target); err != nil {
+				return nil, err
+			}
+
+		case tar.TypeLink:
+			// Later links to a file are archived as hard links to its first entry.
+			linkTarget, err := extraction.hostPath(path.Join(name, entryRelativePath(header.Linkname, base)))
+			if err != nil {
+				return nil, err
+			}
+			if err := os.Remove(target); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return nil, err
+			}
+			if err := os.Link(linkTarget, target); err != nil {
+				return nil, err
+			}
+
+		default:
+			return nil, fmt.Errorf("%s: unsupported entry type %q", entryName, header.Typeflag)
+		}
+	}
+	return links, nil
+}
+
+// hostPath returns where an entry is written, refusing entries which would
+// land outside of the host directory, including through symlinks.
+func (extraction *extraction) hostPath(name string) (string, error) {
+	target := filepath.Join(extraction.hostDir, filepath.FromSlash(name))
+	relativePath, err := filepath.Rel(extraction.hostDir, target)
+	if err != nil || relativePath == ".." || strings.HasPrefix(relativePath, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("refusing to write %s outside of %s", name, extraction.hostDir)
+	}
+
+	parent := extraction.hostDir
+	for _, component := range strings.Split(filepath.Dir(relativePath), string(filepath.Separator)) {
+		if component == "." {
+			continue
+		}
+		parent = filepath.Join(parent, component)
+		if info, err := os.Lstat(parent); err == nil && info.Mode()&os.ModeSymlink != 0 {
+			return "", fmt.Errorf("refusing to write %s through the symbolic link %s", name, parent)
+		}
+	}
+	return target, nil
+}
+
+func (extraction *extraction) writeFile(target string, reader io.Reader, mode os.FileMode) error {
+	// A symlink in place of the file would have it written elsewhere.
+	if info, err := os.Lstat(target); err == nil && info.Mode()&os.ModeSymlink != 0 {
+		if err := os.Remove(target); err != nil {
+			return err
+		}
+	}
+
+	file, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	extraction.counter.reader = reader
+	if _, err := io.Copy(file, extraction.counter); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	if extraction.options.PreserveModes {
+		return os.Chmod(target, mode)
+	}
+	return nil
+}
+
+// applyDirectoryModes sets the modes of the directories last, so that
+// read-only directories could be filled.
+func (extraction *extraction) applyDirectoryModes() error {
+	for directory, mode := range extraction.directories {
+		if err := os.Chmod(directory, mode); err != nil {
+			return err
+		}
+	}
+	return nil
+}
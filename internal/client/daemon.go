@@ -0,0 +1,127 @@
+package client
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/system"
+)
+
+// DaemonInfo describes the Docker daemon the client is connected to.
+type DaemonInfo struct {
+	Name             string // Hostname of the daemon's host.
+	EngineVersion    string
+	APIVersion       string // Newest API version of the daemon.
+	MinAPIVersion    string
+	ClientAPIVersion string // API version negotiated by the client.
+	GoVersion        string
+	GitCommit        string
+	OperatingSystem  string
+	OSType           string
+	Architecture     string
+	KernelVersion    string
+	Experimental     bool
+
+	StorageDriver  string
+	CgroupDriver   string
+	CgroupVersion  string
+	LoggingDriver  string
+	Runtimes       []string // Sorted.
+	DefaultRuntime string
+
+	SecurityOptions []string // e.g. "seccomp (profile=builtin)".
+	Rootless        bool
+
+	CPUs        int
+	MemoryTotal int64
+
+	RegistryMirrors    []string
+	InsecureRegistries []string // Registries and CIDRs, sorted.
+
+	Warnings []string
+}
+
+// GetDaemonInfo retrieves the system information and version of the daemon.
+func (clientWrapper *ClientWrapper) GetDaemonInfo() (DaemonInfo, error) {
+	info, err := clientWrapper.client.Info(context.Background())
+	if err != nil {
+		return DaemonInfo{}, err
+	}
+	version, err := clientWrapper.client.ServerVersion(context.Background())
+	if err != nil {
+		return DaemonInfo{}, err
+	}
+
+	daemonInfo := newDaemonInfo(info, version)
+	daemonInfo.ClientAPIVersion = clientWrapper.client.ClientVersion()
+	return daemonInfo, nil
+}
+
+func newDaemonInfo(info system.Info, version types.Version) DaemonInfo {
+	daemonInfo := DaemonInfo{
+		Name:            info.Name,
+		EngineVersion:   version.Version,
+		APIVersion:      version.APIVersion,
+		MinAPIVersion:   version.MinAPIVersion,
+		GoVersion:       version.GoVersion,
+		GitCommit:       version.GitCommit,
+		OperatingSystem: info.OperatingSystem,
+		OSType:          info.OSType,
+		Architecture:    info.Architecture,
+		KernelVersion:   info.KernelVersion,
+		Experimental:    info.ExperimentalBuild,
+		StorageDriver:   info.Driver,
+		CgroupDriver:    info.CgroupDriver,
+		CgroupVersion:   info.CgroupVersion,
+		LoggingDriver:   info.LoggingDriver,
+		DefaultRuntime:  info.DefaultRuntime,
+		CPUs:            info.NCPU,
+		MemoryTotal:     info.MemTotal,
+		Warnings:        info.Warnings,
+	}
+	if daemonInfo.EngineVersion == "" {
+		daemonInfo.EngineVersion = info.ServerVersion
+	}
+
+	for name := range info.Runtimes {
+		daemonInfo.Runtimes = append(daemonInfo.Runtimes, name)
+	}
+	sort.Strings(daemonInfo.Runtimes)
+
+	securityOptions, err := system.DecodeSecurityOptions(info.SecurityOptions)
+	if err != nil {
+		// Shown as reported, rather than not at all.
+		daemonInfo.SecurityOptions = info.SecurityOptions
+	}
+	for _, securityOption := range securityOptions {
+		if securityOption.Name == "rootless" {
+			daemonInfo.Rootless = true
+		}
+		description := securityOption.Name
+		if len(securityOption.Options) > 0 {
+			options := make([]string, 0, len(securityOption.Options))
+			for _, option := range securityOption.Options {
+				options = append(options, option.Key+"="+option.Value)
+			}
+			description += " (" + strings.Join(options, ", ") + ")"
+		}
+		daemonInfo.SecurityOptions = append(daemonInfo.SecurityOptions, description)
+	}
+
+	if registryConfig := info.RegistryConfig; registryConfig != nil {
+		daemonInfo.RegistryMirrors = registryConfig.Mirrors
+		for _, cidr := range registryConfig.InsecureRegistryCIDRs {
+			daemonInfo.InsecureRegistries = append(daemonInfo.InsecureRegistries, cidr.String())
+		}
+		for name, index := range registryConfig.IndexConfigs {
+			if index != nil && !index.Secure {
+				daemonInfo.InsecureRegistries = append(daemonInfo.InsecureRegistries, name)
+			}
+		}
+		sort.Strings(daemonInfo.InsecureRegistries)
+	}
+
+	return daemonInfo
+}
@@ -0,0 +1,92 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/filters"
+	timetypes "github.com/docker/docker/api/types/time"
+)
+
+// EventTypes are the types of the events streamed by StreamEvents.
+var EventTypes = []string{"container", "image", "volume", "network", "plugin"}
+
+// EventOptions select the events streamed by StreamEvents.
+type EventOptions struct {
+	Since string // A timestamp or a duration relative to now, e.g. "1h", to replay past events first.
+}
+
+// Validate reports options the daemon would reject.
+func (options EventOptions) Validate() error {
+	if options.Since != "" {
+		if _, err := timetypes.GetTimestamp(options.Since, time.Now()); err != nil {
+			return fmt.Errorf("invalid since %q: expected a timestamp or a duration like 1h", options.Since)
+		}
+	}
+	return nil
+}
+
+// Event is something which happened to an object of the daemon.
+type Event struct {
+	Time       time.Time
+	Type       string // One of EventTypes.
+	Action     string // e.g. "die", "oom" or "health_status: unhealthy".
+	ActorID    string
+	Name       string            // Name of the object, or its ID when it has none.
+	Attributes map[string]string // Include the labels of containers.
+}
+
+// StreamEvents sends the events of the daemon to the channel, until the
+// context is cancelled or the connection fails.
+func (clientWrapper *ClientWrapper) StreamEvents(ctx context.Context, options EventOptions, eventChannel chan<- Event) error {
+	eventFilters := filters.NewArgs()
+	for _, eventType := range EventTypes {
+		eventFilters.Add("type", eventType)
+	}
+
+	messages, errs := clientWrapper.client.Events(ctx, types.EventsOptions{Since: options.Since, Filters: eventFilters})
+	for {
+		select {
+		case message := <-messages:
+			select {
+			case eventChannel <- newEvent(message):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		case err := <-errs:
+			if errors.Is(err, io.EOF) {
+				return errors.New("the daemon closed the stream of events")
+			}
+			return err
+		}
+	}
+}
+
+func newEvent(message events.Message) Event {
+	event := Event{
+		Type:       string(message.Type),
+		Action:     string(message.Action),
+		ActorID:    message.Actor.ID,
+		Name:       message.Actor.Attributes["name"],
+		Attributes: message.Actor.Attributes,
+	}
+	if message.TimeNano != 0 {
+		event.Time = time.Unix(0, message.TimeNano)
+	} else {
+		event.Time = time.Unix(message.Time, 0)
+	}
+	if event.Name == "" {
+		// Full IDs are shortened as the CLI does, references of images are kept.
+		event.Name = strings.TrimPrefix(event.ActorID, "sha256:")
+		if len(event.Name) == 64 {
+			event.Name = event.Name[:12]
+		}
+	}
+	return event
+}
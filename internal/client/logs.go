@@ -0,0 +1,138 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	timetypes "github.com/docker/docker/api/types/time"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+// LogOptions select the lines streamed by StreamLogs.
+type LogOptions struct {
+	Follow bool
+	Tail   string // Number of lines from the end of the logs, or "all".
+	Since  string // A timestamp or a duration relative to now, e.g. "10m".
+	Until  string // A timestamp or a duration relative to now.
+}
+
+// Validate reports options the daemon would reject.
+func (options LogOptions) Validate() error {
+	if options.Tail != "" && options.Tail != "all" {
+		if count, err := strconv.Atoi(options.Tail); err != nil || count < 0 {
+			return fmt.Errorf("invalid tail %q: expected a number of lines or \"all\"", options.Tail)
+		}
+	}
+
+	now := time.Now()
+	if options.Since != "" {
+		if _, err := timetypes.GetTimestamp(options.Since, now); err != nil {
+			return fmt.Errorf("invalid since %q: expected a timestamp or a duration like 15m", options.Since)
+		}
+	}
+	if options.Until != "" {
+		if _, err := timetypes.GetTimestamp(options.Until, now); err != nil {
+			return fmt.Errorf("invalid until %q: expected a timestamp or a duration like 15m", options.Until)
+		}
+	}
+	return nil
+}
+
+// LogLine is a line written by a container to its stdout or stderr.
+type LogLine struct {
+	ContainerID string
+	Stream      string // "stdout" or "stderr", always "stdout" for containers with a TTY.
+	Timestamp   time.Time
+	Text        string
+}
+
+// StreamLogs sends the lines of the logs of a container to the channel,
+// until the logs end or the context is cancelled. Each line carries the
+// timestamp the daemon recorded it with.
+func (clientWrapper *ClientWrapper) StreamLogs(ctx context.Context, containerID string, options LogOptions, lines chan<- LogLine) error {
+	inspection, err := clientWrapper.client.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return err
+	}
+
+	reader, err := clientWrapper.OpenLogs(containerID, options)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	// Reading a followed stream only ends when the connection is closed.
+	stop := context.AfterFunc(ctx, func() { reader.Close() })
+	defer stop()
+
+	// The streams of containers without a TTY are multiplexed.
+	if inspection.Config != nil && inspection.Config.Tty {
+		return scanLogLines(ctx, containerID, "stdout", reader, lines)
+	}
+
+	stdoutReader, stdoutWriter := io.Pipe()
+	stderrReader, stderrWriter := io.Pipe()
+	go func() {
+		_, err := stdcopy.StdCopy(stdoutWriter, stderrWriter, reader)
+		stdoutWriter.CloseWithError(err)
+		stderrWriter.CloseWithError(err)
+	}()
+
+	var (
+		group     sync.WaitGroup
+		stderrErr error
+	)
+	group.Add(1)
+	go func() {
+		defer group.Done()
+		stderrErr = scanLogLines(ctx, containerID, "stderr", stderrReader, lines)
+		stderrReader.Close()
+	}()
+	err = scanLogLines(ctx, containerID, "stdout", stdoutReader, lines)
+	stdoutReader.Close()
+	group.Wait()
+
+	if err != nil {
+		return err
+	}
+	return stderrErr
+}
+
+func scanLogLines(ctx context.Context, containerID, stream string, reader io.Reader, lines chan<- LogLine) error {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := parseLogLine(scanner.Text())
+		line.ContainerID = containerID
+		line.Stream = stream
+		select {
+		case lines <- line:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	if err := scanner.Err(); err != nil && ctx.Err() == nil {
+		return err
+	}
+	return ctx.Err()
+}
+
+// parseLogLine splits the timestamp the daemon prefixes each line with from its text.
+func parseLogLine(text string) LogLine {
+	text = strings.TrimSuffix(text, "\r")
+	prefix, rest, found := strings.Cut(text, " ")
+	if !found {
+		prefix, rest = text, ""
+	}
+	timestamp, err := time.Parse(time.RFC3339Nano, prefix)
+	if err != nil {
+		return LogLine{Text: text}
+	}
+	return LogLine{Timestamp: timestamp, Text: rest}
+}
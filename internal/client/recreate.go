@@ -0,0 +1,348 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/go-connections/nat"
+)
+
+// RecreateOptions are the settings of a container which can be changed by
+// replacing it with a new container.
+type RecreateOptions struct {
+	Image    string
+	Env      []string // KEY=value pairs.
+	Ports    []string // In the format of `docker run -p`, e.g. "127.0.0.1:8080:80/tcp".
+	Mounts   []string // In the format of `docker run -v`, e.g. "data:/var/lib/data:ro".
+	Labels   map[string]string
+	Command  []string // Empty to use the image's default command.
+	Networks []string // The first network is the network mode, e.g. "bridge", "host" or "container:db".
+}
+
+// NewRecreateOptions extracts the recreatable settings of an inspected
+// container. The environment variables, labels and command of imageConfig,
+// the configuration of the container's image, are left out, so the new
+// container takes them from its own image; with a nil imageConfig every one
+// is kept.
+func NewRecreateOptions(inspection types.ContainerJSON, imageConfig *container.Config) RecreateOptions {
+	if imageConfig == nil {
+		imageConfig = &container.Config{}
+	}
+
+	options := RecreateOptions{}
+	if inspection.Config != nil {
+		options.Image = inspection.Config.Image
+		options.Env = slices.DeleteFunc(slices.Clone(inspection.Config.Env), func(variable string) bool {
+			return slices.Contains(imageConfig.Env, variable)
+		})
+		if !slices.Equal(inspection.Config.Cmd, imageConfig.Cmd) {
+			options.Command = slices.Clone([]string(inspection.Config.Cmd))
+		}
+		options.Labels = make(map[string]string, len(inspection.Config.Labels))
+		for key, value := range inspection.Config.Labels {
+			if imageValue, ok := imageConfig.Labels[key]; !ok || imageValue != value {
+				options.Labels[key] = value
+			}
+		}
+	}
+
+	if inspection.HostConfig != nil {
+		options.Ports = formatPortBindings(inspection.HostConfig.PortBindings)
+		options.Networks = containerNetworks(inspection)
+	}
+
+	for _, mountPoint := range inspection.Mounts {
+		source := mountPoint.Source
+		switch mountPoint.Type {
+		case mount.TypeVolume:
+			source = mountPoint.Name
+		case mount.TypeBind:
+		default:
+			continue // Tmpfs and other mounts are kept as they are.
+		}
+
+		spec := source + ":" + mountPoint.Destination
+		if !mountPoint.RW {
+			spec += ":ro"
+		}
+		options.Mounts = append(options.Mounts, spec)
+	}
+
+	return options
+}
+
+// formatPortBindings converts the port bindings to `docker run -p` specs, sorted by container port.
+func formatPortBindings(portMap nat.PortMap) []string {
+	ports := make([]nat.Port, 0, len(portMap))
+	for port := range portMap {
+		ports = append(ports, port)
+	}
+	sort.Slice(ports, func(i, j int) bool {
+		if ports[i].Int() != ports[j].Int() {
+			return ports[i].Int() < ports[j].Int()
+		}
+		return ports[i].Proto() < ports[j].Proto()
+	})
+
+	var specs []string
+	for _, port := range ports {
+		target := string(port)
+		if len(portMap[port]) == 0 {
+			specs = append(specs, target)
+			continue
+		}
+		for _, binding := range portMap[port] {
+			spec := binding.HostPort + ":" + target
+			if binding.HostIP != "" {
+				hostIP := binding.HostIP
+				if strings.Contains(hostIP, ":") {
+					hostIP = "[" + hostIP + "]"
+				}
+				spec = hostIP + ":" + spec
+			}
+			specs = append(specs, spec)
+		}
+	}
+
+	return specs
+}
+
+// containerNetworks lists the networks of a container, starting with its network mode.
+func containerNetworks(inspection types.ContainerJSON) []string {
+	networkMode := inspection.HostConfig.NetworkMode
+	primary := string(networkMode)
+	if networkMode.IsDefault() {
+		primary = "bridge"
+	}
+	if networkMode.IsHost() || networkMode.IsNone() || networkMode.IsContainer() {
+		return []string{primary}
+	}
+
+	networks := []string{primary}
+	if inspection.NetworkSettings != nil {
+		var others []string
+		for name := range inspection.NetworkSettings.Networks {
+			if name != primary {
+				others = append(others, name)
+			}
+		}
+		sort.Strings(others)
+		networks = append(networks, others...)
+	}
+
+	return networks
+}
+
+// newRecreateConfig derives the configuration of the replacement of a
+// container, keeping every setting which is not part of the options. An
+// entrypoint which is the one of oldImageConfig, the configuration of the
+// old container's image, is left to the new image.
+func newRecreateConfig(old types.ContainerJSON, oldImageConfig *container.Config, options RecreateOptions) (*container.Config, *container.HostConfig, *network.NetworkingConfig, error) {
+	if options.Image == "" {
+		return nil, nil, nil, fmt.Errorf("an image is required")
+	}
+	if len(options.Networks) == 0 {
+		return nil, nil, nil, fmt.Errorf("a network is required")
+	}
+
+	exposedPorts, portBindings, err := nat.ParsePortSpecs(options.Ports)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	for _, spec := range options.Mounts {
+		if parts := strings.Split(spec, ":"); len(parts) < 2 || len(parts) > 3 || parts[0] == "" || parts[1] == "" {
+			return nil, nil, nil, fmt.Errorf("invalid mount %q, expected source:destination[:options]", spec)
+		}
+	}
+
+	config := *old.Config
+	config.Image = options.Image
+	config.Env = options.Env
+	config.Labels = options.Labels
+	config.Cmd = options.Command
+	if oldImageConfig != nil && slices.Equal(config.Entrypoint, oldImageConfig.Entrypoint) {
+		config.Entrypoint = nil
+	}
+	config.ExposedPorts = make(nat.PortSet, len(old.Config.ExposedPorts)+len(exposedPorts))
+	for port := range old.Config.ExposedPorts {
+		config.ExposedPorts[port] = struct{}{}
+	}
+	for port := range exposedPorts {
+		config.ExposedPorts[port] = struct{}{}
+	}
+	// The daemon defaults the hostname to the short ID, which belongs to the old container.
+	if len(old.ID) >= 12 && config.Hostname == old.ID[:12] {
+		config.Hostname = ""
+	}
+
+	hostConfig := *old.HostConfig
+	hostConfig.PortBindings = portBindings
+	hostConfig.Binds = options.Mounts
+	hostConfig.Mounts = nil
+	for _, hostMount := range old.HostConfig.Mounts {
+		if hostMount.Type == mount.TypeTmpfs {
+			hostConfig.Mounts = append(hostConfig.Mounts, hostMount)
+		}
+	}
+	hostConfig.NetworkMode = container.NetworkMode(options.Networks[0])
+
+	if hostConfig.NetworkMode.IsHost() || hostConfig.NetworkMode.IsNone() || hostConfig.NetworkMode.IsContainer() {
+		if len(options.Networks) > 1 {
+			return nil, nil, nil, fmt.Errorf("%s networking cannot be combined with other networks", options.Networks[0])
+		}
+		return &config, &hostConfig, nil, nil
+	}
+
+	networkingConfig := &network.NetworkingConfig{EndpointsConfig: make(map[string]*network.EndpointSettings, len(options.Networks))}
+	for _, name := range options.Networks {
+		settings := &network.EndpointSettings{}
+		if old.NetworkSettings != nil {
+			if oldSettings, ok := old.NetworkSettings.Networks[name]; ok && oldSettings != nil {
+				settings.IPAMConfig = oldSettings.IPAMConfig
+				settings.Links = oldSettings.Links
+				settings.DriverOpts = oldSettings.DriverOpts
+				for _, alias := range oldSettings.Aliases {
+					if !IsShortIDAlias(old.ID, alias) {
+						settings.Aliases = append(settings.Aliases, alias)
+					}
+				}
+			}
+		}
+		networkingConfig.EndpointsConfig[name] = settings
+	}
+
+	return &config, &hostConfig, networkingConfig, nil
+}
+
+// RecreateContainer replaces a container with a new one using the given
+// options, and returns the ID of the new container. The old container is
+// stopped and renamed out of the way, then removed once the new container has
+// started. If the new container cannot be created or started, the old one is
+// restored under its name and restarted if it was running.
+func (clientWrapper *ClientWrapper) RecreateContainer(containerID string, options RecreateOptions) (string, error) {
+	ctx := context.Background()
+
+	old, err := clientWrapper.client.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return "", err
+	}
+	if old.HostConfig.AutoRemove {
+		return "", fmt.Errorf("containers started with --rm are removed when stopped, so they cannot be recreated")
+	}
+
+	// Without the old image, its entrypoint is carried over to the new container.
+	oldImageConfig, _ := clientWrapper.InspectImageConfig(old.Image)
+	config, hostConfig, networkingConfig, err := newRecreateConfig(old, oldImageConfig, options)
+	if err != nil {
+		return "", err
+	}
+	if err := clientWrapper.ensureImage(options.Image); err != nil {
+		return "", err
+	}
+
+	name := strings.TrimPrefix(old.Name, "/")
+	wasRunning := old.State != nil && (old.State.Running || old.State.Paused)
+	if wasRunning {
+		if err := clientWrapper.client.ContainerStop(ctx, old.ID, container.StopOptions{}); err != nil {
+			return "", err
+		}
+	}
+
+	restore := func(cause error) error {
+		if wasRunning {
+			if err := clientWrapper.client.ContainerStart(ctx, old.ID, container.StartOptions{}); err != nil {
+				return errors.Join(cause, fmt.Errorf("restarting %s: %w", name, err))
+			}
+		}
+		return cause
+	}
+
+	backupName := name + "-old-" + old.ID[:12]
+	if err := clientWrapper.client.ContainerRename(ctx, old.ID, backupName); err != nil {
+		return "", restore(err)
+	}
+
+	rollback := func(cause error) error {
+		if err := clientWrapper.client.ContainerRename(ctx, old.ID, name); err != nil {
+			return errors.Join(cause, fmt.Errorf("renaming %s back: %w", backupName, err))
+		}
+		return restore(cause)
+	}
+
+	created, err := clientWrapper.createContainer(ctx, config, hostConfig, networkingConfig, name)
+	if err != nil {
+		return "", rollback(fmt.Errorf("creating the new container: %w", err))
+	}
+
+	if err := clientWrapper.client.ContainerStart(ctx, created.ID, container.StartOptions{}); err != nil {
+		cause := fmt.Errorf("starting the new container: %w", err)
+		if removeErr := clientWrapper.client.ContainerRemove(ctx, created.ID, container.RemoveOptions{Force: true}); removeErr != nil {
+			cause = errors.Join(cause, fmt.Errorf("removing the new container: %w", removeErr))
+		}
+		return "", rollback(cause)
+	}
+
+	if err := clientWrapper.client.ContainerRemove(ctx, old.ID, container.RemoveOptions{}); err != nil {
+		return created.ID, fmt.Errorf("the new container started, but the old one was kept as %s: %w", backupName, err)
+	}
+
+	return created.ID, nil
+}
+
+// createContainer creates a container connected to every network of the
+// networking config. Daemons before API 1.44 accept a single network when
+// creating a container, so it is created on its primary network and
+// connected to the others before it is started.
+func (clientWrapper *ClientWrapper) createContainer(ctx context.Context, config *container.Config, hostConfig *container.HostConfig, networkingConfig *network.NetworkingConfig, name string) (container.CreateResponse, error) {
+	primary, others := splitNetworkingConfig(hostConfig, networkingConfig)
+
+	created, err := clientWrapper.client.ContainerCreate(ctx, config, hostConfig, primary, nil, name)
+	if err != nil {
+		return created, err
+	}
+
+	names := make([]string, 0, len(others))
+	for networkName := range others {
+		names = append(names, networkName)
+	}
+	sort.Strings(names)
+	for _, networkName := range names {
+		if err := clientWrapper.client.NetworkConnect(ctx, networkName, created.ID, others[networkName]); err != nil {
+			cause := fmt.Errorf("connecting to %s: %w", networkName, err)
+			if removeErr := clientWrapper.client.ContainerRemove(ctx, created.ID, container.RemoveOptions{Force: true}); removeErr != nil {
+				cause = errors.Join(cause, fmt.Errorf("removing the new container: %w", removeErr))
+			}
+			return created, cause
+		}
+	}
+
+	return created, nil
+}
+
+// splitNetworkingConfig separates the endpoint of the network mode of a
+// container, which it is created with, from those of its other networks.
+func splitNetworkingConfig(hostConfig *container.HostConfig, networkingConfig *network.NetworkingConfig) (*network.NetworkingConfig, map[string]*network.EndpointSettings) {
+	if networkingConfig == nil || len(networkingConfig.EndpointsConfig) <= 1 {
+		return networkingConfig, nil
+	}
+
+	primaryName := string(hostConfig.NetworkMode)
+	primary := &network.NetworkingConfig{EndpointsConfig: make(map[string]*network.EndpointSettings, 1)}
+	others := make(map[string]*network.EndpointSettings, len(networkingConfig.EndpointsConfig)-1)
+	for networkName, settings := range networkingConfig.EndpointsConfig {
+		if networkName == primaryName {
+			primary.EndpointsConfig[networkName] = settings
+		} else {
+			others[networkName] = settings
+		}
+	}
+	return primary, others
+}
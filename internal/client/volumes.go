@@ -0,0 +1,355 @@
+package client
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+const (
+	// VolumeHelperImage is the image of the helper containers which give access to the contents of volumes.
+	VolumeHelperImage = "busybox:latest"
+	// VolumeHelperMountPath is where helper containers mount their volume.
+	VolumeHelperMountPath = "/volume"
+	// volumeHelperLabel marks helper containers, so that they can be told apart from the user's.
+	volumeHelperLabel = "containertui.volume-helper"
+	// volumeHelperLifetime bounds how long a helper container which is never removed keeps running.
+	volumeHelperLifetime = 24 * time.Hour
+)
+
+// VolumeFile represents an entry of a directory in a volume, or in a container.
+type VolumeFile struct {
+	Name    string
+	Path    string // Absolute path inside the container.
+	Size    int64
+	Mode    os.FileMode
+	ModTime time.Time
+}
+
+// TransferProgress is called with the number of bytes copied so far, and the
+// expected total, which is 0 if unknown.
+type TransferProgress func(transferred, total int64)
+
+// StartVolumeHelper starts a short-lived container with the volume mounted at
+// VolumeHelperMountPath, pulling VolumeHelperImage if needed. The container
+// should be removed with RemoveVolumeHelper when done, otherwise it exits and
+// removes itself after a day.
+func (clientWrapper *ClientWrapper) StartVolumeHelper(volumeName string, readOnly bool) (string, error) {
+	ctx := context.Background()
+
+	if err := clientWrapper.ensureImage(VolumeHelperImage); err != nil {
+		return "", err
+	}
+
+	config := &container.Config{
+		Image:  VolumeHelperImage,
+		Cmd:    []string{"sleep", strconv.Itoa(int(volumeHelperLifetime.Seconds()))},
+		Labels: map[string]string{volumeHelperLabel: volumeName},
+	}
+	hostConfig := &container.HostConfig{
+		NetworkMode: "none",
+		AutoRemove:  true,
+		Mounts: []mount.Mount{{
+			Type:     mount.TypeVolume,
+			Source:   volumeName,
+			Target:   VolumeHelperMountPath,
+			ReadOnly: readOnly,
+		}},
+	}
+
+	response, err := clientWrapper.client.ContainerCreate(ctx, config, hostConfig, nil, nil, "")
+	if err != nil {
+		return "", err
+	}
+
+	if err := clientWrapper.client.ContainerStart(ctx, response.ID, container.StartOptions{}); err != nil {
+		_ = clientWrapper.RemoveVolumeHelper(response.ID)
+		return "", err
+	}
+
+	return response.ID, nil
+}
+
+// RemoveVolumeHelper stops and removes a helper container started by StartVolumeHelper.
+func (clientWrapper *ClientWrapper) RemoveVolumeHelper(containerID string) error {
+	return clientWrapper.client.ContainerRemove(context.Background(), containerID, container.RemoveOptions{Force: true})
+}
+
+// ensureImage pulls the image unless it is already present.
+func (clientWrapper *ClientWrapper) ensureImage(imageName string) error {
+	_, _, err := clientWrapper.client.ImageInspectWithRaw(context.Background(), imageName)
+	if err == nil || !client.IsErrNotFound(err) {
+		return err
+	}
+
+	reader, err := clientWrapper.client.ImagePull(context.Background(), imageName, types.ImagePullOptions{})
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	_, err = io.Copy(io.Discard, reader)
+	return err
+}
+
+// execOutput runs a command in a running container and returns its stdout.
+// A non-zero exit code is returned as an error carrying stderr.
+func (clientWrapper *ClientWrapper) execOutput(containerID string, command []string) ([]byte, error) {
+	ctx := context.Background()
+
+	execResp, err := clientWrapper.client.ContainerExecCreate(ctx, containerID, types.ExecConfig{
+		Cmd:          command,
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	attachResp, err := clientWrapper.client.ContainerExecAttach(ctx, execResp.ID, types.ExecStartCheck{})
+	if err != nil {
+		return nil, err
+	}
+	defer attachResp.Close()
+
+	var stdout, stderr bytes.Buffer
+	if _, err := stdcopy.StdCopy(&stdout, &stderr, attachResp.Reader); err != nil {
+		return nil, err
+	}
+
+	inspect, err := clientWrapper.client.ContainerExecInspect(ctx, execResp.ID)
+	if err != nil {
+		return nil, err
+	}
+	if inspect.ExitCode != 0 {
+		message := strings.TrimSpace(stderr.String())
+		if message == "" {
+			message = fmt.Sprintf("%s exited with code %d", command[0], inspect.ExitCode)
+		}
+		return nil, errors.New(message)
+	}
+
+	return stdout.Bytes(), nil
+}
+
+// ListVolumeDirectory lists the entries of a directory through a helper container.
+func (clientWrapper *ClientWrapper) ListVolumeDirectory(helperID, dir string) ([]VolumeFile, error) {
+	return clientWrapper.listDirectory(helperID, dir)
+}
+
+// listDirectory lists the entries of a directory of a running container
+// with find and stat, which the container must provide.
+func (clientWrapper *ClientWrapper) listDirectory(containerID, dir string) ([]VolumeFile, error) {
+	output, err := clientWrapper.execOutput(containerID, []string{
+		"find", dir, "-mindepth", "1", "-maxdepth", "1",
+		"-exec", "stat", "-c", "%f %s %Y %n", "{}", "+",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return parseStatOutput(string(output)), nil
+}
+
+// parseStatOutput parses lines of `stat -c "%f %s %Y %n"`, i.e. the raw mode
+// in hex, the size, the modification time and the path.
+func parseStatOutput(output string) []VolumeFile {
+	var files []VolumeFile
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.SplitN(line, " ", 4)
+		if len(fields) != 4 {
+			continue
+		}
+
+		rawMode, err := strconv.ParseUint(fields[0], 16, 32)
+		if err != nil {
+			continue
+		}
+		size, _ := strconv.ParseInt(fields[1], 10, 64)
+		modTime, _ := strconv.ParseInt(fields[2], 10, 64)
+
+		files = append(files, VolumeFile{
+			Name:    path.Base(fields[3]),
+			Path:    fields[3],
+			Size:    size,
+			Mode:    fileModeFromUnix(uint32(rawMode)),
+			ModTime: time.Unix(modTime, 0),
+		})
+	}
+
+	return files
+}
+
+// fileModeFromUnix converts a Unix st_mode to an os.FileMode.
+func fileModeFromUnix(rawMode uint32) os.FileMode {
+	mode := os.FileMode(rawMode & 0o777)
+
+	switch rawMode & 0o170000 {
+	case 0o040000:
+		mode |= os.ModeDir
+	case 0o120000:
+		mode |= os.ModeSymlink
+	case 0o010000:
+		mode |= os.ModeNamedPipe
+	case 0o140000:
+		mode |= os.ModeSocket
+	case 0o020000:
+		mode |= os.ModeDevice | os.ModeCharDevice
+	case 0o060000:
+		mode |= os.ModeDevice
+	}
+
+	if rawMode&0o4000 != 0 {
+		mode |= os.ModeSetuid
+	}
+	if rawMode&0o2000 != 0 {
+		mode |= os.ModeSetgid
+	}
+	if rawMode&0o1000 != 0 {
+		mode |= os.ModeSticky
+	}
+
+	return mode
+}
+
+// ReadVolumeFile reads up to limit bytes of a regular file through a helper container.
+func (clientWrapper *ClientWrapper) ReadVolumeFile(helperID, filePath string, limit int64) ([]byte, error) {
+	reader, _, err := clientWrapper.client.CopyFromContainer(context.Background(), helperID, filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	tarReader := tar.NewReader(reader)
+	header, err := tarReader.Next()
+	if err != nil {
+		return nil, err
+	}
+	if header.Typeflag != tar.TypeReg {
+		return nil, fmt.Errorf("%s is not a regular file", filePath)
+	}
+
+	return io.ReadAll(io.LimitReader(tarReader, limit))
+}
+
+// volumeUsage returns the size of the helper's volume in bytes, as reported by du.
+func (clientWrapper *ClientWrapper) volumeUsage(helperID string) (int64, error) {
+	output, err := clientWrapper.execOutput(helperID, []string{"du", "-s", "-k", VolumeHelperMountPath})
+	if err != nil {
+		return 0, err
+	}
+
+	fields := strings.Fields(string(output))
+	if len(fields) == 0 {
+		return 0, fmt.Errorf("unexpected du output %q", output)
+	}
+
+	kilobytes, err := strconv.ParseInt(fields[0], 10, 64)
+	return kilobytes * 1024, err
+}
+
+// BackupVolume writes the contents of a volume to writer as a gzip compressed
+// tar archive, with paths relative to the root of the volume.
+func (clientWrapper *ClientWrapper) BackupVolume(volumeName string, writer io.Writer, progress TransferProgress) error {
+	helperID, err := clientWrapper.StartVolumeHelper(volumeName, true)
+	if err != nil {
+		return err
+	}
+	defer clientWrapper.RemoveVolumeHelper(helperID)
+
+	total, _ := clientWrapper.volumeUsage(helperID) // Best effort, only used for progress.
+
+	reader, _, err := clientWrapper.client.CopyFromContainer(context.Background(), helperID, VolumeHelperMountPath)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	gzipWriter := gzip.NewWriter(writer)
+	tarWriter := tar.NewWriter(gzipWriter)
+	tarReader := tar.NewReader(&progressReader{reader: reader, total: total, progress: progress})
+
+	// The daemon names entries after the mount path, e.g. "volume/data.db".
+	base := path.Base(VolumeHelperMountPath)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		header.Name = rebaseEntryName(header.Name, base)
+		if err := tarWriter.WriteHeader(header); err != nil {
+			return err
+		}
+		if _, err := io.Copy(tarWriter, tarReader); err != nil {
+			return err
+		}
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		return err
+	}
+	return gzipWriter.Close()
+}
+
+// rebaseEntryName makes a tar entry name under base relative to base, like `tar -C base .` would.
+func rebaseEntryName(name, base string) string {
+	if name != base && !strings.HasPrefix(name, base+"/") {
+		return name
+	}
+	return "." + strings.TrimPrefix(name, base)
+}
+
+// RestoreVolume extracts a tar archive, optionally compressed, into the root of a volume.
+// Existing files with the same paths are overwritten. total is the size of the
+// archive, used only to report progress.
+func (clientWrapper *ClientWrapper) RestoreVolume(volumeName string, archive io.Reader, total int64, progress TransferProgress) error {
+	helperID, err := clientWrapper.StartVolumeHelper(volumeName, false)
+	if err != nil {
+		return err
+	}
+	defer clientWrapper.RemoveVolumeHelper(helperID)
+
+	return clientWrapper.client.CopyToContainer(
+		context.Background(),
+		helperID,
+		VolumeHelperMountPath,
+		&progressReader{reader: archive, total: total, progress: progress},
+		types.CopyToContainerOptions{},
+	)
+}
+
+// progressReader reports the number of bytes read through it.
+type progressReader struct {
+	reader      io.Reader
+	transferred int64
+	total       int64
+	progress    TransferProgress
+}
+
+func (progressReader *progressReader) Read(buffer []byte) (int, error) {
+	n, err := progressReader.reader.Read(buffer)
+	progressReader.transferred += int64(n)
+	if progressReader.progress != nil && n > 0 {
+		progressReader.progress(progressReader.transferred, progressReader.total)
+	}
+	return n, err
+}
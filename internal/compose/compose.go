@@ -0,0 +1,416 @@
+// Package compose loads Compose files into projects which can be run through
+// the engine API, without the docker compose plugin.
+package compose
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultFileNames are the names of Compose files, in order of preference.
+var DefaultFileNames = []string{"compose.yaml", "compose.yml", "docker-compose.yaml", "docker-compose.yml"}
+
+// DefaultNetwork is the network of services which do not declare any.
+const DefaultNetwork = "default"
+
+// envFileName is the file next to the Compose file which defines variables.
+const envFileName = ".env"
+
+// Project is a loaded Compose file.
+type Project struct {
+	Name       string
+	Path       string // Absolute path of the Compose file.
+	WorkingDir string // Directory relative paths are resolved against.
+	Services   []Service
+	Networks   map[string]Network
+	Volumes    map[string]Volume
+}
+
+// Service is a service of a project, run as a single container.
+type Service struct {
+	Name          string
+	Image         string // Defaults to "<project>-<service>" for services which are built.
+	Build         *Build
+	ContainerName string
+	Command       []string
+	Entrypoint    []string
+	Environment   []string // KEY=value pairs, sorted.
+	Ports         []string // In the format of `docker run -p`.
+	Volumes       []string // In the format of `docker run -v`, with absolute host paths.
+	Networks      map[string]ServiceNetwork
+	NetworkMode   string
+	DependsOn     []string
+	Restart       string
+	Labels        map[string]string
+	WorkingDir    string
+	User          string
+	Hostname      string
+	Tty           bool
+	StdinOpen     bool
+}
+
+// Build describes how to build the image of a service.
+type Build struct {
+	Context    string // Absolute path.
+	Dockerfile string // Relative to the context.
+	Args       map[string]string
+	Target     string
+}
+
+// ServiceNetwork is the attachment of a service to a network.
+type ServiceNetwork struct {
+	Aliases []string
+}
+
+// Network is a network declared by a project.
+type Network struct {
+	Name     string // Name of the network in the engine.
+	External bool
+	Driver   string
+	Labels   map[string]string
+}
+
+// Volume is a named volume declared by a project.
+type Volume struct {
+	Name     string // Name of the volume in the engine.
+	External bool
+	Driver   string
+	Labels   map[string]string
+}
+
+// FindFile returns the Compose file of a directory, following DefaultFileNames.
+func FindFile(dir string) (string, error) {
+	for _, name := range DefaultFileNames {
+		path := filepath.Join(dir, name)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("no Compose file in %s", dir)
+}
+
+// Load reads and validates a Compose file. Variables are interpolated from the
+// environment and the .env file next to the Compose file.
+func Load(path string) (*Project, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		if path, err = FindFile(path); err != nil {
+			return nil, err
+		}
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	workingDir := filepath.Dir(path)
+	variables, err := loadEnvFile(filepath.Join(workingDir, envFileName))
+	if err != nil {
+		return nil, err
+	}
+	lookup := func(name string) (string, bool) {
+		if value, ok := os.LookupEnv(name); ok {
+			return value, true
+		}
+		value, ok := variables[name]
+		return value, ok
+	}
+
+	return parse(content, path, lookup)
+}
+
+// parse converts the content of a Compose file into a project.
+func parse(content []byte, path string, lookup func(string) (string, bool)) (*Project, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return nil, err
+	}
+	if err := interpolateNode(&document, lookup); err != nil {
+		return nil, err
+	}
+
+	var file composeFile
+	if err := document.Decode(&file); err != nil {
+		return nil, err
+	}
+	if len(file.Services) == 0 {
+		return nil, errors.New("the Compose file has no services")
+	}
+
+	workingDir := filepath.Dir(path)
+	project := &Project{
+		Name:       file.Name,
+		Path:       path,
+		WorkingDir: workingDir,
+		Networks:   make(map[string]Network),
+		Volumes:    make(map[string]Volume),
+	}
+	if name, ok := lookup("COMPOSE_PROJECT_NAME"); ok && project.Name == "" {
+		project.Name = name
+	}
+	if project.Name == "" {
+		project.Name = filepath.Base(workingDir)
+	}
+	project.Name = normalizeProjectName(project.Name)
+	if project.Name == "" {
+		return nil, fmt.Errorf("cannot derive a project name from %s, set one with name:", workingDir)
+	}
+
+	for key, network := range file.Networks {
+		project.Networks[key] = Network{
+			Name:     resourceName(project.Name, key, network.Name, bool(network.External)),
+			External: bool(network.External),
+			Driver:   network.Driver,
+			Labels:   network.Labels.labels(),
+		}
+	}
+	for key, volume := range file.Volumes {
+		project.Volumes[key] = Volume{
+			Name:     resourceName(project.Name, key, volume.Name, bool(volume.External)),
+			External: bool(volume.External),
+			Driver:   volume.Driver,
+			Labels:   volume.Labels.labels(),
+		}
+	}
+
+	usesDefaultNetwork := false
+	for name, definition := range file.Services {
+		service, err := newService(project, name, definition, lookup)
+		if err != nil {
+			return nil, fmt.Errorf("service %s: %w", name, err)
+		}
+		if _, ok := service.Networks[DefaultNetwork]; ok {
+			usesDefaultNetwork = true
+		}
+		project.Services = append(project.Services, service)
+	}
+	sort.Slice(project.Services, func(i, j int) bool {
+		return project.Services[i].Name < project.Services[j].Name
+	})
+
+	if _, ok := project.Networks[DefaultNetwork]; !ok && usesDefaultNetwork {
+		project.Networks[DefaultNetwork] = Network{Name: project.Name + "_" + DefaultNetwork}
+	}
+
+	for _, service := range project.Services {
+		for _, dependency := range service.DependsOn {
+			if _, err := project.Service(dependency); err != nil {
+				return nil, fmt.Errorf("service %s depends on %w", service.Name, err)
+			}
+		}
+	}
+	if _, err := project.Order(project.ServiceNames()); err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+func newService(project *Project, name string, definition serviceDefinition, lookup func(string) (string, bool)) (Service, error) {
+	service := Service{
+		Name:          name,
+		Image:         definition.Image,
+		ContainerName: definition.ContainerName,
+		Command:       []string(definition.Command),
+		Entrypoint:    []string(definition.Entrypoint),
+		Environment:   pairs(definition.Environment.resolve(lookup)),
+		NetworkMode:   definition.NetworkMode,
+		DependsOn:     definition.DependsOn,
+		Restart:       definition.Restart,
+		Labels:        definition.Labels.labels(),
+		WorkingDir:    definition.WorkingDir,
+		User:          definition.User,
+		Hostname:      definition.Hostname,
+		Tty:           definition.Tty,
+		StdinOpen:     definition.StdinOpen,
+	}
+
+	if definition.Build != nil {
+		contextDir := definition.Build.Context
+		if contextDir == "" {
+			contextDir = "."
+		}
+		if !filepath.IsAbs(contextDir) {
+			contextDir = filepath.Join(project.WorkingDir, contextDir)
+		}
+		service.Build = &Build{
+			Context:    contextDir,
+			Dockerfile: definition.Build.Dockerfile,
+			Args:       definition.Build.Args.resolve(lookup),
+			Target:     definition.Build.Target,
+		}
+		if service.Build.Dockerfile == "" {
+			service.Build.Dockerfile = "Dockerfile"
+		}
+		if service.Image == "" {
+			service.Image = project.Name + "-" + name
+		}
+	}
+	if service.Image == "" {
+		return service, errors.New("either image or build is required")
+	}
+
+	for _, port := range definition.Ports {
+		service.Ports = append(service.Ports, port.spec())
+	}
+
+	for _, volume := range definition.Volumes {
+		spec, err := volume.spec(project)
+		if err != nil {
+			return service, err
+		}
+		service.Volumes = append(service.Volumes, spec)
+	}
+
+	service.Networks = map[string]ServiceNetwork(definition.Networks)
+	if service.NetworkMode != "" && len(service.Networks) > 0 {
+		return service, errors.New("network_mode and networks cannot be combined")
+	}
+	if service.NetworkMode == "" && len(service.Networks) == 0 {
+		service.Networks = map[string]ServiceNetwork{DefaultNetwork: {}}
+	}
+	for network := range service.Networks {
+		if _, ok := project.Networks[network]; !ok && network != DefaultNetwork {
+			return service, fmt.Errorf("undefined network %s", network)
+		}
+	}
+
+	return service, nil
+}
+
+// Service returns the service with the given name.
+func (project *Project) Service(name string) (Service, error) {
+	for _, service := range project.Services {
+		if service.Name == name {
+			return service, nil
+		}
+	}
+	return Service{}, fmt.Errorf("undefined service %s", name)
+}
+
+// ServiceNames returns the names of the services, sorted.
+func (project *Project) ServiceNames() []string {
+	names := make([]string, len(project.Services))
+	for index, service := range project.Services {
+		names[index] = service.Name
+	}
+	return names
+}
+
+// Order returns the services with their dependencies, each after the services it depends on.
+func (project *Project) Order(names []string) ([]Service, error) {
+	var (
+		ordered  []Service
+		visited  = make(map[string]bool)
+		visiting = make(map[string]bool)
+		visit    func(name string) error
+	)
+	visit = func(name string) error {
+		if visited[name] {
+			return nil
+		}
+		if visiting[name] {
+			return fmt.Errorf("dependency cycle through service %s", name)
+		}
+		visiting[name] = true
+
+		service, err := project.Service(name)
+		if err != nil {
+			return err
+		}
+		dependencies := append([]string(nil), service.DependsOn...)
+		sort.Strings(dependencies)
+		for _, dependency := range dependencies {
+			if err := visit(dependency); err != nil {
+				return err
+			}
+		}
+
+		visiting[name] = false
+		visited[name] = true
+		ordered = append(ordered, service)
+		return nil
+	}
+
+	for _, name := range names {
+		if err := visit(name); err != nil {
+			return nil, err
+		}
+	}
+	return ordered, nil
+}
+
+// ContainerName returns the name of the container of a service.
+func (project *Project) ContainerName(service Service) string {
+	if service.ContainerName != "" {
+		return service.ContainerName
+	}
+	return project.Name + "-" + service.Name + "-1"
+}
+
+var invalidProjectNameCharacters = regexp.MustCompile(`[^a-z0-9_-]`)
+
+// normalizeProjectName lowercases the name and removes the characters the engine does not accept.
+func normalizeProjectName(name string) string {
+	name = invalidProjectNameCharacters.ReplaceAllString(strings.ToLower(name), "")
+	return strings.TrimLeft(name, "_-")
+}
+
+// resourceName returns the engine name of a network or volume declared by a project.
+func resourceName(projectName, key, name string, external bool) string {
+	switch {
+	case name != "":
+		return name
+	case external:
+		return key
+	}
+	return projectName + "_" + key
+}
+
+// loadEnvFile reads KEY=value lines, ignoring comments, from an optional file.
+func loadEnvFile(path string) (map[string]string, error) {
+	variables := make(map[string]string)
+
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return variables, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, found := strings.Cut(strings.TrimPrefix(line, "export "), "=")
+		if !found {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		if unquoted, err := strconv.Unquote(value); err == nil && strings.HasPrefix(value, `"`) {
+			value = unquoted
+		} else if len(value) >= 2 && strings.HasPrefix(value, "'") && strings.HasSuffix(value, "'") {
+			value = value[1 : len(value)-1]
+		}
+		variables[strings.TrimSpace(key)] = value
+	}
+
+	return variables, scanner.Err()
+}
@@ -0,0 +1,165 @@
+package compose
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const testComposeFile = `
+name: Shop
+services:
+  web:
+    build: ./web
+    command: nginx -g "daemon off;"
+    ports:
+      - "${WEB_PORT:-8080}:80"
+      - target: 443
+        published: "8443"
+        host_ip: 127.0.0.1
+    environment:
+      API_URL: http://api:${API_PORT}
+      DEBUG:
+    volumes:
+      - ./html:/usr/share/nginx/html:ro
+      - data:/data
+      - /cache
+    depends_on:
+      api:
+        condition: service_healthy
+  api:
+    image: shop/api:${TAG-latest}
+    environment:
+      - MODE=production
+      - API_TOKEN
+    networks:
+      backend:
+        aliases: [service]
+networks:
+  backend:
+volumes:
+  data:
+    external: true
+`
+
+func TestParse(t *testing.T) {
+	lookup := func(name string) (string, bool) {
+		value, ok := map[string]string{"API_PORT": "3000", "API_TOKEN": "s3cret"}[name]
+		return value, ok
+	}
+
+	project, err := parse([]byte(testComposeFile), "/srv/shop/compose.yaml", lookup)
+	if err != nil {
+		t.Fatalf("parse returned error: %v", err)
+	}
+
+	if project.Name != "shop" {
+		t.Errorf("Name = %q; want shop", project.Name)
+	}
+	if names := project.ServiceNames(); !reflect.DeepEqual(names, []string{"api", "web"}) {
+		t.Errorf("ServiceNames() = %q", names)
+	}
+
+	web, _ := project.Service("web")
+	if web.Image != "shop-web" || web.Build == nil || web.Build.Context != "/srv/shop/web" || web.Build.Dockerfile != "Dockerfile" {
+		t.Errorf("web image %q, build %+v", web.Image, web.Build)
+	}
+	if want := []string{"nginx", "-g", "daemon off;"}; !reflect.DeepEqual(web.Command, want) {
+		t.Errorf("web Command = %q; want %q", web.Command, want)
+	}
+	if want := []string{"8080:80", "127.0.0.1:8443:443"}; !reflect.DeepEqual(web.Ports, want) {
+		t.Errorf("web Ports = %q; want %q", web.Ports, want)
+	}
+	// DEBUG has no value and is unset on the host, so it is left out.
+	if want := []string{"API_URL=http://api:3000"}; !reflect.DeepEqual(web.Environment, want) {
+		t.Errorf("web Environment = %q; want %q", web.Environment, want)
+	}
+	if want := []string{"/srv/shop/html:/usr/share/nginx/html:ro", "data:/data", "/cache"}; !reflect.DeepEqual(web.Volumes, want) {
+		t.Errorf("web Volumes = %q; want %q", web.Volumes, want)
+	}
+	if _, ok := web.Networks[DefaultNetwork]; !ok {
+		t.Errorf("web Networks = %v; want the default network", web.Networks)
+	}
+
+	api, _ := project.Service("api")
+	if api.Image != "shop/api:latest" || !reflect.DeepEqual(api.Networks["backend"].Aliases, []string{"service"}) {
+		t.Errorf("api image %q, networks %v", api.Image, api.Networks)
+	}
+	if want := []string{"API_TOKEN=s3cret", "MODE=production"}; !reflect.DeepEqual(api.Environment, want) {
+		t.Errorf("api Environment = %q; want %q", api.Environment, want)
+	}
+
+	if project.Networks["backend"].Name != "shop_backend" || project.Networks[DefaultNetwork].Name != "shop_default" {
+		t.Errorf("Networks = %+v", project.Networks)
+	}
+	if volume := project.Volumes["data"]; volume.Name != "data" || !volume.External {
+		t.Errorf("Volumes[data] = %+v; want the external volume data", volume)
+	}
+
+	ordered, err := project.Order([]string{"web"})
+	if err != nil || len(ordered) != 2 || ordered[0].Name != "api" || ordered[1].Name != "web" {
+		t.Errorf("Order(web) = %v, %v; want api then web", ordered, err)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"no services", "name: x\n"},
+		{"no image", "services:\n  web:\n    command: true\n"},
+		{"undefined network", "services:\n  web:\n    image: nginx\n    networks: [front]\n"},
+		{"undefined volume", "services:\n  web:\n    image: nginx\n    volumes: [data:/data]\n"},
+		{"undefined dependency", "services:\n  web:\n    image: nginx\n    depends_on: [db]\n"},
+		{"cycle", "services:\n  a:\n    image: x\n    depends_on: [b]\n  b:\n    image: x\n    depends_on: [a]\n"},
+		{"required variable", "services:\n  web:\n    image: ${IMAGE:?set the image}\n"},
+	}
+
+	lookup := func(string) (string, bool) { return "", false }
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parse([]byte(tt.content), "/srv/app/compose.yaml", lookup); err == nil {
+				t.Errorf("parse(%q) returned no error", tt.content)
+			}
+		})
+	}
+}
+
+func TestInterpolate(t *testing.T) {
+	lookup := func(name string) (string, bool) {
+		value, ok := map[string]string{"SET": "value", "EMPTY": ""}[name]
+		return value, ok
+	}
+
+	tests := map[string]string{
+		"$SET and ${SET}":     "value and value",
+		"${EMPTY:-default}":   "default",
+		"${EMPTY-default}":    "",
+		"${UNSET-default}":    "default",
+		"$$SET costs $5":      "$SET costs $5",
+		"${SET}_suffix/$SET!": "value_suffix/value!",
+	}
+	for input, expected := range tests {
+		if result, err := interpolate(input, lookup); err != nil || result != expected {
+			t.Errorf("interpolate(%q) = %q, %v; want %q", input, result, err, expected)
+		}
+	}
+}
+
+func TestLoadEnvFile(t *testing.T) {
+	dir := t.TempDir()
+	content := "# comment\nTAG=1.2\nexport NAME=\"shop app\"\nQUOTED='single'\n\n"
+	if err := os.WriteFile(filepath.Join(dir, envFileName), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	variables, err := loadEnvFile(filepath.Join(dir, envFileName))
+	if err != nil {
+		t.Fatalf("loadEnvFile returned error: %v", err)
+	}
+	if want := map[string]string{"TAG": "1.2", "NAME": "shop app", "QUOTED": "single"}; !reflect.DeepEqual(variables, want) {
+		t.Errorf("loadEnvFile() = %v; want %v", variables, want)
+	}
+}
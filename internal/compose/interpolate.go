@@ -0,0 +1,145 @@
+package compose
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// interpolateNode substitutes the variables in the scalar values of the document.
+func interpolateNode(node *yaml.Node, lookup func(string) (string, bool)) error {
+	if node.Kind == yaml.ScalarNode {
+		if !strings.Contains(node.Value, "$") {
+			return nil
+		}
+		value, err := interpolate(node.Value, lookup)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", node.Line, err)
+		}
+		if value != node.Value && node.Style == 0 {
+			node.Tag = "" // Resolve the type of the substituted value, e.g. a number.
+		}
+		node.Value = value
+		return nil
+	}
+
+	for index, child := range node.Content {
+		// The keys of mappings are not interpolated.
+		if node.Kind == yaml.MappingNode && index%2 == 0 {
+			continue
+		}
+		if err := interpolateNode(child, lookup); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// interpolate substitutes $VAR, ${VAR}, ${VAR:-default}, ${VAR-default},
+// ${VAR:?error} and ${VAR?error} in the value. $$ is a literal $.
+func interpolate(value string, lookup func(string) (string, bool)) (string, error) {
+	var builder strings.Builder
+
+	for index := 0; index < len(value); index++ {
+		if value[index] != '$' || index+1 == len(value) {
+			builder.WriteByte(value[index])
+			continue
+		}
+
+		next := value[index+1]
+		switch {
+		case next == '$':
+			builder.WriteByte('$')
+			index++
+
+		case next == '{':
+			end := strings.IndexByte(value[index:], '}')
+			if end < 0 {
+				return "", fmt.Errorf("unterminated variable in %q", value)
+			}
+			substituted, err := substitute(value[index+2:index+end], lookup)
+			if err != nil {
+				return "", err
+			}
+			builder.WriteString(substituted)
+			index += end
+
+		case isNameCharacter(next, true):
+			end := index + 1
+			for end < len(value) && isNameCharacter(value[end], false) {
+				end++
+			}
+			substituted, _ := lookup(value[index+1 : end])
+			builder.WriteString(substituted)
+			index = end - 1
+
+		default:
+			builder.WriteByte('$')
+		}
+	}
+
+	return builder.String(), nil
+}
+
+// substitute resolves the expression between the braces of ${...}.
+func substitute(expression string, lookup func(string) (string, bool)) (string, error) {
+	end := 0
+	for end < len(expression) && isNameCharacter(expression[end], end == 0) {
+		end++
+	}
+	name, operator := expression[:end], expression[end:]
+	if name == "" {
+		return "", fmt.Errorf("invalid variable ${%s}", expression)
+	}
+	value, isSet := lookup(name)
+
+	switch {
+	case operator == "":
+		return value, nil
+	case strings.HasPrefix(operator, ":-"):
+		if value == "" {
+			return operator[2:], nil
+		}
+	case strings.HasPrefix(operator, "-"):
+		if !isSet {
+			return operator[1:], nil
+		}
+	case strings.HasPrefix(operator, ":?"):
+		if value == "" {
+			return "", fmt.Errorf("required variable %s is missing: %s", name, operator[2:])
+		}
+	case strings.HasPrefix(operator, "?"):
+		if !isSet {
+			return "", fmt.Errorf("required variable %s is missing: %s", name, operator[1:])
+		}
+	default:
+		return "", fmt.Errorf("invalid variable ${%s}", expression)
+	}
+	return value, nil
+}
+
+func isNameCharacter(character byte, isFirst bool) bool {
+	switch {
+	case character == '_', character >= 'a' && character <= 'z', character >= 'A' && character <= 'Z':
+		return true
+	case character >= '0' && character <= '9':
+		return !isFirst
+	}
+	return false
+}
+
+// expandHostPath resolves a host path of a bind mount against the project's directory.
+func expandHostPath(workingDir, path string) string {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, strings.TrimPrefix(path, "~"))
+		}
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(workingDir, path)
+	}
+	return path
+}
@@ -0,0 +1,327 @@
+package compose
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/givensuman/containertui/internal/shellwords"
+	"gopkg.in/yaml.v3"
+)
+
+// The types below mirror the Compose specification, accepting both the short
+// and long syntax of the attributes which have one.
+
+type composeFile struct {
+	Name     string                        `yaml:"name"`
+	Services map[string]serviceDefinition  `yaml:"services"`
+	Networks map[string]resourceDefinition `yaml:"networks"`
+	Volumes  map[string]resourceDefinition `yaml:"volumes"`
+}
+
+type resourceDefinition struct {
+	Name     string       `yaml:"name"`
+	External externalFlag `yaml:"external"`
+	Driver   string       `yaml:"driver"`
+	Labels   keyValues    `yaml:"labels"`
+}
+
+type serviceDefinition struct {
+	Image         string                  `yaml:"image"`
+	Build         *buildDefinition        `yaml:"build"`
+	ContainerName string                  `yaml:"container_name"`
+	Command       commandLine             `yaml:"command"`
+	Entrypoint    commandLine             `yaml:"entrypoint"`
+	Environment   keyValues               `yaml:"environment"`
+	Ports         []portDefinition        `yaml:"ports"`
+	Volumes       []volumeMountDefinition `yaml:"volumes"`
+	Networks      serviceNetworks         `yaml:"networks"`
+	NetworkMode   string                  `yaml:"network_mode"`
+	DependsOn     dependencies            `yaml:"depends_on"`
+	Restart       string                  `yaml:"restart"`
+	Labels        keyValues               `yaml:"labels"`
+	WorkingDir    string                  `yaml:"working_dir"`
+	User          string                  `yaml:"user"`
+	Hostname      string                  `yaml:"hostname"`
+	Tty           bool                    `yaml:"tty"`
+	StdinOpen     bool                    `yaml:"stdin_open"`
+}
+
+// buildDefinition is either a context path or a mapping.
+type buildDefinition struct {
+	Context    string    `yaml:"context"`
+	Dockerfile string    `yaml:"dockerfile"`
+	Args       keyValues `yaml:"args"`
+	Target     string    `yaml:"target"`
+}
+
+func (build *buildDefinition) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		build.Context = node.Value
+		return nil
+	}
+	type plain buildDefinition
+	return node.Decode((*plain)(build))
+}
+
+// commandLine is either a list of arguments or a string split like a shell.
+type commandLine []string
+
+func (command *commandLine) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		words, err := shellwords.Split(node.Value)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", node.Line, err)
+		}
+		*command = words
+		return nil
+	}
+	return node.Decode((*[]string)(command))
+}
+
+// keyValues is either a mapping or a list of KEY=value items. A key
+// without a value, e.g. "- KEY" or "KEY:", has a nil value.
+type keyValues map[string]*string
+
+func (values *keyValues) UnmarshalYAML(node *yaml.Node) error {
+	*values = make(keyValues)
+
+	switch node.Kind {
+	case yaml.SequenceNode:
+		var items []string
+		if err := node.Decode(&items); err != nil {
+			return err
+		}
+		for _, item := range items {
+			if key, value, ok := strings.Cut(item, "="); ok {
+				(*values)[key] = &value
+			} else {
+				(*values)[key] = nil
+			}
+		}
+		return nil
+
+	case yaml.MappingNode:
+		for index := 0; index+1 < len(node.Content); index += 2 {
+			value := node.Content[index+1]
+			if value.Tag == "!!null" {
+				(*values)[node.Content[index].Value] = nil
+				continue
+			}
+			(*values)[node.Content[index].Value] = &value.Value
+		}
+		return nil
+	}
+
+	return fmt.Errorf("line %d: expected a mapping or a list of key=value", node.Line)
+}
+
+// resolve returns the values, taking those of keys without a value from the
+// host environment, as docker compose does. Keys unset on the host are left out.
+func (values keyValues) resolve(lookup func(string) (string, bool)) map[string]string {
+	resolved := make(map[string]string, len(values))
+	for key, value := range values {
+		if value != nil {
+			resolved[key] = *value
+		} else if hostValue, ok := lookup(key); ok {
+			resolved[key] = hostValue
+		}
+	}
+	return resolved
+}
+
+// labels returns the values, keys without a value being empty labels.
+func (values keyValues) labels() map[string]string {
+	labels := make(map[string]string, len(values))
+	for key, value := range values {
+		if value != nil {
+			labels[key] = *value
+		} else {
+			labels[key] = ""
+		}
+	}
+	return labels
+}
+
+// pairs returns the values as sorted KEY=value pairs.
+func pairs(values map[string]string) []string {
+	pairs := make([]string, 0, len(values))
+	for key, value := range values {
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+	return pairs
+}
+
+// externalFlag is either a boolean or, in older files, a mapping with a name.
+type externalFlag bool
+
+func (external *externalFlag) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.MappingNode {
+		*external = true
+		return nil
+	}
+	var value bool
+	if err := node.Decode(&value); err != nil {
+		return err
+	}
+	*external = externalFlag(value)
+	return nil
+}
+
+// portDefinition is either a `docker run -p` string, a number or a mapping.
+type portDefinition struct {
+	short     string
+	Target    int    `yaml:"target"`
+	Published string `yaml:"published"`
+	HostIP    string `yaml:"host_ip"`
+	Protocol  string `yaml:"protocol"`
+}
+
+func (port *portDefinition) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		port.short = node.Value
+		return nil
+	}
+	type plain portDefinition
+	if err := node.Decode((*plain)(port)); err != nil {
+		return err
+	}
+	if port.Target == 0 {
+		return fmt.Errorf("line %d: a port target is required", node.Line)
+	}
+	return nil
+}
+
+// spec returns the port in the format of `docker run -p`.
+func (port portDefinition) spec() string {
+	if port.short != "" {
+		return port.short
+	}
+
+	spec := strconv.Itoa(port.Target)
+	if port.Published != "" {
+		spec = port.Published + ":" + spec
+		if port.HostIP != "" {
+			spec = port.HostIP + ":" + spec
+		}
+	}
+	if port.Protocol != "" {
+		spec += "/" + port.Protocol
+	}
+	return spec
+}
+
+// volumeMountDefinition is either a `docker run -v` string or a mapping.
+type volumeMountDefinition struct {
+	short    string
+	Type     string `yaml:"type"`
+	Source   string `yaml:"source"`
+	Target   string `yaml:"target"`
+	ReadOnly bool   `yaml:"read_only"`
+}
+
+func (volume *volumeMountDefinition) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		volume.short = node.Value
+		return nil
+	}
+	type plain volumeMountDefinition
+	return node.Decode((*plain)(volume))
+}
+
+// spec returns the mount in the format of `docker run -v`, resolving the
+// relative host paths and the names of the project's volumes.
+func (volume volumeMountDefinition) spec(project *Project) (string, error) {
+	source, target, mode := volume.Source, volume.Target, ""
+	if volume.ReadOnly {
+		mode = "ro"
+	}
+	if volume.short != "" {
+		parts := strings.Split(volume.short, ":")
+		switch len(parts) {
+		case 1:
+			source, target = "", parts[0]
+		case 2:
+			source, target = parts[0], parts[1]
+		case 3:
+			source, target, mode = parts[0], parts[1], parts[2]
+		default:
+			return "", fmt.Errorf("invalid volume %q", volume.short)
+		}
+	}
+	if target == "" {
+		return "", errors.New("a volume target is required")
+	}
+	if volume.Type != "" && volume.Type != "bind" && volume.Type != "volume" {
+		return "", fmt.Errorf("unsupported volume type %s", volume.Type)
+	}
+
+	switch {
+	case source == "":
+		return target, nil // Anonymous volume.
+	case strings.HasPrefix(source, "."), strings.HasPrefix(source, "~"), filepath.IsAbs(source):
+		source = expandHostPath(project.WorkingDir, source)
+	default:
+		declared, ok := project.Volumes[source]
+		if !ok {
+			return "", fmt.Errorf("undefined volume %s", source)
+		}
+		source = declared.Name
+	}
+
+	spec := source + ":" + target
+	if mode != "" {
+		spec += ":" + mode
+	}
+	return spec, nil
+}
+
+// serviceNetworks is either a list of networks or a mapping with their options.
+type serviceNetworks map[string]ServiceNetwork
+
+func (networks *serviceNetworks) UnmarshalYAML(node *yaml.Node) error {
+	*networks = make(serviceNetworks)
+
+	switch node.Kind {
+	case yaml.SequenceNode:
+		var names []string
+		if err := node.Decode(&names); err != nil {
+			return err
+		}
+		for _, name := range names {
+			(*networks)[name] = ServiceNetwork{}
+		}
+		return nil
+
+	case yaml.MappingNode:
+		for index := 0; index+1 < len(node.Content); index += 2 {
+			var options struct {
+				Aliases []string `yaml:"aliases"`
+			}
+			if err := node.Content[index+1].Decode(&options); err != nil {
+				return err
+			}
+			(*networks)[node.Content[index].Value] = ServiceNetwork{Aliases: options.Aliases}
+		}
+		return nil
+	}
+
+	return fmt.Errorf("line %d: expected a list or mapping of networks", node.Line)
+}
+
+// dependencies is either a list of services or a mapping with their conditions.
+type dependencies []string
+
+func (services *dependencies) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.MappingNode {
+		for index := 0; index < len(node.Content); index += 2 {
+			*services = append(*services, node.Content[index].Value)
+		}
+		return nil
+	}
+	return node.Decode((*[]string)(services))
+}
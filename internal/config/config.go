@@ -13,6 +13,25 @@ import (
 type Config struct {
 	NoNerdFonts ConfigBool  `yaml:"no-nerd-fonts"`
 	Theme       ThemeConfig `yaml:"colors,omitempty"`
+	ComposeFile string      `yaml:"compose-file,omitempty"` // Compose project opened at startup.
+	Logs        LogsConfig  `yaml:"logs,omitempty"`
+	Alerts      []AlertRule `yaml:"alerts,omitempty"`
+}
+
+// AlertRule raises a notification when a container logs a line matching a pattern.
+type AlertRule struct {
+	Container string `yaml:"container"`          // Glob of the container names, e.g. "api-*".
+	Pattern   string `yaml:"pattern"`            // Regular expression matched against each line.
+	Severity  string `yaml:"severity,omitempty"` // "info", "warning" or "error", the default.
+}
+
+// LogsConfig holds the options the log viewer opens with.
+type LogsConfig struct {
+	Tail       string   `yaml:"tail,omitempty"`       // Number of lines from the end of the logs, or "all".
+	Since      string   `yaml:"since,omitempty"`      // A timestamp or a duration relative to now, e.g. "15m".
+	Until      string   `yaml:"until,omitempty"`      // A timestamp or a duration relative to now.
+	Timestamps string   `yaml:"timestamps,omitempty"` // "local" or "utc" to show the time of each line.
+	Columns    []string `yaml:"columns,omitempty"`    // Fields of JSON lines shown first, in order.
 }
 
 // DefaultConfig returns a default configuration
@@ -20,6 +39,10 @@ func DefaultConfig() *Config {
 	return &Config{
 		NoNerdFonts: false,
 		Theme:       emptyThemeConfig(),
+		Logs: LogsConfig{
+			Tail:    "1000",
+			Columns: []string{"time", "level", "msg"},
+		},
 	}
 }
 
@@ -55,13 +78,14 @@ func LoadFromFile(path string) (*Config, error) {
 		return nil, fmt.Errorf("failed to close file reader: %w", err)
 	}
 
-	var cfg Config
+	// Options missing from the file keep their defaults.
+	cfg := DefaultConfig()
 	decoder := yaml.NewDecoder(file)
-	if err := decoder.Decode(&cfg); err != nil {
+	if err := decoder.Decode(cfg); err != nil {
 		return nil, fmt.Errorf("failed to decode config file: %w", err)
 	}
 
-	return &cfg, nil
+	return cfg, nil
 }
 
 // ConfigDir returns the default configuration directory.
@@ -78,3 +78,27 @@ func TestLoadFromFile(t *testing.T) {
 		t.Error("expected error for invalid YAML, got nil")
 	}
 }
+
+func TestLoadLogsConfig(t *testing.T) {
+	tempFile := filepath.Join(t.TempDir(), "config.yaml")
+
+	// Options missing from the file keep their defaults
+	err := os.WriteFile(tempFile, []byte("logs:\n  since: 15m\n  timestamps: utc\n"), 0o600)
+	if err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cfg, err := LoadFromFile(tempFile)
+	if err != nil {
+		t.Fatalf("LoadFromFile failed: %v", err)
+	}
+	if cfg.Logs.Tail != DefaultConfig().Logs.Tail {
+		t.Errorf("expected default tail %q, got %q", DefaultConfig().Logs.Tail, cfg.Logs.Tail)
+	}
+	if cfg.Logs.Since != "15m" {
+		t.Errorf("expected since 15m, got %q", cfg.Logs.Since)
+	}
+	if cfg.Logs.Timestamps != "utc" {
+		t.Errorf("expected timestamps utc, got %q", cfg.Logs.Timestamps)
+	}
+}
@@ -0,0 +1,85 @@
+// Package shellwords splits and quotes words like a POSIX shell.
+package shellwords
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Split splits the input on whitespace like a POSIX shell, so that words
+// may contain spaces when quoted, e.g. `echo "hello world"` or 'a b'.
+// Backslashes escape the next character outside of single quotes.
+func Split(input string) ([]string, error) {
+	var (
+		words   []string
+		word    strings.Builder
+		inWord  bool
+		quote   rune
+		escaped bool
+	)
+
+	for _, character := range input {
+		switch {
+		case escaped:
+			word.WriteRune(character)
+			escaped = false
+		case quote == '\'':
+			if character == '\'' {
+				quote = 0
+			} else {
+				word.WriteRune(character)
+			}
+		case character == '\\':
+			escaped = true
+			inWord = true
+		case quote == '"':
+			if character == '"' {
+				quote = 0
+			} else {
+				word.WriteRune(character)
+			}
+		case character == '\'' || character == '"':
+			quote = character
+			inWord = true
+		case character == ' ' || character == '\t' || character == '\n':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(character)
+			inWord = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+	if escaped {
+		return nil, fmt.Errorf("trailing backslash")
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+
+	return words, nil
+}
+
+// Join joins the words with spaces, quoting those which Split would
+// otherwise split or unescape.
+func Join(words []string) string {
+	quoted := make([]string, len(words))
+	for index, word := range words {
+		quoted[index] = Quote(word)
+	}
+	return strings.Join(quoted, " ")
+}
+
+// Quote quotes a word for a POSIX shell when it contains special characters.
+func Quote(word string) string {
+	if word != "" && !strings.ContainsAny(word, " \t\n'\"\\$`!*?[]{}()<>|&;#~") {
+		return word
+	}
+	return "'" + strings.ReplaceAll(word, "'", `'\''`) + "'"
+}
@@ -0,0 +1,49 @@
+package shellwords
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+		wantErr  bool
+	}{
+		{"empty", "  ", nil, false},
+		{"plain words", "nginx -g daemon", []string{"nginx", "-g", "daemon"}, false},
+		{"double quotes", `sh -c "echo hello world"`, []string{"sh", "-c", "echo hello world"}, false},
+		{"single quotes", `GREETING='hello "you"'`, []string{`GREETING=hello "you"`}, false},
+		{"escaped space", `a\ b c`, []string{"a b", "c"}, false},
+		{"empty quoted word", `a "" b`, []string{"a", "", "b"}, false},
+		{"unterminated quote", `"abc`, nil, true},
+		{"trailing backslash", `abc\`, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Split(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Split(%q) error = %v; wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Split(%q) = %q; want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestJoinRoundTrip(t *testing.T) {
+	words := []string{"sh", "-c", "echo 'it''s' $HOME", "", "PLAIN=value", `back\slash`}
+
+	quoted := Join(words)
+	result, err := Split(quoted)
+	if err != nil {
+		t.Fatalf("Split(%q) error = %v", quoted, err)
+	}
+	if !reflect.DeepEqual(result, words) {
+		t.Errorf("Split(Join(%q)) = %q", words, result)
+	}
+}
@@ -0,0 +1,808 @@
+// Package compose defines the Compose component, which runs the services of a
+// Compose file through the engine API.
+package compose
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/givensuman/containertui/internal/client"
+	"github.com/givensuman/containertui/internal/colors"
+	"github.com/givensuman/containertui/internal/compose"
+	"github.com/givensuman/containertui/internal/context"
+	"github.com/givensuman/containertui/internal/ui/logs"
+	"github.com/givensuman/containertui/internal/ui/notifications"
+	"github.com/givensuman/containertui/internal/ui/shared"
+	overlay "github.com/rmhubbert/bubbletea-overlay"
+)
+
+type detailsKeybindings struct {
+	Up     key.Binding
+	Down   key.Binding
+	Switch key.Binding
+}
+
+func newDetailsKeybindings() detailsKeybindings {
+	return detailsKeybindings{
+		Up: key.NewBinding(
+			key.WithKeys("up", "k"),
+			key.WithHelp("↑/k", "up"),
+		),
+		Down: key.NewBinding(
+			key.WithKeys("down", "j"),
+			key.WithHelp("↓/j", "down"),
+		),
+		Switch: key.NewBinding(
+			key.WithKeys("tab"),
+			key.WithHelp("tab", "switch focus"),
+		),
+	}
+}
+
+type keybindings struct {
+	open       key.Binding
+	up         key.Binding
+	upAll      key.Binding
+	down       key.Binding
+	downAll    key.Binding
+	restart    key.Binding
+	restartAll key.Binding
+	pull       key.Binding
+	pullAll    key.Binding
+	build      key.Binding
+	buildAll   key.Binding
+	logs       key.Binding
+	refresh    key.Binding
+	switchTab  key.Binding
+}
+
+func newKeybindings() *keybindings {
+	return &keybindings{
+		open: key.NewBinding(
+			key.WithKeys("o"),
+			key.WithHelp("o", "open compose file"),
+		),
+		up: key.NewBinding(
+			key.WithKeys("u"),
+			key.WithHelp("u", "up service"),
+		),
+		upAll: key.NewBinding(
+			key.WithKeys("U"),
+			key.WithHelp("U", "up project"),
+		),
+		down: key.NewBinding(
+			key.WithKeys("d"),
+			key.WithHelp("d", "down service"),
+		),
+		downAll: key.NewBinding(
+			key.WithKeys("D"),
+			key.WithHelp("D", "down project"),
+		),
+		restart: key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", "restart service"),
+		),
+		restartAll: key.NewBinding(
+			key.WithKeys("R"),
+			key.WithHelp("R", "restart project"),
+		),
+		pull: key.NewBinding(
+			key.WithKeys("p"),
+			key.WithHelp("p", "pull service"),
+		),
+		pullAll: key.NewBinding(
+			key.WithKeys("P"),
+			key.WithHelp("P", "pull project"),
+		),
+		build: key.NewBinding(
+			key.WithKeys("b"),
+			key.WithHelp("b", "build service"),
+		),
+		buildAll: key.NewBinding(
+			key.WithKeys("B"),
+			key.WithHelp("B", "build project"),
+		),
+		logs: key.NewBinding(
+			key.WithKeys("L"),
+			key.WithHelp("L", "project logs"),
+		),
+		refresh: key.NewBinding(
+			key.WithKeys("ctrl+r"),
+			key.WithHelp("ctrl+r", "refresh"),
+		),
+		switchTab: key.NewBinding(
+			key.WithKeys("1", "2", "3", "4", "5", "6", "7", "tab", "shift+tab"),
+			key.WithHelp("1-7/tab", "switch tab"),
+		),
+	}
+}
+
+// operation is an action on the services of a project.
+type operation struct {
+	name     string // Past participle shown once done, e.g. "Started".
+	progress string // Shown while running, e.g. "Starting".
+	run      func(clientWrapper *client.ClientWrapper, project *compose.Project, services []string) error
+}
+
+var (
+	operationUp      = operation{"Started", "Starting", (*client.ClientWrapper).ComposeUp}
+	operationDown    = operation{"Removed", "Removing", (*client.ClientWrapper).ComposeDown}
+	operationRestart = operation{"Restarted", "Restarting", (*client.ClientWrapper).ComposeRestart}
+	operationPull    = operation{"Pulled", "Pulling", (*client.ClientWrapper).ComposePull}
+	operationBuild   = operation{"Built", "Building", (*client.ClientWrapper).ComposeBuild}
+)
+
+// servicesMsg carries the containers of the services of a project.
+type servicesMsg struct {
+	project    *compose.Project
+	containers map[string][]client.Container
+	err        error
+}
+
+func (servicesMsg) IsBackground() {}
+
+// operationMsg is sent when an operation is done.
+type operationMsg struct {
+	project *compose.Project
+	summary string
+	err     error
+}
+
+func (operationMsg) IsBackground() {}
+
+type sessionState int
+
+const (
+	viewMain sessionState = iota
+	viewOverlay
+	viewLogs
+)
+
+const (
+	focusList = iota
+	focusDetails
+)
+
+// Model represents the Compose component state.
+type Model struct {
+	shared.Component
+	style       lipgloss.Style
+	list        list.Model
+	viewport    viewport.Model
+	keybindings *keybindings
+
+	sessionState       sessionState
+	focusedView        int
+	detailsKeybindings detailsKeybindings
+	foreground         tea.Model
+	overlayModel       *overlay.Model
+	project            *compose.Project
+	loadErr            error  // Why the project given at startup could not be loaded.
+	status             string // Operation in progress.
+	logViewer          logs.Viewer
+}
+
+var (
+	_ tea.Model             = (*Model)(nil)
+	_ shared.ComponentModel = (*Model)(nil)
+)
+
+func New() Model {
+	width, height := context.GetWindowSize()
+	style := lipgloss.NewStyle().
+		Width(width).
+		Height(height).
+		PaddingTop(1)
+
+	delegate := newDefaultDelegate()
+	listModel := list.New([]list.Item{}, delegate, width, height)
+	listModel.SetShowHelp(false)
+	listModel.SetShowTitle(false)
+	listModel.SetShowStatusBar(false)
+	listModel.SetFilteringEnabled(true)
+	listModel.Styles.FilterPrompt = lipgloss.NewStyle().Foreground(colors.Primary())
+	listModel.Styles.FilterCursor = lipgloss.NewStyle().Foreground(colors.Primary())
+	listModel.FilterInput.PromptStyle = lipgloss.NewStyle().Foreground(colors.Primary())
+	listModel.FilterInput.Cursor.Style = lipgloss.NewStyle().Foreground(colors.Primary())
+
+	composeKeybindings := newKeybindings()
+	listModel.AdditionalFullHelpKeys = func() []key.Binding {
+		return []key.Binding{
+			composeKeybindings.open,
+			composeKeybindings.up,
+			composeKeybindings.upAll,
+			composeKeybindings.down,
+			composeKeybindings.downAll,
+			composeKeybindings.restart,
+			composeKeybindings.restartAll,
+			composeKeybindings.pull,
+			composeKeybindings.pullAll,
+			composeKeybindings.build,
+			composeKeybindings.buildAll,
+			composeKeybindings.logs,
+			composeKeybindings.refresh,
+			composeKeybindings.switchTab,
+		}
+	}
+
+	model := Model{
+		style:              style,
+		list:               listModel,
+		viewport:           viewport.New(0, 0),
+		keybindings:        composeKeybindings,
+		sessionState:       viewMain,
+		focusedView:        focusList,
+		detailsKeybindings: newDetailsKeybindings(),
+	}
+
+	if path := context.GetConfig().ComposeFile; path != "" {
+		project, err := compose.Load(path)
+		if err != nil {
+			model.loadErr = err
+		} else {
+			model.setProject(project)
+		}
+	}
+
+	model.overlayModel = overlay.New(nil, model.list, overlay.Center, overlay.Center, 0, 0)
+	return model
+}
+
+func (model Model) Init() tea.Cmd {
+	if model.loadErr != nil {
+		return notifications.ShowError(model.loadErr)
+	}
+	if model.project != nil {
+		return loadServices(model.project)
+	}
+	return nil
+}
+
+// setProject lists the services of a project, until their containers are loaded.
+func (model *Model) setProject(project *compose.Project) {
+	model.project = project
+	model.status = ""
+
+	items := make([]list.Item, 0, len(project.Services))
+	for _, service := range project.Services {
+		items = append(items, ServiceItem{Service: service})
+	}
+	model.list.ResetFilter()
+	model.list.SetItems(items)
+	model.list.Select(0)
+	model.refreshDetails()
+}
+
+func loadServices(project *compose.Project) tea.Cmd {
+	return func() tea.Msg {
+		containers, err := context.GetClient().GetComposeContainers(project.Name)
+		return servicesMsg{project: project, containers: containers, err: err}
+	}
+}
+
+// runOperation runs an operation on the services, all services if none is given.
+func runOperation(project *compose.Project, operation operation, services []string) tea.Cmd {
+	return func() tea.Msg {
+		target := "project " + project.Name
+		if len(services) > 0 {
+			target = strings.Join(services, ", ")
+		}
+		return operationMsg{
+			project: project,
+			summary: operation.name + " " + target,
+			err:     operation.run(context.GetClient(), project, services),
+		}
+	}
+}
+
+func (model Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmds []tea.Cmd
+
+	switch msg := msg.(type) {
+	case servicesMsg:
+		if msg.project != model.project {
+			return model, nil
+		}
+		if msg.err != nil {
+			return model, notifications.ShowError(msg.err)
+		}
+		model.setContainers(msg.containers)
+		return model, nil
+
+	case operationMsg:
+		if msg.project != model.project {
+			return model, nil
+		}
+		model.status = ""
+		model.refreshDetails()
+		if msg.err != nil {
+			return model, tea.Batch(notifications.ShowError(msg.err), loadServices(model.project))
+		}
+		return model, tea.Batch(notifications.ShowSuccess(msg.summary), loadServices(model.project))
+	}
+
+	switch model.sessionState {
+	case viewLogs:
+		if _, ok := msg.(logs.CloseMsg); ok {
+			model.sessionState = viewMain
+			model.logViewer = logs.Viewer{}
+			break
+		}
+
+		updatedViewer, viewerCmd := model.logViewer.Update(msg)
+		model.logViewer = updatedViewer.(logs.Viewer)
+		cmds = append(cmds, viewerCmd)
+	case viewOverlay:
+		foregroundModel, foregroundCmd := model.foreground.Update(msg)
+		model.foreground = foregroundModel
+		cmds = append(cmds, foregroundCmd)
+
+		if _, ok := msg.(shared.CloseDialogMessage); ok {
+			model.sessionState = viewMain
+			model.foreground = nil
+		} else if confirmMsg, ok := msg.(shared.ConfirmationMessage); ok {
+			switch confirmMsg.Action.Type {
+			case "OpenComposeFile":
+				path := confirmMsg.Action.Payload.(string)
+				if path == "" {
+					form := newOpenForm()
+					model.foreground = form
+					cmds = append(cmds, form.Init())
+					return model, tea.Batch(cmds...)
+				}
+				cmds = append(cmds, model.openProject(path))
+			case "ComposeDown":
+				cmds = append(cmds, model.startOperation(operationDown, confirmMsg.Action.Payload.([]string)))
+			}
+			model.sessionState = viewMain
+			model.foreground = nil
+		} else if submitMsg, ok := msg.(shared.FormSubmitMessage); ok && submitMsg.Action.Type == "OpenComposeFile" {
+			project, err := compose.Load(expandHome(submitMsg.Values["path"]))
+			if err != nil {
+				model.foreground = shared.SetFormError(model.foreground, err)
+				break
+			}
+			model.setProject(project)
+			cmds = append(cmds, loadServices(project))
+			model.sessionState = viewMain
+			model.foreground = nil
+		}
+	case viewMain:
+		if keyMsg, ok := msg.(tea.KeyMsg); ok {
+			if keyMsg.String() == "tab" && model.list.FilterState() != list.Filtering {
+				if model.focusedView == focusList {
+					model.focusedView = focusDetails
+				} else {
+					model.focusedView = focusList
+				}
+				return model, nil
+			}
+		}
+
+		isKeyMessage := false
+		if _, ok := msg.(tea.KeyMsg); ok {
+			isKeyMessage = true
+		}
+
+		if !isKeyMessage || model.focusedView == focusList {
+			switch msg := msg.(type) {
+			case tea.WindowSizeMsg:
+				model.UpdateWindowDimensions(msg)
+			case tea.KeyMsg:
+				if model.list.FilterState() == list.Filtering {
+					break
+				}
+
+				if key.Matches(msg, model.keybindings.switchTab) {
+					return model, nil
+				}
+				if cmd, handled := model.handleKey(msg); handled {
+					return model, cmd
+				}
+			}
+			updatedList, listCmd := model.list.Update(msg)
+			model.list = updatedList
+			cmds = append(cmds, listCmd)
+		}
+
+		model.refreshDetails()
+
+		if !isKeyMessage || model.focusedView == focusDetails {
+			updatedViewport, viewportCmd := model.viewport.Update(msg)
+			model.viewport = updatedViewport
+			cmds = append(cmds, viewportCmd)
+		}
+	}
+
+	model.overlayModel.Foreground = model.foreground
+	model.overlayModel.Background = model.list
+
+	return model, tea.Batch(cmds...)
+}
+
+// handleKey runs the action of a key of the list, reporting whether it has one.
+func (model *Model) handleKey(msg tea.KeyMsg) (tea.Cmd, bool) {
+	if key.Matches(msg, model.keybindings.open) {
+		model.foreground = newFilePicker()
+		model.sessionState = viewOverlay
+		return nil, true
+	}
+	if model.project == nil {
+		return nil, false
+	}
+
+	var services []string
+	if serviceItem, ok := model.list.SelectedItem().(ServiceItem); ok {
+		services = []string{serviceItem.Service.Name}
+	}
+
+	switch {
+	case key.Matches(msg, model.keybindings.refresh):
+		return loadServices(model.project), true
+	case key.Matches(msg, model.keybindings.logs):
+		var sources []logs.Source
+		for _, item := range model.list.Items() {
+			serviceItem := item.(ServiceItem)
+			for _, container := range serviceItem.Containers {
+				sources = append(sources, logs.Source{ContainerID: container.ID, Name: serviceItem.Service.Name})
+			}
+		}
+		if len(sources) == 0 {
+			return notifications.ShowInfo("No container of " + model.project.Name + " was created"), true
+		}
+		model.logViewer = logs.New("Logs of "+model.project.Name, sources)
+		model.sessionState = viewLogs
+		return model.logViewer.Init(), true
+	case key.Matches(msg, model.keybindings.down, model.keybindings.downAll):
+		target := "project " + model.project.Name
+		if key.Matches(msg, model.keybindings.downAll) {
+			services = []string{}
+		} else if len(services) > 0 {
+			target = "service " + services[0]
+		} else {
+			return nil, true
+		}
+		model.foreground = shared.NewSmartDialog(
+			fmt.Sprintf("Stop and remove the containers of %s?\nVolumes are kept.", target),
+			[]shared.DialogButton{
+				{Label: "Cancel", IsSafe: true},
+				{Label: "Down", IsSafe: false, Action: shared.SmartDialogAction{Type: "ComposeDown", Payload: services}},
+			},
+		)
+		model.sessionState = viewOverlay
+		return nil, true
+	}
+
+	for _, binding := range []struct {
+		service, project key.Binding
+		operation        operation
+	}{
+		{model.keybindings.up, model.keybindings.upAll, operationUp},
+		{model.keybindings.restart, model.keybindings.restartAll, operationRestart},
+		{model.keybindings.pull, model.keybindings.pullAll, operationPull},
+		{model.keybindings.build, model.keybindings.buildAll, operationBuild},
+	} {
+		switch {
+		case key.Matches(msg, binding.project):
+			return model.startOperation(binding.operation, nil), true
+		case key.Matches(msg, binding.service) && len(services) > 0:
+			return model.startOperation(binding.operation, services), true
+		}
+	}
+
+	return nil, false
+}
+
+// startOperation runs an operation unless another one is in progress.
+func (model *Model) startOperation(operation operation, services []string) tea.Cmd {
+	if model.status != "" {
+		return notifications.ShowInfo("Wait for " + strings.ToLower(model.status) + " to finish")
+	}
+
+	target := "project " + model.project.Name
+	if len(services) > 0 {
+		target = strings.Join(services, ", ")
+	}
+	model.status = operation.progress + " " + target + "..."
+	model.refreshDetails()
+
+	return runOperation(model.project, operation, services)
+}
+
+// openProject loads a Compose file and lists its services.
+func (model *Model) openProject(path string) tea.Cmd {
+	project, err := compose.Load(path)
+	if err != nil {
+		return notifications.ShowError(err)
+	}
+	model.setProject(project)
+	return loadServices(project)
+}
+
+// setContainers updates the containers of the listed services.
+func (model *Model) setContainers(containers map[string][]client.Container) {
+	for index, item := range model.list.Items() {
+		serviceItem := item.(ServiceItem)
+		serviceItem.Containers = containers[serviceItem.Service.Name]
+		model.list.SetItem(index, serviceItem)
+	}
+	model.refreshDetails()
+}
+
+// newFilePicker lists the Compose files of the working directory and of its
+// subdirectories, and an entry to type another path.
+func newFilePicker() shared.Picker {
+	var items []shared.PickerItem
+
+	if workingDir, err := os.Getwd(); err == nil {
+		dirs := []string{workingDir}
+		if entries, err := os.ReadDir(workingDir); err == nil {
+			for _, entry := range entries {
+				if entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") {
+					dirs = append(dirs, filepath.Join(workingDir, entry.Name()))
+				}
+			}
+		}
+		for _, dir := range dirs {
+			path, err := compose.FindFile(dir)
+			if err != nil {
+				continue
+			}
+			label, err := filepath.Rel(workingDir, path)
+			if err != nil {
+				label = path
+			}
+			items = append(items, shared.PickerItem{Label: label, Detail: filepath.Base(dir), Value: path})
+		}
+	}
+
+	items = append(items, shared.PickerItem{Label: "Other file...", Detail: "Type the path of a Compose file", Value: ""})
+
+	return shared.NewPicker("Open a Compose file", items, shared.SmartDialogAction{Type: "OpenComposeFile"})
+}
+
+func newOpenForm() shared.Form {
+	return shared.NewForm("Open a Compose file", []shared.FormField{
+		{Key: "path", Label: "Path", Placeholder: "~/project/compose.yaml", Hint: "a file or its directory"},
+	}, shared.SmartDialogAction{Type: "OpenComposeFile"})
+}
+
+// expandHome replaces a leading ~ with the home directory.
+func expandHome(path string) string {
+	if rest, ok := strings.CutPrefix(path, "~"); ok && (rest == "" || strings.HasPrefix(rest, "/")) {
+		if home, err := os.UserHomeDir(); err == nil {
+			return home + rest
+		}
+	}
+	return path
+}
+
+// refreshDetails shows the project and the selected service.
+func (model *Model) refreshDetails() {
+	if model.project == nil {
+		return
+	}
+	serviceItem, _ := model.list.SelectedItem().(ServiceItem)
+	model.viewport.SetContent(formatService(model.project, serviceItem, model.status))
+}
+
+func formatService(project *compose.Project, serviceItem ServiceItem, status string) string {
+	var builder strings.Builder
+
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(colors.Primary())
+	mutedStyle := lipgloss.NewStyle().Foreground(colors.Muted())
+
+	builder.WriteString(headerStyle.Render("Project "+project.Name) + "\n")
+	builder.WriteString(mutedStyle.Render(project.Path) + "\n")
+	if status != "" {
+		builder.WriteString(lipgloss.NewStyle().Foreground(colors.Warning()).Render(status) + "\n")
+	}
+
+	service := serviceItem.Service
+	if service.Name == "" {
+		return builder.String()
+	}
+
+	builder.WriteString("\n" + headerStyle.Render(service.Name) + "\n\n")
+	builder.WriteString(fmt.Sprintf("Image: %s\n", service.Image))
+	if service.Build != nil {
+		builder.WriteString(fmt.Sprintf("Build: %s %s\n", service.Build.Context, mutedStyle.Render(service.Build.Dockerfile)))
+	}
+	builder.WriteString(fmt.Sprintf("Container: %s\n", project.ContainerName(service)))
+	if len(service.Command) > 0 {
+		builder.WriteString(fmt.Sprintf("Command: %s\n", strings.Join(service.Command, " ")))
+	}
+	if service.Restart != "" {
+		builder.WriteString(fmt.Sprintf("Restart: %s\n", service.Restart))
+	}
+	if len(service.DependsOn) > 0 {
+		builder.WriteString(fmt.Sprintf("Depends on: %s\n", strings.Join(service.DependsOn, ", ")))
+	}
+
+	builder.WriteString("\n" + headerStyle.Render("Containers") + "\n")
+	if len(serviceItem.Containers) == 0 {
+		builder.WriteString(mutedStyle.Render("Not created, press u to start it.") + "\n")
+	}
+	for _, container := range serviceItem.Containers {
+		builder.WriteString(fmt.Sprintf("%s %s\n", container.Name, mutedStyle.Render(serviceItem.State())))
+	}
+
+	writeList(&builder, "Ports", service.Ports)
+	writeList(&builder, "Volumes", service.Volumes)
+
+	networks := make([]string, 0, len(service.Networks))
+	for network := range service.Networks {
+		networks = append(networks, project.Networks[network].Name)
+	}
+	if service.NetworkMode != "" {
+		networks = append(networks, "mode "+service.NetworkMode)
+	}
+	sort.Strings(networks)
+	writeList(&builder, "Networks", networks)
+	writeList(&builder, "Environment", service.Environment)
+
+	return builder.String()
+}
+
+// writeList writes a section of items, if there are any.
+func writeList(builder *strings.Builder, title string, items []string) {
+	if len(items) == 0 {
+		return
+	}
+	builder.WriteString("\n" + lipgloss.NewStyle().Bold(true).Foreground(colors.Primary()).Render(title) + "\n")
+	for _, item := range items {
+		builder.WriteString(item + "\n")
+	}
+}
+
+func (model Model) View() string {
+	if model.sessionState == viewOverlay && model.foreground != nil {
+		return model.overlayModel.View()
+	}
+	if model.sessionState == viewLogs {
+		return model.logViewer.View()
+	}
+
+	if model.project == nil {
+		mutedStyle := lipgloss.NewStyle().Foreground(colors.Muted())
+		message := "No Compose project loaded.\n\nPress o to open a Compose file, or start with --compose-file."
+		if model.loadErr != nil {
+			message = lipgloss.NewStyle().Foreground(colors.Error()).Render(model.loadErr.Error()) + "\n\n" + message
+		}
+		return lipgloss.NewStyle().
+			Width(model.WindowWidth).
+			Height(model.WindowHeight).
+			Padding(1, 2).
+			Render(mutedStyle.Render(message))
+	}
+
+	layoutManager := shared.NewLayoutManager(model.WindowWidth, model.WindowHeight)
+	_, detailLayout := layoutManager.CalculateMasterDetail(lipgloss.NewStyle())
+
+	listView := model.style.Render(model.list.View())
+
+	borderColor := colors.Muted()
+	if model.focusedView == focusDetails {
+		borderColor = colors.Primary()
+	}
+
+	detailStyle := lipgloss.NewStyle().
+		Width(detailLayout.Width - 2).
+		Height(detailLayout.Height).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(borderColor).
+		Padding(1)
+
+	detailView := detailStyle.Render(model.viewport.View())
+
+	return lipgloss.JoinHorizontal(lipgloss.Top, listView, detailView)
+}
+
+func (model *Model) UpdateWindowDimensions(msg tea.WindowSizeMsg) {
+	model.WindowWidth = msg.Width
+	model.WindowHeight = msg.Height
+
+	layoutManager := shared.NewLayoutManager(msg.Width, msg.Height)
+	masterLayout, detailLayout := layoutManager.CalculateMasterDetail(model.style)
+
+	model.style = model.style.Width(masterLayout.Width).Height(masterLayout.Height)
+
+	model.viewport.Width = shared.Max(detailLayout.Width-4, 0)
+	model.viewport.Height = shared.Max(detailLayout.Height-2, 0)
+
+	if model.list.Width() != masterLayout.ContentWidth || model.list.Height() != masterLayout.ContentHeight {
+		model.list.SetWidth(masterLayout.ContentWidth)
+		model.list.SetHeight(masterLayout.ContentHeight)
+	}
+
+	switch model.sessionState {
+	case viewOverlay:
+		switch foregroundModel := model.foreground.(type) {
+		case shared.SmartDialog:
+			foregroundModel.UpdateWindowDimensions(msg)
+			model.foreground = foregroundModel
+		case shared.Form:
+			foregroundModel.UpdateWindowDimensions(msg)
+			model.foreground = foregroundModel
+		case shared.Picker:
+			foregroundModel.UpdateWindowDimensions(msg)
+			model.foreground = foregroundModel
+		}
+	case viewLogs:
+		model.logViewer.UpdateWindowDimensions(msg)
+	}
+}
+
+// IsCapturingInput reports whether keys are being typed into a filter or a form.
+func (model Model) IsCapturingInput() bool {
+	if model.sessionState == viewLogs {
+		return model.logViewer.IsCapturingInput()
+	}
+	if model.sessionState == viewOverlay {
+		switch foregroundModel := model.foreground.(type) {
+		case shared.Form:
+			return true
+		case shared.Picker:
+			return foregroundModel.IsFiltering()
+		}
+	}
+	return model.list.FilterState() == list.Filtering
+}
+
+func (model Model) ShortHelp() []key.Binding {
+	if model.sessionState == viewLogs {
+		return model.logViewer.ShortHelp()
+	}
+	if model.project == nil {
+		return []key.Binding{model.keybindings.open, model.keybindings.switchTab}
+	}
+
+	switch model.focusedView {
+	case focusList:
+		return []key.Binding{
+			model.keybindings.up,
+			model.keybindings.down,
+			model.keybindings.restart,
+			model.keybindings.logs,
+			model.keybindings.open,
+		}
+	case focusDetails:
+		return []key.Binding{
+			model.detailsKeybindings.Up,
+			model.detailsKeybindings.Down,
+			model.detailsKeybindings.Switch,
+		}
+	}
+	return nil
+}
+
+func (model Model) FullHelp() [][]key.Binding {
+	if model.sessionState == viewLogs {
+		return model.logViewer.FullHelp()
+	}
+	if model.project == nil {
+		return [][]key.Binding{model.ShortHelp()}
+	}
+
+	switch model.focusedView {
+	case focusList:
+		return model.list.FullHelp()
+	case focusDetails:
+		return [][]key.Binding{
+			{
+				model.detailsKeybindings.Up,
+				model.detailsKeybindings.Down,
+				model.detailsKeybindings.Switch,
+			},
+		}
+	}
+	return nil
+}
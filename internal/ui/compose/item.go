@@ -0,0 +1,83 @@
+package compose
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/givensuman/containertui/internal/client"
+	"github.com/givensuman/containertui/internal/colors"
+	"github.com/givensuman/containertui/internal/compose"
+	"github.com/givensuman/containertui/internal/context"
+	"github.com/givensuman/containertui/internal/ui/shared"
+)
+
+// ServiceItem is a service of the project with its containers.
+type ServiceItem struct {
+	Service    compose.Service
+	Containers []client.Container
+}
+
+var (
+	_ list.Item        = (*ServiceItem)(nil)
+	_ list.DefaultItem = (*ServiceItem)(nil)
+)
+
+func newDefaultDelegate() list.DefaultDelegate {
+	delegate := list.NewDefaultDelegate()
+	delegate = shared.ChangeDelegateStyles(delegate)
+
+	return delegate
+}
+
+func (serviceItem ServiceItem) getTitleOrnament() string {
+	switch context.GetConfig().NoNerdFonts {
+	case true: // Don't use nerd fonts.
+		return ""
+	case false: // Use nerd fonts.
+		return " "
+	}
+
+	return ""
+}
+
+// State summarizes the states of the service's containers.
+func (serviceItem ServiceItem) State() string {
+	if len(serviceItem.Containers) == 0 {
+		return "not created"
+	}
+
+	states := make([]string, 0, len(serviceItem.Containers))
+	for _, container := range serviceItem.Containers {
+		state := container.State
+		if container.Health != "" {
+			state += " (" + container.Health + ")"
+		}
+		states = append(states, state)
+	}
+	return strings.Join(states, ", ")
+}
+
+func (serviceItem ServiceItem) FilterValue() string {
+	return serviceItem.Service.Name
+}
+
+func (serviceItem ServiceItem) Title() string {
+	titleColor := colors.Muted()
+	for _, container := range serviceItem.Containers {
+		switch container.State {
+		case "running":
+			titleColor = colors.Success()
+		case "paused", "restarting":
+			titleColor = colors.Warning()
+		}
+	}
+
+	title := fmt.Sprintf("%s %s", serviceItem.getTitleOrnament(), serviceItem.Service.Name)
+	return lipgloss.NewStyle().Foreground(titleColor).Render(strings.TrimSpace(title))
+}
+
+func (serviceItem ServiceItem) Description() string {
+	return fmt.Sprintf("%s • %s", serviceItem.State(), serviceItem.Service.Image)
+}
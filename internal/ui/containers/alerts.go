@@ -0,0 +1,255 @@
+package containers
+
+import (
+	stdcontext "context"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/givensuman/containertui/internal/client"
+	"github.com/givensuman/containertui/internal/colors"
+	"github.com/givensuman/containertui/internal/config"
+	"github.com/givensuman/containertui/internal/context"
+	"github.com/givensuman/containertui/internal/ui/notifications"
+)
+
+// alertThrottle is the least time between two notifications of a rule for a container.
+// Hits in between are only counted.
+const alertThrottle = 10 * time.Second
+
+// alertRule is an alert rule of the configuration, compiled.
+type alertRule struct {
+	config.AlertRule
+	pattern  *regexp.Regexp
+	severity notifications.Level
+}
+
+// compileAlertRules checks the alert rules of the configuration.
+func compileAlertRules(rules []config.AlertRule) ([]alertRule, error) {
+	compiled := make([]alertRule, 0, len(rules))
+	for index, rule := range rules {
+		if rule.Container == "" {
+			rule.Container = "*"
+		}
+		if _, err := path.Match(rule.Container, ""); err != nil {
+			return nil, fmt.Errorf("alert %d: invalid container glob %q", index+1, rule.Container)
+		}
+
+		pattern, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("alert %d: invalid pattern: %w", index+1, err)
+		}
+
+		var severity notifications.Level
+		switch strings.ToLower(rule.Severity) {
+		case "info":
+			severity = notifications.Info
+		case "warning", "warn":
+			severity = notifications.Warning
+		case "error", "":
+			severity = notifications.Error
+		default:
+			return nil, fmt.Errorf("alert %d: unknown severity %q, expected info, warning or error", index+1, rule.Severity)
+		}
+
+		compiled = append(compiled, alertRule{AlertRule: rule, pattern: pattern, severity: severity})
+	}
+	return compiled, nil
+}
+
+// MsgAlert reports a line of a container matching an alert rule.
+type MsgAlert struct {
+	ContainerID   string
+	ContainerName string
+	RuleIndex     int
+	Line          string
+}
+
+func (MsgAlert) IsBackground() {}
+
+// follower follows the logs of a container until cancelled or the logs end.
+type follower struct {
+	cancel stdcontext.CancelFunc
+	done   chan struct{}
+}
+
+// alertWatcher follows the logs of the running containers matched by the
+// alert rules, in the background of every tab.
+type alertWatcher struct {
+	rules     []alertRule
+	alerts    chan MsgAlert
+	followers map[string]follower  // By container ID.
+	notified  map[string]time.Time // Last notification, by container ID and rule.
+	hits      map[string]alertHits // By container ID.
+}
+
+// alertHits counts the lines of a container which raised alerts.
+type alertHits struct {
+	count    int
+	severity notifications.Level // The most severe.
+}
+
+func newAlertWatcher(rules []alertRule) *alertWatcher {
+	return &alertWatcher{
+		rules:     rules,
+		alerts:    make(chan MsgAlert),
+		followers: make(map[string]follower),
+		notified:  make(map[string]time.Time),
+		hits:      make(map[string]alertHits),
+	}
+}
+
+// waitForAlert receives the next alert of the followers.
+func (watcher *alertWatcher) waitForAlert() tea.Msg {
+	return <-watcher.alerts
+}
+
+// sync follows the running containers some rule applies to, and stops
+// following the others.
+func (watcher *alertWatcher) sync(containers []client.Container) {
+	if len(watcher.rules) == 0 {
+		return
+	}
+
+	running := make(map[string]bool, len(containers))
+	for _, container := range containers {
+		if container.State != "running" {
+			continue
+		}
+		running[container.ID] = true
+
+		if existing, ok := watcher.followers[container.ID]; ok {
+			select {
+			case <-existing.done: // The logs ended, e.g. on a restart.
+			default:
+				continue
+			}
+		}
+
+		var rules []int
+		for index, rule := range watcher.rules {
+			if matched, _ := path.Match(rule.Container, container.Name); matched {
+				rules = append(rules, index)
+			}
+		}
+		if len(rules) > 0 {
+			watcher.followers[container.ID] = watcher.follow(container, rules)
+		}
+	}
+
+	for containerID, existing := range watcher.followers {
+		if !running[containerID] {
+			existing.cancel()
+			delete(watcher.followers, containerID)
+		}
+	}
+}
+
+// follow matches the new lines of a container against the given rules.
+func (watcher *alertWatcher) follow(container client.Container, rules []int) follower {
+	ctx, cancel := stdcontext.WithCancel(stdcontext.Background())
+	done := make(chan struct{})
+	lines := make(chan client.LogLine)
+
+	go func() {
+		defer close(lines)
+		options := client.LogOptions{Follow: true, Tail: "0"}
+		_ = context.GetClient().StreamLogs(ctx, container.ID, options, lines)
+	}()
+
+	go func() {
+		defer close(done)
+		for line := range lines {
+			for _, index := range rules {
+				if !watcher.rules[index].pattern.MatchString(line.Text) {
+					continue
+				}
+				alert := MsgAlert{ContainerID: container.ID, ContainerName: container.Name, RuleIndex: index, Line: line.Text}
+				select {
+				case watcher.alerts <- alert:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return follower{cancel: cancel, done: done}
+}
+
+// handleAlert counts the hit of an alert and notifies of it, unless the
+// rule already notified of the container recently.
+func (watcher *alertWatcher) handleAlert(msg MsgAlert) tea.Cmd {
+	rule := watcher.rules[msg.RuleIndex]
+
+	hits := watcher.hits[msg.ContainerID]
+	hits.count++
+	if hits.count == 1 || severityRank(rule.severity) > severityRank(hits.severity) {
+		hits.severity = rule.severity
+	}
+	watcher.hits[msg.ContainerID] = hits
+
+	key := fmt.Sprintf("%s/%d", msg.ContainerID, msg.RuleIndex)
+	if time.Since(watcher.notified[key]) < alertThrottle {
+		return nil
+	}
+	watcher.notified[key] = time.Now()
+
+	return func() tea.Msg {
+		return notifications.AddNotificationMsg{
+			Message:  fmt.Sprintf("%s: %s", msg.ContainerName, strings.TrimSpace(msg.Line)),
+			Level:    rule.severity,
+			Duration: 10 * time.Second,
+		}
+	}
+}
+
+func severityRank(level notifications.Level) int {
+	switch level {
+	case notifications.Error:
+		return 2
+	case notifications.Warning:
+		return 1
+	}
+	return 0
+}
+
+// applyAlertHits shows the hits of the alerts on the items of their containers.
+func (containerList *ContainerList) applyAlertHits(hits map[string]alertHits) {
+	for index, item := range containerList.list.Items() {
+		containerItem, ok := item.(ContainerItem)
+		if !ok || containerItem.alertHits == hits[containerItem.ID] {
+			continue
+		}
+		containerItem.alertHits = hits[containerItem.ID]
+		containerList.list.SetItem(index, containerItem)
+	}
+}
+
+// getAlertBadge renders the count of the lines which raised alerts.
+func (containerItem ContainerItem) getAlertBadge() string {
+	if containerItem.alertHits.count == 0 {
+		return ""
+	}
+
+	color := colors.Primary()
+	switch containerItem.alertHits.severity {
+	case notifications.Error:
+		color = colors.Error()
+	case notifications.Warning:
+		color = colors.Warning()
+	}
+
+	var badge string
+	switch context.GetConfig().NoNerdFonts {
+	case true: // Don't use nerd fonts.
+		badge = fmt.Sprintf("[%d]", containerItem.alertHits.count)
+	case false: // Use nerd fonts.
+		badge = fmt.Sprintf(" %d", containerItem.alertHits.count)
+	}
+	return lipgloss.NewStyle().Foreground(color).Render(" " + badge)
+}
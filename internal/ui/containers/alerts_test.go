@@ -0,0 +1,61 @@
+package containers
+
+import (
+	"testing"
+
+	"github.com/givensuman/containertui/internal/config"
+	"github.com/givensuman/containertui/internal/ui/notifications"
+)
+
+func TestCompileAlertRules(t *testing.T) {
+	rules, err := compileAlertRules([]config.AlertRule{
+		{Container: "api-*", Pattern: `(?i)panic`, Severity: "warning"},
+		{Pattern: `timeout`},
+	})
+	if err != nil {
+		t.Fatalf("compileAlertRules returned error: %v", err)
+	}
+	if rules[0].severity != notifications.Warning {
+		t.Errorf("expected warning severity, got %v", rules[0].severity)
+	}
+	if rules[1].Container != "*" || rules[1].severity != notifications.Error {
+		t.Errorf("expected a rule for every container with error severity, got %q and %v", rules[1].Container, rules[1].severity)
+	}
+
+	invalid := []config.AlertRule{
+		{Container: "[", Pattern: "x"},
+		{Pattern: "("},
+		{Pattern: "x", Severity: "loud"},
+	}
+	for _, rule := range invalid {
+		if _, err := compileAlertRules([]config.AlertRule{rule}); err == nil {
+			t.Errorf("compileAlertRules(%+v) expected error, got nil", rule)
+		}
+	}
+}
+
+func TestHandleAlert(t *testing.T) {
+	rules, err := compileAlertRules([]config.AlertRule{
+		{Pattern: "slow", Severity: "warning"},
+		{Pattern: "failed", Severity: "error"},
+	})
+	if err != nil {
+		t.Fatalf("compileAlertRules returned error: %v", err)
+	}
+	watcher := newAlertWatcher(rules)
+
+	if cmd := watcher.handleAlert(MsgAlert{ContainerID: "a", RuleIndex: 0, Line: "slow query"}); cmd == nil {
+		t.Error("expected a notification for the first alert")
+	}
+	if cmd := watcher.handleAlert(MsgAlert{ContainerID: "a", RuleIndex: 0, Line: "slow query"}); cmd != nil {
+		t.Error("expected the repeated alert to be throttled")
+	}
+	if cmd := watcher.handleAlert(MsgAlert{ContainerID: "a", RuleIndex: 1, Line: "request failed"}); cmd == nil {
+		t.Error("expected a notification for another rule")
+	}
+
+	hits := watcher.hits["a"]
+	if hits.count != 3 || hits.severity != notifications.Error {
+		t.Errorf("expected 3 hits of error severity, got %d of %v", hits.count, hits.severity)
+	}
+}
@@ -0,0 +1,173 @@
+package containers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/givensuman/containertui/internal/client"
+	"github.com/givensuman/containertui/internal/context"
+	"github.com/givensuman/containertui/internal/ui/notifications"
+	"github.com/moby/term"
+	"github.com/muesli/cancelreader"
+)
+
+// resizeInterval is how often the size of the terminal is checked while
+// attached, to resize the TTY of the container along.
+const resizeInterval = 250 * time.Millisecond
+
+// attachSession connects the terminal to the main process of a container,
+// until the process exits or the user detaches. It runs through tea.Exec,
+// which suspends the interface meanwhile.
+type attachSession struct {
+	containerID   string
+	containerName string
+	stdin         io.Reader
+	stdout        io.Writer
+	stderr        io.Writer
+	isDetached    bool
+}
+
+var _ tea.ExecCommand = (*attachSession)(nil)
+
+func (session *attachSession) SetStdin(reader io.Reader)  { session.stdin = reader }
+func (session *attachSession) SetStdout(writer io.Writer) { session.stdout = writer }
+func (session *attachSession) SetStderr(writer io.Writer) { session.stderr = writer }
+
+func (session *attachSession) Run() error {
+	attachment, err := context.GetClient().AttachContainer(session.containerID)
+	if err != nil {
+		return err
+	}
+	defer attachment.Close()
+
+	detachKeys, err := term.ToBytes(client.DetachKeys)
+	if err != nil {
+		return err
+	}
+
+	// With a TTY, keys go to the container as typed, and the TTY of the
+	// container follows the size of the terminal. Without one, lines are
+	// sent once entered, and ctrl-c detaches rather than being forwarded.
+	var interrupts chan os.Signal
+	fd, isTerminal := term.GetFdInfo(session.stdin)
+	switch {
+	case attachment.Tty && isTerminal:
+		state, err := term.SetRawTerminal(fd)
+		if err != nil {
+			return err
+		}
+		defer term.RestoreTerminal(fd, state) //nolint:errcheck // Best effort, the interface resets the terminal too.
+
+		stopResizing := make(chan struct{})
+		defer close(stopResizing)
+		go session.followSize(fd, stopResizing)
+
+		fmt.Fprintf(session.stdout, "Attached to %s, detach with ctrl-p ctrl-q.\r\n", session.containerName)
+	case !attachment.Tty:
+		interrupts = make(chan os.Signal, 1)
+		signal.Notify(interrupts, os.Interrupt)
+		defer signal.Stop(interrupts)
+
+		fmt.Fprintf(session.stdout, "Attached to %s, detach with ctrl-c.\n", session.containerName)
+	}
+	if !attachment.OpenStdin {
+		fmt.Fprintf(session.stdout, "%s was not started with an open stdin, input is ignored.\r\n", session.containerName)
+	}
+
+	// The reader is cancelled on return, so that it does not keep the next
+	// keys from the interface.
+	input, err := cancelreader.NewReader(session.stdin)
+	if err != nil {
+		return err
+	}
+	defer input.Cancel()
+
+	outputDone := make(chan error, 1)
+	go func() {
+		var err error
+		if attachment.Tty {
+			_, err = io.Copy(session.stdout, attachment.Reader)
+		} else {
+			_, err = stdcopy.StdCopy(session.stdout, session.stderr, attachment.Reader)
+		}
+		outputDone <- err
+	}()
+
+	inputDone := make(chan error, 1)
+	go func() {
+		_, err := io.Copy(attachment.Conn, term.NewEscapeProxy(input, detachKeys))
+		if err == nil {
+			err = attachment.CloseWrite() // Sends the end of the input to the process.
+		}
+		inputDone <- err
+	}()
+
+	select {
+	case err := <-outputDone:
+		return err
+
+	case err := <-inputDone:
+		var escapeErr term.EscapeError
+		if errors.As(err, &escapeErr) {
+			session.isDetached = true
+			return nil
+		}
+		if err != nil && !errors.Is(err, cancelreader.ErrCanceled) {
+			return err
+		}
+		return <-outputDone
+
+	case <-interrupts:
+		session.isDetached = true
+		return nil
+	}
+}
+
+// followSize resizes the TTY of the container to the size of the terminal, until stopped.
+func (session *attachSession) followSize(fd uintptr, stop <-chan struct{}) {
+	var width, height uint16
+	ticker := time.NewTicker(resizeInterval)
+	defer ticker.Stop()
+
+	for {
+		if size, err := term.GetWinsize(fd); err == nil && (size.Width != width || size.Height != height) {
+			width, height = size.Width, size.Height
+			_ = context.GetClient().ResizeContainerTTY(session.containerID, uint(width), uint(height))
+		}
+
+		select {
+		case <-ticker.C:
+		case <-stop:
+			return
+		}
+	}
+}
+
+func (containerList *ContainerList) handleAttach() tea.Cmd {
+	item, ok := containerList.list.SelectedItem().(ContainerItem)
+	if !ok || item.isWorking {
+		return nil
+	}
+
+	if item.State != "running" {
+		return notifications.ShowInfo(item.Name + " is not running")
+	}
+
+	session := &attachSession{containerID: item.ID, containerName: item.Name}
+	return tea.Exec(session, func(err error) tea.Msg {
+		switch {
+		case err != nil:
+			return notifications.ShowError(err)()
+		case session.isDetached:
+			return notifications.ShowInfo("Detached from " + session.containerName)()
+		default:
+			return notifications.ShowInfo(session.containerName + " exited")()
+		}
+	})
+}
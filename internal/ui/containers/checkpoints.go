@@ -0,0 +1,188 @@
+package containers
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/givensuman/containertui/internal/context"
+	"github.com/givensuman/containertui/internal/ui/notifications"
+	"github.com/givensuman/containertui/internal/ui/shared"
+)
+
+// checkpointsUnsupported explains why checkpoints are unavailable, in place of the daemon's error.
+const checkpointsUnsupported = "Checkpoints are an experimental feature of Docker, " +
+	"and this daemon does not have experimental features enabled.\n\n" +
+	"Set \"experimental\": true in its daemon.json and restart it. " +
+	"CRIU must also be installed on the daemon's host."
+
+// checkpointNamePattern is the format of the names the daemon accepts for checkpoints.
+var checkpointNamePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]+$`)
+
+// checkpointTarget is the container whose checkpoints are managed.
+type checkpointTarget struct {
+	containerID   string
+	containerName string
+	state         string
+}
+
+// checkpointChoice is a checkpoint of a container, or a new one when name is empty.
+type checkpointChoice struct {
+	target checkpointTarget
+	name   string
+}
+
+type checkpointOperation int
+
+const (
+	checkpointCreate checkpointOperation = iota
+	checkpointStart
+	checkpointDelete
+)
+
+// MsgCheckpoints contains the checkpoints of a container, if the daemon supports them.
+type MsgCheckpoints struct {
+	target      checkpointTarget
+	Supported   bool
+	Checkpoints []string
+	Err         error
+}
+
+// MsgCheckpointResult indicates the result of an operation on a checkpoint.
+type MsgCheckpointResult struct {
+	operation    checkpointOperation
+	choice       checkpointChoice
+	leaveRunning bool
+	Err          error
+}
+
+// fetchCheckpoints checks that the daemon supports checkpoints before listing them,
+// as an unsupported daemon only answers with a raw error.
+func fetchCheckpoints(target checkpointTarget) tea.Cmd {
+	return func() tea.Msg {
+		msg := MsgCheckpoints{target: target}
+		msg.Supported, msg.Err = context.GetClient().CheckpointsSupported()
+		if msg.Err != nil || !msg.Supported {
+			return msg
+		}
+		msg.Checkpoints, msg.Err = context.GetClient().GetCheckpoints(target.containerID)
+		return msg
+	}
+}
+
+// newCheckpointPicker offers the checkpoints of the container, after a new
+// one when the container is running.
+func newCheckpointPicker(target checkpointTarget, names []string) shared.Picker {
+	var items []shared.PickerItem
+	if target.state == "running" {
+		items = append(items, shared.PickerItem{Label: "New checkpoint...", Detail: "Save the state of the running container", Value: checkpointChoice{target: target}})
+	}
+	for _, name := range names {
+		items = append(items, shared.PickerItem{Label: name, Detail: "Start from or delete", Value: checkpointChoice{target: target, name: name}})
+	}
+	return shared.NewPicker("Checkpoints of "+target.containerName, items, shared.SmartDialogAction{Type: "PickCheckpoint"})
+}
+
+func newCheckpointForm(target checkpointTarget) shared.Form {
+	return shared.NewForm(
+		"Checkpoint "+target.containerName,
+		[]shared.FormField{
+			{Key: "name", Label: "Name", Value: time.Now().Format("checkpoint-20060102-150405"), Hint: "letters, digits, _ . and -"},
+			{Key: "running", Label: "Leave running", Value: "no", Hint: "yes or no, otherwise the container is stopped"},
+		},
+		shared.SmartDialogAction{Type: "CreateCheckpoint", Payload: target},
+	)
+}
+
+// parseCheckpointForm converts the values of the checkpoint form.
+func parseCheckpointForm(values map[string]string) (string, bool, error) {
+	name := strings.TrimSpace(values["name"])
+	if name == "" {
+		return "", false, errors.New("name: required")
+	}
+	if !checkpointNamePattern.MatchString(name) {
+		return "", false, fmt.Errorf("name: invalid name %q, expected letters, digits, _ . and -", name)
+	}
+
+	switch strings.ToLower(strings.TrimSpace(values["running"])) {
+	case "yes", "y":
+		return name, true, nil
+	case "no", "n", "":
+		return name, false, nil
+	}
+	return "", false, fmt.Errorf("leave running: invalid value %q, expected yes or no", values["running"])
+}
+
+// newCheckpointDialog offers the operations on an existing checkpoint. A
+// container is only started from a checkpoint while it is stopped.
+func newCheckpointDialog(choice checkpointChoice) shared.SmartDialog {
+	message := fmt.Sprintf("Checkpoint %s of %s", choice.name, choice.target.containerName)
+	var buttons []shared.DialogButton
+	if choice.target.state == "running" || choice.target.state == "paused" {
+		message += "\n\nStop the container to start it from the checkpoint."
+	} else {
+		buttons = append(buttons, shared.DialogButton{Label: "Start from it", Action: shared.SmartDialogAction{Type: "StartFromCheckpoint", Payload: choice}, IsSafe: true})
+	}
+	buttons = append(buttons,
+		shared.DialogButton{Label: "Delete", Action: shared.SmartDialogAction{Type: "DeleteCheckpoint", Payload: choice}},
+		shared.DialogButton{Label: "Cancel", IsSafe: true},
+	)
+	return shared.NewSmartDialog(message, buttons)
+}
+
+// runCheckpointOperation performs the operation on the checkpoint in the background.
+func runCheckpointOperation(operation checkpointOperation, choice checkpointChoice, leaveRunning bool) tea.Cmd {
+	return func() tea.Msg {
+		msg := MsgCheckpointResult{operation: operation, choice: choice, leaveRunning: leaveRunning}
+		containerID := choice.target.containerID
+		switch operation {
+		case checkpointCreate:
+			msg.Err = context.GetClient().CreateCheckpoint(containerID, choice.name, leaveRunning)
+		case checkpointStart:
+			msg.Err = context.GetClient().StartContainerFromCheckpoint(containerID, choice.name)
+		case checkpointDelete:
+			msg.Err = context.GetClient().RemoveCheckpoint(containerID, choice.name)
+		}
+		return msg
+	}
+}
+
+// handleCheckpointResult notifies of the result, and updates the state of
+// the container when the operation stopped or started it.
+func handleCheckpointResult(msg MsgCheckpointResult) tea.Cmd {
+	if msg.Err != nil {
+		return notifications.ShowError(msg.Err)
+	}
+
+	containerIDs := []string{msg.choice.target.containerID}
+	switch msg.operation {
+	case checkpointCreate:
+		success := notifications.ShowSuccess(fmt.Sprintf("Checkpointed %s as %s", msg.choice.target.containerName, msg.choice.name))
+		if msg.leaveRunning {
+			return success
+		}
+		return tea.Batch(success, func() tea.Msg {
+			return MessageContainerOperationResult{Operation: Stop, IDs: containerIDs}
+		})
+	case checkpointStart:
+		return tea.Batch(
+			notifications.ShowSuccess(fmt.Sprintf("Started %s from %s", msg.choice.target.containerName, msg.choice.name)),
+			func() tea.Msg { return MessageContainerOperationResult{Operation: Start, IDs: containerIDs} },
+		)
+	case checkpointDelete:
+		return notifications.ShowSuccess("Deleted checkpoint " + msg.choice.name)
+	}
+	return nil
+}
+
+func (containerList *ContainerList) handleCheckpoints() tea.Cmd {
+	item, ok := containerList.list.SelectedItem().(ContainerItem)
+	if !ok || item.isWorking {
+		return nil
+	}
+
+	return fetchCheckpoints(checkpointTarget{containerID: item.ID, containerName: item.Name, state: item.State})
+}
@@ -0,0 +1,31 @@
+package containers
+
+import "testing"
+
+func TestParseCheckpointForm(t *testing.T) {
+	name, leaveRunning, err := parseCheckpointForm(map[string]string{"name": " before-upgrade ", "running": "Yes"})
+	if err != nil {
+		t.Fatalf("parseCheckpointForm returned error: %v", err)
+	}
+	if name != "before-upgrade" || !leaveRunning {
+		t.Errorf("expected before-upgrade left running, got %q, %v", name, leaveRunning)
+	}
+
+	if _, leaveRunning, err := parseCheckpointForm(map[string]string{"name": "v1.2_snapshot"}); err != nil || leaveRunning {
+		t.Errorf("expected the container to be stopped by default, got %v, %v", leaveRunning, err)
+	}
+
+	invalid := []map[string]string{
+		{"name": "  "},
+		{"name": "a"},
+		{"name": "-leading-dash"},
+		{"name": "has space"},
+		{"name": "../escape"},
+		{"name": "valid", "running": "maybe"},
+	}
+	for _, values := range invalid {
+		if _, _, err := parseCheckpointForm(values); err == nil {
+			t.Errorf("parseCheckpointForm(%v) expected error, got nil", values)
+		}
+	}
+}
@@ -0,0 +1,413 @@
+package containers
+
+import (
+	stdcontext "context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/givensuman/containertui/internal/client"
+	"github.com/givensuman/containertui/internal/colors"
+	"github.com/givensuman/containertui/internal/context"
+	"github.com/givensuman/containertui/internal/shellwords"
+	"github.com/givensuman/containertui/internal/ui/notifications"
+	"github.com/givensuman/containertui/internal/ui/shared"
+)
+
+// commandHistoryLimit is the number of commands remembered per image.
+const commandHistoryLimit = 10
+
+// commandTarget is a container a command is run in.
+type commandTarget struct {
+	containerID   string
+	containerName string
+	image         string
+}
+
+// commandDraft fills the command form, with a command of the history or blank.
+type commandDraft struct {
+	targets []commandTarget
+	options client.ExecOptions
+}
+
+// MessageOpenCommandForm indicates the user requested to run a command in containers.
+type MessageOpenCommandForm struct {
+	targets []commandTarget
+}
+
+// MsgCommandResult contains the output of a command in a container.
+type MsgCommandResult struct {
+	Run         int // Tells the results of a run from those of an earlier one.
+	ContainerID string
+	Result      client.ExecResult
+	Err         error
+}
+
+func (MsgCommandResult) IsBackground() {}
+
+// commandHistory remembers the commands run in the containers of each image,
+// most recent first, for the duration of the session.
+type commandHistory map[string][]client.ExecOptions
+
+// add records a command run in a container of the image, moving it first
+// when it was already recorded.
+func (history commandHistory) add(image string, options client.ExecOptions) {
+	entries := []client.ExecOptions{options}
+	for _, entry := range history[image] {
+		if describeCommand(entry) != describeCommand(options) && len(entries) < commandHistoryLimit {
+			entries = append(entries, entry)
+		}
+	}
+	history[image] = entries
+}
+
+// recent lists the commands of the images, in the order of the images and
+// without duplicates.
+func (history commandHistory) recent(images []string) []client.ExecOptions {
+	seen := make(map[string]bool)
+	var entries []client.ExecOptions
+	for _, image := range images {
+		for _, entry := range history[image] {
+			if description := describeCommand(entry); !seen[description] {
+				seen[description] = true
+				entries = append(entries, entry)
+			}
+		}
+	}
+	return entries
+}
+
+// describeCommand renders a command with the options it differs from the container's defaults by.
+func describeCommand(options client.ExecOptions) string {
+	description := shellwords.Join(options.Cmd)
+	if options.User != "" {
+		description += " as " + options.User
+	}
+	if options.WorkingDir != "" {
+		description += " in " + options.WorkingDir
+	}
+	if len(options.Env) > 0 {
+		description += " with " + shellwords.Join(options.Env)
+	}
+	return description
+}
+
+// newCommandPicker offers the recent commands of the images of the targets,
+// after a blank command.
+func newCommandPicker(targets []commandTarget, entries []client.ExecOptions) shared.Picker {
+	items := []shared.PickerItem{{Label: "New command...", Detail: "Type a command", Value: commandDraft{targets: targets}}}
+	for _, entry := range entries {
+		items = append(items, shared.PickerItem{
+			Label:  shellwords.Join(entry.Cmd),
+			Detail: strings.TrimPrefix(describeCommand(entry), shellwords.Join(entry.Cmd)+" "),
+			Value:  commandDraft{targets: targets, options: entry},
+		})
+	}
+	return shared.NewPicker(commandTitle(targets), items, shared.SmartDialogAction{Type: "PickCommand"})
+}
+
+func newCommandForm(draft commandDraft) shared.Form {
+	return shared.NewForm(
+		commandTitle(draft.targets),
+		[]shared.FormField{
+			{Key: "command", Label: "Command", Placeholder: "ls -la /tmp", Value: shellwords.Join(draft.options.Cmd), Hint: "run without a shell, quote arguments with spaces"},
+			{Key: "user", Label: "User", Placeholder: "container default", Value: draft.options.User, Hint: "name or uid[:gid]"},
+			{Key: "workdir", Label: "Working directory", Placeholder: "container default", Value: draft.options.WorkingDir},
+			{Key: "env", Label: "Environment", Placeholder: "KEY=value", Value: shellwords.Join(draft.options.Env), Hint: "space separated, quote values with spaces"},
+		},
+		shared.SmartDialogAction{Type: "RunCommand", Payload: draft.targets},
+	)
+}
+
+// parseCommandForm converts the values of the command form.
+func parseCommandForm(values map[string]string) (client.ExecOptions, error) {
+	options := client.ExecOptions{
+		User:       strings.TrimSpace(values["user"]),
+		WorkingDir: strings.TrimSpace(values["workdir"]),
+	}
+
+	var err error
+	if options.Cmd, err = shellwords.Split(values["command"]); err != nil {
+		return options, fmt.Errorf("command: %w", err)
+	}
+	if len(options.Cmd) == 0 {
+		return options, errors.New("command: required")
+	}
+	if options.Env, err = shellwords.Split(values["env"]); err != nil {
+		return options, fmt.Errorf("environment: %w", err)
+	}
+	for _, variable := range options.Env {
+		if key, _, found := strings.Cut(variable, "="); !found || key == "" {
+			return options, fmt.Errorf("environment: invalid variable %q, expected KEY=value", variable)
+		}
+	}
+	return options, nil
+}
+
+func commandTitle(targets []commandTarget) string {
+	if len(targets) == 1 {
+		return "Run in " + targets[0].containerName
+	}
+	return fmt.Sprintf("Run in %d containers", len(targets))
+}
+
+// runCommand runs the command in each target concurrently, each reporting its own result.
+func runCommand(ctx stdcontext.Context, run int, targets []commandTarget, options client.ExecOptions) tea.Cmd {
+	cmds := make([]tea.Cmd, 0, len(targets))
+	for _, target := range targets {
+		containerID := target.containerID
+		cmds = append(cmds, func() tea.Msg {
+			result, err := context.GetClient().RunCommand(ctx, containerID, options)
+			return MsgCommandResult{Run: run, ContainerID: containerID, Result: result, Err: err}
+		})
+	}
+	return tea.Batch(cmds...)
+}
+
+func (containerList *ContainerList) handleRunCommand() tea.Cmd {
+	var targets []commandTarget
+	for _, item := range containerList.list.Items() {
+		if container, ok := item.(ContainerItem); ok && container.isSelected {
+			targets = append(targets, commandTarget{containerID: container.ID, containerName: container.Name, image: container.Image})
+		}
+	}
+	if len(targets) == 0 {
+		item, ok := containerList.list.SelectedItem().(ContainerItem)
+		if !ok || item.isWorking {
+			return nil
+		}
+		if item.State != "running" {
+			return notifications.ShowInfo(item.Name + " is not running")
+		}
+		targets = []commandTarget{{containerID: item.ID, containerName: item.Name, image: item.Image}}
+	}
+
+	return func() tea.Msg {
+		return MessageOpenCommandForm{targets: targets}
+	}
+}
+
+type commandOutputKeybindings struct {
+	next     key.Binding
+	previous key.Binding
+	scroll   key.Binding
+	close    key.Binding
+}
+
+func newCommandOutputKeybindings() commandOutputKeybindings {
+	return commandOutputKeybindings{
+		next: key.NewBinding(
+			key.WithKeys("l", "right"),
+			key.WithHelp("→/l", "next container"),
+		),
+		previous: key.NewBinding(
+			key.WithKeys("h", "left"),
+			key.WithHelp("←/h", "previous container"),
+		),
+		scroll: key.NewBinding(
+			key.WithKeys("up", "down", "k", "j", "pgup", "pgdown"),
+			key.WithHelp("↑/↓", "scroll"),
+		),
+		close: key.NewBinding(
+			key.WithKeys("esc", "q"),
+			key.WithHelp("esc", "close"),
+		),
+	}
+}
+
+// CommandOutput shows the output and exit code of a command, with a tab per container.
+type CommandOutput struct {
+	shared.Component
+	style       lipgloss.Style
+	run         int
+	command     string
+	targets     []commandTarget
+	results     map[string]MsgCommandResult // By container ID, once the command exited.
+	activeTab   int
+	viewport    viewport.Model
+	cancel      stdcontext.CancelFunc
+	keybindings commandOutputKeybindings
+}
+
+var (
+	_ tea.Model             = (*CommandOutput)(nil)
+	_ shared.ComponentModel = (*CommandOutput)(nil)
+)
+
+func newCommandOutput(run int, targets []commandTarget, options client.ExecOptions, cancel stdcontext.CancelFunc) CommandOutput {
+	width, height := context.GetWindowSize()
+
+	style := lipgloss.NewStyle().
+		Padding(1).
+		Border(lipgloss.RoundedBorder(), true, true).
+		BorderForeground(colors.Primary())
+
+	model := CommandOutput{
+		style:       style,
+		run:         run,
+		command:     describeCommand(options),
+		targets:     targets,
+		results:     make(map[string]MsgCommandResult, len(targets)),
+		viewport:    viewport.New(0, 0),
+		cancel:      cancel,
+		keybindings: newCommandOutputKeybindings(),
+	}
+	model.UpdateWindowDimensions(tea.WindowSizeMsg{Width: width, Height: height})
+	return model
+}
+
+// commandOutputHeaderHeight is the height of the command, the tabs and the exit code.
+const commandOutputHeaderHeight = 6
+
+func (model *CommandOutput) UpdateWindowDimensions(msg tea.WindowSizeMsg) {
+	model.WindowWidth = msg.Width
+	model.WindowHeight = msg.Height
+
+	layoutManager := shared.NewLayoutManager(msg.Width, msg.Height)
+	dimensions := layoutManager.CalculateLargeOverlay(model.style)
+
+	model.style = model.style.Width(dimensions.Width).Height(dimensions.Height)
+	model.viewport.Width = shared.Max(dimensions.ContentWidth, 0)
+	model.viewport.Height = shared.Max(dimensions.ContentHeight-commandOutputHeaderHeight, 0)
+	model.refreshContent()
+}
+
+func (model CommandOutput) Init() tea.Cmd {
+	return nil
+}
+
+func (model CommandOutput) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		model.UpdateWindowDimensions(msg)
+		return model, nil
+
+	case MsgCommandResult:
+		if msg.Run != model.run {
+			return model, nil
+		}
+		model.results[msg.ContainerID] = msg
+		if model.targets[model.activeTab].containerID == msg.ContainerID {
+			model.refreshContent()
+		}
+		return model, nil
+
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, model.keybindings.close):
+			// Only stops waiting: the commands still running keep running in their containers.
+			model.cancel()
+			if running := len(model.targets) - len(model.results); running == 1 {
+				return model, tea.Batch(CloseOverlay(), notifications.ShowInfo("The command keeps running in 1 container"))
+			} else if running > 1 {
+				return model, tea.Batch(CloseOverlay(), notifications.ShowInfo(fmt.Sprintf("The command keeps running in %d containers", running)))
+			}
+			return model, CloseOverlay()
+		case key.Matches(msg, model.keybindings.next):
+			model.activeTab = (model.activeTab + 1) % len(model.targets)
+			model.refreshContent()
+			return model, nil
+		case key.Matches(msg, model.keybindings.previous):
+			model.activeTab = (model.activeTab + len(model.targets) - 1) % len(model.targets)
+			model.refreshContent()
+			return model, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	model.viewport, cmd = model.viewport.Update(msg)
+	return model, cmd
+}
+
+// refreshContent shows the output of the container of the active tab, stdout then stderr.
+func (model *CommandOutput) refreshContent() {
+	if len(model.targets) == 0 {
+		return
+	}
+	result, ok := model.results[model.targets[model.activeTab].containerID]
+	mutedStyle := lipgloss.NewStyle().Foreground(colors.Muted())
+	wrapStyle := lipgloss.NewStyle().Width(model.viewport.Width)
+
+	var builder strings.Builder
+	switch {
+	case !ok:
+		builder.WriteString(mutedStyle.Render("Waiting for the command to exit..."))
+	case result.Err != nil:
+		builder.WriteString(wrapStyle.Foreground(colors.Error()).Render(result.Err.Error()))
+	case result.Result.Stdout == "" && result.Result.Stderr == "":
+		builder.WriteString(mutedStyle.Render("No output."))
+	default:
+		if stdout := strings.TrimSuffix(result.Result.Stdout, "\n"); stdout != "" {
+			builder.WriteString(wrapStyle.Render(stdout) + "\n")
+		}
+		if stderr := strings.TrimSuffix(result.Result.Stderr, "\n"); stderr != "" {
+			builder.WriteString(mutedStyle.Render("stderr") + "\n")
+			builder.WriteString(wrapStyle.Foreground(colors.Error()).Render(stderr) + "\n")
+		}
+		if result.Result.Truncated {
+			builder.WriteString(mutedStyle.Render(fmt.Sprintf("Output truncated to the first %d bytes of each stream.", client.ExecOutputLimit)))
+		}
+	}
+	model.viewport.SetContent(builder.String())
+	model.viewport.GotoTop()
+}
+
+// status renders how the command ended in a container.
+func (model CommandOutput) status(containerID string) (string, lipgloss.Color) {
+	result, ok := model.results[containerID]
+	switch {
+	case !ok:
+		return "running", colors.Muted()
+	case result.Err != nil:
+		return "failed", colors.Error()
+	case result.Result.ExitCode != 0:
+		return fmt.Sprintf("exit code %d", result.Result.ExitCode), colors.Error()
+	default:
+		return "exit code 0", colors.Success()
+	}
+}
+
+func (model CommandOutput) View() string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(colors.Primary())
+	activeTabStyle := lipgloss.NewStyle().Bold(true).Foreground(colors.Text()).Background(colors.Primary()).Padding(0, 1)
+	tabStyle := lipgloss.NewStyle().Foreground(colors.Muted()).Padding(0, 1)
+
+	tabs := make([]string, 0, len(model.targets))
+	for index, target := range model.targets {
+		style := tabStyle
+		if index == model.activeTab {
+			style = activeTabStyle
+		}
+		_, color := model.status(target.containerID)
+		tabs = append(tabs, style.Render(target.containerName)+lipgloss.NewStyle().Foreground(color).Render("● "))
+	}
+
+	status, color := model.status(model.targets[model.activeTab].containerID)
+
+	return model.style.Render(lipgloss.JoinVertical(lipgloss.Left,
+		titleStyle.Render(commandTitle(model.targets)),
+		lipgloss.NewStyle().Width(model.viewport.Width).MaxHeight(1).Render("$ "+model.command),
+		"",
+		lipgloss.JoinHorizontal(lipgloss.Top, tabs...),
+		lipgloss.NewStyle().Foreground(color).Bold(true).Render(status),
+		"",
+		model.viewport.View(),
+	))
+}
+
+func (model CommandOutput) ShortHelp() []key.Binding {
+	return []key.Binding{
+		model.keybindings.next,
+		model.keybindings.previous,
+		model.keybindings.scroll,
+		model.keybindings.close,
+	}
+}
+
+func (model CommandOutput) FullHelp() [][]key.Binding {
+	return [][]key.Binding{model.ShortHelp()}
+}
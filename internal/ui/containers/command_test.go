@@ -0,0 +1,68 @@
+package containers
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/givensuman/containertui/internal/client"
+)
+
+func TestParseCommandForm(t *testing.T) {
+	options, err := parseCommandForm(map[string]string{
+		"command": `sh -c "echo $HOME"`,
+		"user":    " 1000:1000 ",
+		"workdir": "/srv",
+		"env":     `DEBUG=1 "GREETING=hello world"`,
+	})
+	if err != nil {
+		t.Fatalf("parseCommandForm returned error: %v", err)
+	}
+	expected := client.ExecOptions{
+		Cmd:        []string{"sh", "-c", "echo $HOME"},
+		User:       "1000:1000",
+		WorkingDir: "/srv",
+		Env:        []string{"DEBUG=1", "GREETING=hello world"},
+	}
+	if !reflect.DeepEqual(options, expected) {
+		t.Errorf("expected %+v, got %+v", expected, options)
+	}
+
+	invalid := []map[string]string{
+		{"command": "  "},
+		{"command": `echo "unterminated`},
+		{"command": "env", "env": "=1"},
+		{"command": "env", "env": "DEBUG"},
+	}
+	for _, values := range invalid {
+		if _, err := parseCommandForm(values); err == nil {
+			t.Errorf("parseCommandForm(%v) expected error, got nil", values)
+		}
+	}
+}
+
+func TestCommandHistory(t *testing.T) {
+	history := make(commandHistory)
+	ls := client.ExecOptions{Cmd: []string{"ls"}}
+	lsAsRoot := client.ExecOptions{Cmd: []string{"ls"}, User: "root"}
+	ps := client.ExecOptions{Cmd: []string{"ps", "aux"}}
+
+	history.add("nginx", ls)
+	history.add("nginx", lsAsRoot)
+	history.add("nginx", ls)
+	history.add("redis", ps)
+	history.add("redis", ls)
+
+	if got := history.recent([]string{"nginx"}); !reflect.DeepEqual(got, []client.ExecOptions{ls, lsAsRoot}) {
+		t.Errorf("expected the most recent command first without duplicates, got %+v", got)
+	}
+	if got := history.recent([]string{"nginx", "redis"}); !reflect.DeepEqual(got, []client.ExecOptions{ls, lsAsRoot, ps}) {
+		t.Errorf("expected the commands of both images without duplicates, got %+v", got)
+	}
+
+	for index := 0; index < commandHistoryLimit+5; index++ {
+		history.add("alpine", client.ExecOptions{Cmd: []string{"echo", string(rune('a' + index))}})
+	}
+	if got := len(history["alpine"]); got != commandHistoryLimit {
+		t.Errorf("expected %d commands to be kept, got %d", commandHistoryLimit, got)
+	}
+}
@@ -2,12 +2,14 @@
 package containers
 
 import (
+	stdcontext "context"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -15,6 +17,8 @@ import (
 	"github.com/givensuman/containertui/internal/client"
 	"github.com/givensuman/containertui/internal/colors"
 	"github.com/givensuman/containertui/internal/context"
+	"github.com/givensuman/containertui/internal/ui/logs"
+	"github.com/givensuman/containertui/internal/ui/notifications"
 	"github.com/givensuman/containertui/internal/ui/shared"
 	"github.com/guptarohit/asciigraph"
 	overlay "github.com/rmhubbert/bubbletea-overlay"
@@ -25,6 +29,8 @@ type sessionState int
 const (
 	viewMain sessionState = iota
 	viewOverlay
+	viewLogs
+	viewCopy
 )
 
 const (
@@ -52,6 +58,7 @@ type MsgContainerInspection struct {
 type detailsKeybindings struct {
 	Up     key.Binding
 	Down   key.Binding
+	Edit   key.Binding
 	Switch key.Binding
 }
 
@@ -65,6 +72,10 @@ func newDetailsKeybindings() detailsKeybindings {
 			key.WithKeys("down", "j"),
 			key.WithHelp("↓/j", "down"),
 		),
+		Edit: key.NewBinding(
+			key.WithKeys("e"),
+			key.WithHelp("e", "edit resources"),
+		),
 		Switch: key.NewBinding(
 			key.WithKeys("tab"),
 			key.WithHelp("tab", "switch focus"),
@@ -89,6 +100,14 @@ type Model struct {
 	viewport           viewport.Model
 	inspection         types.ContainerJSON
 	detailsKeybindings detailsKeybindings
+	logViewer          logs.Viewer
+	copyPicker         CopyPicker
+
+	alerts    *alertWatcher
+	alertsErr error
+
+	commandHistory commandHistory
+	commandRuns    int // Number of commands run, to number the next.
 }
 
 var (
@@ -101,6 +120,7 @@ func New() Model {
 	containerList := newContainerList()
 
 	detailViewport := viewport.New(0, 0)
+	alertRules, alertsErr := compileAlertRules(context.GetConfig().Alerts)
 
 	model := Model{
 		sessionState: viewMain,
@@ -118,6 +138,9 @@ func New() Model {
 		cpuHistory:         make([]float64, 0),
 		viewport:           detailViewport,
 		detailsKeybindings: newDetailsKeybindings(),
+		alerts:             newAlertWatcher(alertRules),
+		alertsErr:          alertsErr,
+		commandHistory:     make(commandHistory),
 	}
 
 	return model
@@ -156,18 +179,34 @@ func (model *Model) UpdateWindowDimensions(msg tea.WindowSizeMsg) {
 		}
 	case viewOverlay:
 		switch foregroundModel := model.foreground.(type) {
-		case *ContainerLogs:
-			foregroundModel.setDimensions(msg.Width, msg.Height)
-			model.foreground = foregroundModel
 		case DeleteConfirmation:
 			foregroundModel.UpdateWindowDimensions(msg)
 			model.foreground = foregroundModel
+		case shared.Form:
+			foregroundModel.UpdateWindowDimensions(msg)
+			model.foreground = foregroundModel
+		case shared.Picker:
+			foregroundModel.UpdateWindowDimensions(msg)
+			model.foreground = foregroundModel
+		case CommandOutput:
+			foregroundModel.UpdateWindowDimensions(msg)
+			model.foreground = foregroundModel
+		case shared.SmartDialog:
+			foregroundModel.UpdateWindowDimensions(msg)
+			model.foreground = foregroundModel
 		}
 	}
 }
 
 func (model Model) Init() tea.Cmd {
-	return tickCmd()
+	cmds := []tea.Cmd{fetchHealth, healthTickCmd()}
+	if model.alertsErr != nil {
+		cmds = append(cmds, notifications.ShowError(model.alertsErr))
+	}
+	if len(model.alerts.rules) > 0 {
+		cmds = append(cmds, model.alerts.waitForAlert)
+	}
+	return tea.Batch(cmds...)
 }
 
 func (model Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -183,6 +222,10 @@ func (model Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 				return model, nil
 			}
+
+			if model.focusedView == focusDetails && key.Matches(keyMsg, model.detailsKeybindings.Edit) {
+				return model, model.openUpdateForm()
+			}
 		}
 	}
 
@@ -194,14 +237,200 @@ func (model Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		foregroundModel, foregroundCmd := model.foreground.Update(msg)
 		model.foreground = foregroundModel
 		cmds = append(cmds, foregroundCmd)
+
+	case viewLogs:
+		updatedViewer, viewerCmd := model.logViewer.Update(msg)
+		model.logViewer = updatedViewer.(logs.Viewer)
+		cmds = append(cmds, viewerCmd)
+
+	case viewCopy:
+		updatedPicker, pickerCmd := model.copyPicker.Update(msg)
+		model.copyPicker = updatedPicker.(CopyPicker)
+		cmds = append(cmds, pickerCmd)
 	}
 
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		model.UpdateWindowDimensions(msg)
 
-	case MessageCloseOverlay:
+	case MessageCloseOverlay, shared.CloseDialogMessage:
+		// The log viewer closes its own dialogs.
+		if model.sessionState == viewOverlay {
+			model.sessionState = viewMain
+		}
+
+	case exportDoneMsg:
+		form, isOpen := model.foreground.(shared.Form)
+		isOpen = isOpen && model.sessionState == viewOverlay && form.Action().Type == "ExportContainers"
+		switch {
+		case msg.err != nil && isOpen:
+			model.foreground = shared.SetFormError(model.foreground, msg.err)
+		case msg.err != nil:
+			cmds = append(cmds, notifications.ShowError(msg.err))
+		default:
+			if isOpen {
+				model.sessionState = viewMain
+			}
+			cmds = append(cmds, notifications.ShowSuccess(msg.success))
+		}
+
+	case logs.CloseMsg:
+		model.sessionState = viewMain
+		model.logViewer = logs.Viewer{}
+
+	case closeCopyMsg:
 		model.sessionState = viewMain
+		model.copyPicker = CopyPicker{}
+
+	case MessageOpenCopyFiles:
+		inspection, err := context.GetClient().InspectContainer(msg.container.ID)
+		if err != nil {
+			cmds = append(cmds, notifications.ShowError(err))
+			break
+		}
+		model.copyPicker = newCopyPicker(msg.container.ID, msg.container.Name, inspection.Config.WorkingDir)
+		model.copyPicker.UpdateWindowDimensions(tea.WindowSizeMsg{Width: model.WindowWidth, Height: model.WindowHeight})
+		model.sessionState = viewCopy
+		cmds = append(cmds, model.copyPicker.Init())
+
+	case MessageOpenLogs:
+		model.logViewer = logs.New(logsTitle(msg.sources), msg.sources)
+		model.logViewer.UpdateWindowDimensions(tea.WindowSizeMsg{Width: model.WindowWidth, Height: model.WindowHeight})
+		model.sessionState = viewLogs
+		cmds = append(cmds, model.logViewer.Init())
+
+	case shared.FormSubmitMessage:
+		if msg.Action.Type == "UpdateContainer" {
+			target := msg.Action.Payload.(updateTarget)
+			warnings, err := updateContainer(target, msg.Values)
+			if err != nil {
+				model.foreground = shared.SetFormError(model.foreground, err)
+				break
+			}
+			model.sessionState = viewMain
+			cmds = append(cmds, notifications.ShowSuccess("Updated "+target.containerName), inspectContainer(target.containerID))
+			if len(warnings) > 0 {
+				cmds = append(cmds, notifications.ShowInfo(strings.Join(warnings, "\n")))
+			}
+		}
+		if msg.Action.Type == "ExportContainers" {
+			cmds = append(cmds, runExport(msg.Action.Payload.(exportTarget), msg.Values))
+		}
+		if msg.Action.Type == "RecreateContainer" {
+			target := msg.Action.Payload.(recreateTarget)
+			options, err := parseRecreateForm(msg.Values)
+			if err != nil {
+				model.foreground = shared.SetFormError(model.foreground, err)
+				break
+			}
+			model.sessionState = viewMain
+			if containerList, ok := model.background.(ContainerList); ok {
+				containerList.setWorkingState([]string{target.containerID}, true)
+				model.background = containerList
+			}
+			cmds = append(cmds, recreateContainer(target, options))
+		}
+		if msg.Action.Type == "RunCommand" {
+			targets := msg.Action.Payload.([]commandTarget)
+			options, err := parseCommandForm(msg.Values)
+			if err != nil {
+				model.foreground = shared.SetFormError(model.foreground, err)
+				break
+			}
+			for _, target := range targets {
+				model.commandHistory.add(target.image, options)
+			}
+			model.commandRuns++
+			ctx, cancel := stdcontext.WithCancel(stdcontext.Background())
+			model.foreground = newCommandOutput(model.commandRuns, targets, options, cancel)
+			cmds = append(cmds, runCommand(ctx, model.commandRuns, targets, options))
+		}
+		if msg.Action.Type == "CreateCheckpoint" {
+			target := msg.Action.Payload.(checkpointTarget)
+			name, leaveRunning, err := parseCheckpointForm(msg.Values)
+			if err != nil {
+				model.foreground = shared.SetFormError(model.foreground, err)
+				break
+			}
+			model.sessionState = viewMain
+			cmds = append(cmds, runCheckpointOperation(checkpointCreate, checkpointChoice{target: target, name: name}, leaveRunning))
+		}
+
+	case shared.ConfirmationMessage:
+		if msg.Action.Type == "PickCommand" {
+			form := newCommandForm(msg.Action.Payload.(commandDraft))
+			model.foreground = form
+			cmds = append(cmds, form.Init())
+		}
+		if msg.Action.Type == "PickCheckpoint" {
+			choice := msg.Action.Payload.(checkpointChoice)
+			if choice.name == "" {
+				model.foreground = newCheckpointForm(choice.target)
+			} else {
+				model.foreground = newCheckpointDialog(choice)
+			}
+			cmds = append(cmds, model.foreground.Init())
+		}
+		if msg.Action.Type == "StartFromCheckpoint" {
+			model.sessionState = viewMain
+			cmds = append(cmds, runCheckpointOperation(checkpointStart, msg.Action.Payload.(checkpointChoice), false))
+		}
+		if msg.Action.Type == "DeleteCheckpoint" {
+			model.sessionState = viewMain
+			cmds = append(cmds, runCheckpointOperation(checkpointDelete, msg.Action.Payload.(checkpointChoice), false))
+		}
+
+	case MsgCheckpoints:
+		switch {
+		case msg.Err != nil:
+			cmds = append(cmds, notifications.ShowError(msg.Err))
+		case !msg.Supported:
+			model.foreground = shared.NewSmartDialog(checkpointsUnsupported, []shared.DialogButton{{Label: "Close", IsSafe: true}})
+			model.sessionState = viewOverlay
+		case len(msg.Checkpoints) == 0 && msg.target.state != "running":
+			cmds = append(cmds, notifications.ShowInfo(msg.target.containerName+" has no checkpoints, and must be running to create one"))
+		case len(msg.Checkpoints) == 0:
+			model.foreground = newCheckpointForm(msg.target)
+			model.sessionState = viewOverlay
+			cmds = append(cmds, model.foreground.Init())
+		default:
+			model.foreground = newCheckpointPicker(msg.target, msg.Checkpoints)
+			model.sessionState = viewOverlay
+		}
+
+	case MsgCheckpointResult:
+		cmds = append(cmds, handleCheckpointResult(msg))
+
+	case MessageOpenCommandForm:
+		images := make([]string, 0, len(msg.targets))
+		for _, target := range msg.targets {
+			images = append(images, target.image)
+		}
+		if entries := model.commandHistory.recent(images); len(entries) > 0 {
+			model.foreground = newCommandPicker(msg.targets, entries)
+		} else {
+			model.foreground = newCommandForm(commandDraft{targets: msg.targets})
+		}
+		model.sessionState = viewOverlay
+		cmds = append(cmds, model.foreground.Init())
+
+	case MessageOpenExportForm:
+		model.foreground = newExportForm(msg.containerIDs)
+		model.sessionState = viewOverlay
+		cmds = append(cmds, model.foreground.Init())
+
+	case MessageOpenRecreateForm:
+		inspection, err := context.GetClient().InspectContainer(msg.container.ID)
+		if err != nil {
+			cmds = append(cmds, notifications.ShowError(err))
+			break
+		}
+		// Without the image, its environment and labels are carried over to the new container.
+		imageConfig, _ := context.GetClient().InspectImageConfig(inspection.Image)
+		form := newRecreateForm(recreateTarget{containerID: msg.container.ID, containerName: msg.container.Name}, client.NewRecreateOptions(inspection, imageConfig))
+		model.foreground = form
+		model.sessionState = viewOverlay
+		cmds = append(cmds, form.Init())
 
 	case MessageOpenDeleteConfirmationDialog:
 		model.foreground = newDeleteConfirmation(msg.requestedContainersToDelete...)
@@ -211,6 +440,29 @@ func (model Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case MsgStatsTick:
 		cmds = append(cmds, model.handleStatsTick()...)
 
+	case MsgHealthTick:
+		cmds = append(cmds, fetchHealth, healthTickCmd())
+
+	case MsgAlert:
+		cmds = append(cmds, model.alerts.handleAlert(msg), model.alerts.waitForAlert)
+		if containerList, ok := model.background.(ContainerList); ok {
+			containerList.applyAlertHits(model.alerts.hits)
+			model.background = containerList
+		}
+
+	case MsgContainerHealth:
+		if msg.Err == nil {
+			model.alerts.sync(msg.Containers)
+		}
+		if containerList, ok := model.background.(ContainerList); ok {
+			cmds = append(cmds, containerList.handleContainerHealth(msg))
+			model.background = containerList
+			// Refresh the health log of the selected container.
+			if containerItem, ok := containerList.list.SelectedItem().(ContainerItem); ok && containerItem.Health != "" {
+				cmds = append(cmds, inspectContainer(containerItem.ID))
+			}
+		}
+
 	case MsgContainerInspection:
 		if msg.ID == model.currentContainerID && msg.Err == nil {
 			model.inspection = msg.Container
@@ -268,10 +520,7 @@ func (model *Model) updateMainView(msg tea.Msg) []tea.Cmd {
 				if containerItem.ID != model.currentContainerID {
 					model.currentContainerID = containerItem.ID
 					model.cpuHistory = make([]float64, 0)
-					cmds = append(cmds, func() tea.Msg {
-						containerInfo, err := context.GetClient().InspectContainer(containerItem.ID)
-						return MsgContainerInspection{ID: containerItem.ID, Container: containerInfo, Err: err}
-					})
+					cmds = append(cmds, inspectContainer(containerItem.ID))
 				}
 			}
 		}
@@ -279,6 +528,51 @@ func (model *Model) updateMainView(msg tea.Msg) []tea.Cmd {
 	return cmds
 }
 
+// openUpdateForm opens the resource form of the selected container, filled with its current settings.
+func (model *Model) openUpdateForm() tea.Cmd {
+	containerList, ok := model.background.(ContainerList)
+	if !ok {
+		return nil
+	}
+	containerItem, ok := containerList.list.SelectedItem().(ContainerItem)
+	if !ok || containerItem.isWorking {
+		return nil
+	}
+
+	inspection := model.inspection
+	if inspection.ContainerJSONBase == nil || inspection.ID != containerItem.ID {
+		var err error
+		inspection, err = context.GetClient().InspectContainer(containerItem.ID)
+		if err != nil {
+			return notifications.ShowError(err)
+		}
+	}
+
+	form := newUpdateForm(updateTarget{
+		containerID:   containerItem.ID,
+		containerName: containerItem.Name,
+		resources:     client.NewContainerResources(inspection.HostConfig),
+	})
+	model.foreground = form
+	model.sessionState = viewOverlay
+	return form.Init()
+}
+
+// logsTitle names the containers whose logs are shown.
+func logsTitle(sources []logs.Source) string {
+	if len(sources) == 1 {
+		return "Logs of " + sources[0].Name
+	}
+	return fmt.Sprintf("Logs of %d containers", len(sources))
+}
+
+func inspectContainer(containerID string) tea.Cmd {
+	return func() tea.Msg {
+		containerInfo, err := context.GetClient().InspectContainer(containerID)
+		return MsgContainerInspection{ID: containerID, Container: containerInfo, Err: err}
+	}
+}
+
 func (model *Model) handleStatsTick() []tea.Cmd {
 	var cmds []tea.Cmd
 	cmds = append(cmds, tickCmd())
@@ -303,6 +597,12 @@ func (model Model) View() string {
 	if model.sessionState == viewOverlay && model.foreground != nil {
 		return model.overlayModel.View()
 	}
+	if model.sessionState == viewLogs {
+		return model.logViewer.View()
+	}
+	if model.sessionState == viewCopy {
+		return model.copyPicker.View()
+	}
 
 	layoutManager := shared.NewLayoutManager(model.WindowWidth, model.WindowHeight)
 	_, detailLayout := layoutManager.CalculateMasterDetail(lipgloss.NewStyle())
@@ -334,6 +634,12 @@ func (model Model) View() string {
 }
 
 func (model Model) ShortHelp() []key.Binding {
+	if model.sessionState == viewLogs {
+		return model.logViewer.ShortHelp()
+	}
+	if model.sessionState == viewCopy {
+		return model.copyPicker.ShortHelp()
+	}
 	if model.sessionState == viewOverlay {
 		if helpKeyMap, ok := model.foreground.(help.KeyMap); ok {
 			return helpKeyMap.ShortHelp()
@@ -350,6 +656,7 @@ func (model Model) ShortHelp() []key.Binding {
 		return []key.Binding{
 			model.detailsKeybindings.Up,
 			model.detailsKeybindings.Down,
+			model.detailsKeybindings.Edit,
 		}
 	}
 
@@ -357,6 +664,12 @@ func (model Model) ShortHelp() []key.Binding {
 }
 
 func (model Model) FullHelp() [][]key.Binding {
+	if model.sessionState == viewLogs {
+		return model.logViewer.FullHelp()
+	}
+	if model.sessionState == viewCopy {
+		return model.copyPicker.FullHelp()
+	}
 	if model.sessionState == viewOverlay {
 		return nil
 	}
@@ -371,6 +684,7 @@ func (model Model) FullHelp() [][]key.Binding {
 			{
 				model.detailsKeybindings.Up,
 				model.detailsKeybindings.Down,
+				model.detailsKeybindings.Edit,
 				model.detailsKeybindings.Switch,
 			},
 		}
@@ -418,11 +732,30 @@ func formatInspection(container types.ContainerJSON, containerStats client.Conta
 
 	sectionHeader := lipgloss.NewStyle().Bold(true).Foreground(colors.Primary()).Underline(true).MarginTop(1).MarginBottom(0)
 
+	if health := formatHealth(container.State.Health, sectionHeader); health != "" {
+		builder.WriteString(health + "\n")
+	}
+
 	builder.WriteString(sectionHeader.Render("Configuration") + "\n")
 	builder.WriteString(fmt.Sprintf("Cmd: %v\n", container.Config.Cmd))
 	builder.WriteString(fmt.Sprintf("Entrypoint: %v\n", container.Config.Entrypoint))
 	builder.WriteString(fmt.Sprintf("WorkingDir: %s\n", container.Config.WorkingDir))
 
+	builder.WriteString("\n" + sectionHeader.Render("Resources") + "\n")
+	resources := formatResources(client.NewContainerResources(container.HostConfig))
+	for _, resource := range []struct{ key, label string }{
+		{"restart", "Restart policy"},
+		{"cpuShares", "CPU shares"},
+		{"cpuPeriod", "CPU period"},
+		{"cpuQuota", "CPU quota"},
+		{"cpuset", "CPU set"},
+		{"memory", "Memory"},
+		{"memorySwap", "Memory and swap"},
+		{"pidsLimit", "PIDs limit"},
+	} {
+		builder.WriteString(fmt.Sprintf("%s: %s\n", resource.label, describeResource(resource.key, resources[resource.key])))
+	}
+
 	if len(container.Config.Env) > 0 {
 		builder.WriteString("\n" + sectionHeader.Render("Environment Variables") + "\n")
 		for _, envVar := range container.Config.Env {
@@ -450,3 +783,26 @@ func formatInspection(container types.ContainerJSON, containerStats client.Conta
 
 	return builder.String()
 }
+
+// IsCapturingInput reports whether keys are being typed into a filter or a form.
+func (model Model) IsCapturingInput() bool {
+	if model.sessionState == viewLogs {
+		return model.logViewer.IsCapturingInput()
+	}
+	if model.sessionState == viewCopy {
+		return true // Tab switches between the panes.
+	}
+	if model.sessionState == viewOverlay {
+		switch foregroundModel := model.foreground.(type) {
+		case shared.Form:
+			return true
+		case shared.Picker:
+			return foregroundModel.IsFiltering()
+		}
+		return false
+	}
+	if containerList, ok := model.background.(ContainerList); ok {
+		return containerList.list.FilterState() == list.Filtering
+	}
+	return false
+}
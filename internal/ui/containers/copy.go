@@ -0,0 +1,591 @@
+package containers
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/docker/go-units"
+	"github.com/givensuman/containertui/internal/client"
+	"github.com/givensuman/containertui/internal/colors"
+	"github.com/givensuman/containertui/internal/context"
+	"github.com/givensuman/containertui/internal/ui/notifications"
+	"github.com/givensuman/containertui/internal/ui/shared"
+)
+
+// MessageOpenCopyFiles indicates the user requested to copy files between the host and a container.
+type MessageOpenCopyFiles struct {
+	container ContainerItem
+}
+
+// closeCopyMsg is sent when the user leaves the copy picker.
+type closeCopyMsg struct{}
+
+type copyFilesMsg struct {
+	side     copySide
+	dir      string
+	files    []client.VolumeFile
+	selected string // Name of the file to select, e.g. the directory we came from.
+	err      error
+}
+
+type copyProgressMsg struct {
+	transferred int64
+	total       int64
+}
+
+type copyDoneMsg struct {
+	transfer copyTransfer
+	err      error
+}
+
+// copySide is a pane of the copy picker.
+type copySide int
+
+const (
+	sideHost copySide = iota
+	sideContainer
+)
+
+func (side copySide) other() copySide {
+	return 1 - side
+}
+
+// copyTransfer is a copy of a file or a directory from a side to the directory of the other.
+type copyTransfer struct {
+	from    copySide
+	source  client.VolumeFile
+	destDir string
+}
+
+type copyKeybindings struct {
+	open          key.Binding
+	parent        key.Binding
+	switchPane    key.Binding
+	copy          key.Binding
+	toggleModes   key.Binding
+	toggleSymlink key.Binding
+	close         key.Binding
+}
+
+func newCopyKeybindings() copyKeybindings {
+	return copyKeybindings{
+		open: key.NewBinding(
+			key.WithKeys("enter", "right", "l"),
+			key.WithHelp("enter", "open directory"),
+		),
+		parent: key.NewBinding(
+			key.WithKeys("backspace", "left", "h"),
+			key.WithHelp("backspace", "parent directory"),
+		),
+		switchPane: key.NewBinding(
+			key.WithKeys("tab"),
+			key.WithHelp("tab", "switch pane"),
+		),
+		copy: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", "copy to the other pane"),
+		),
+		toggleModes: key.NewBinding(
+			key.WithKeys("m"),
+			key.WithHelp("m", "toggle preserving modes"),
+		),
+		toggleSymlink: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "toggle preserving symlinks"),
+		),
+		close: key.NewBinding(
+			key.WithKeys("esc", "q"),
+			key.WithHelp("esc", "close"),
+		),
+	}
+}
+
+// copyFileItem is an entry of a directory of a pane.
+type copyFileItem struct {
+	file client.VolumeFile
+}
+
+var (
+	_ list.Item        = (*copyFileItem)(nil)
+	_ list.DefaultItem = (*copyFileItem)(nil)
+)
+
+func (item copyFileItem) Title() string {
+	name := item.file.Name
+	color := colors.Text()
+	switch {
+	case item.file.Mode.IsDir():
+		name += "/"
+		color = colors.Primary()
+	case item.file.Mode&os.ModeSymlink != 0:
+		name += "@"
+		color = colors.Muted()
+	}
+	return lipgloss.NewStyle().Foreground(color).Render(name)
+}
+
+func (item copyFileItem) Description() string {
+	size := units.HumanSize(float64(item.file.Size))
+	if item.file.Mode.IsDir() {
+		size = "directory"
+	}
+	return fmt.Sprintf("%s • %s", size, item.file.Mode)
+}
+
+func (item copyFileItem) FilterValue() string {
+	return item.file.Name
+}
+
+// copyPane lists a directory of the host or of the container.
+type copyPane struct {
+	dir    string
+	list   list.Model
+	status string // Shown instead of the list while loading, or on errors.
+}
+
+func newCopyPane(dir string) copyPane {
+	listModel := list.New([]list.Item{}, shared.ChangeDelegateStyles(list.NewDefaultDelegate()), 0, 0)
+	listModel.SetShowHelp(false)
+	listModel.SetShowTitle(false)
+	listModel.SetShowStatusBar(false)
+	listModel.SetFilteringEnabled(true)
+	listModel.KeyMap.Quit.SetEnabled(false)
+	listModel.Styles.FilterPrompt = lipgloss.NewStyle().Foreground(colors.Primary())
+	listModel.Styles.FilterCursor = lipgloss.NewStyle().Foreground(colors.Primary())
+	listModel.FilterInput.PromptStyle = lipgloss.NewStyle().Foreground(colors.Primary())
+	listModel.FilterInput.Cursor.Style = lipgloss.NewStyle().Foreground(colors.Primary())
+
+	return copyPane{dir: dir, list: listModel, status: "Loading..."}
+}
+
+// hasFile reports whether the directory of the pane has an entry with the name.
+func (pane copyPane) hasFile(name string) bool {
+	for _, item := range pane.list.Items() {
+		if item.(copyFileItem).file.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// CopyPicker copies files between the host and a container, browsing both
+// side by side. The selected file of the focused pane is copied to the
+// directory shown in the other.
+type CopyPicker struct {
+	shared.Component
+	style         lipgloss.Style
+	containerID   string
+	containerName string
+	panes         [2]copyPane
+	focused       copySide
+	options       client.CopyOptions
+	keybindings   copyKeybindings
+	transfer      *copyTransfer // The copy in progress.
+	transferred   int64
+	total         int64
+	updates       chan tea.Msg
+	overwrite     *copyTransfer // The copy waiting for a confirmation to overwrite.
+	status        string
+}
+
+var (
+	_ tea.Model             = (*CopyPicker)(nil)
+	_ shared.ComponentModel = (*CopyPicker)(nil)
+)
+
+// newCopyPicker opens the working directory of the host next to the one of the container.
+func newCopyPicker(containerID, containerName, containerDir string) CopyPicker {
+	width, height := context.GetWindowSize()
+
+	hostDir, err := os.Getwd()
+	if err != nil {
+		hostDir = string(filepath.Separator)
+	}
+	if containerDir == "" {
+		containerDir = "/"
+	}
+
+	picker := CopyPicker{
+		style:         lipgloss.NewStyle().PaddingTop(1).PaddingLeft(2).PaddingRight(2),
+		containerID:   containerID,
+		containerName: containerName,
+		panes:         [2]copyPane{newCopyPane(hostDir), newCopyPane(containerDir)},
+		focused:       sideHost,
+		options:       client.CopyOptions{PreserveModes: true, PreserveSymlinks: true},
+		keybindings:   newCopyKeybindings(),
+	}
+	picker.UpdateWindowDimensions(tea.WindowSizeMsg{Width: width, Height: height})
+	return picker
+}
+
+func (picker *CopyPicker) UpdateWindowDimensions(msg tea.WindowSizeMsg) {
+	picker.WindowWidth = msg.Width
+	picker.WindowHeight = msg.Height
+
+	picker.style = picker.style.Width(msg.Width).Height(msg.Height)
+	paneWidth, paneHeight := picker.paneSize()
+	for side := range picker.panes {
+		// Leave room for the border and the path.
+		picker.panes[side].list.SetSize(shared.Max(paneWidth-2, 0), shared.Max(paneHeight-3, 0))
+	}
+}
+
+// paneSize is the size of each pane, below the title and above the status.
+func (picker CopyPicker) paneSize() (int, int) {
+	width := (picker.WindowWidth - picker.style.GetHorizontalFrameSize()) / 2
+	height := picker.WindowHeight - picker.style.GetVerticalFrameSize() - 5 // The title, the status and blank lines.
+	return shared.Max(width, 0), shared.Max(height, 0)
+}
+
+func (picker CopyPicker) Init() tea.Cmd {
+	return tea.Batch(
+		picker.listDirectory(sideHost, picker.panes[sideHost].dir, ""),
+		picker.listDirectory(sideContainer, picker.panes[sideContainer].dir, ""),
+	)
+}
+
+func (picker CopyPicker) listDirectory(side copySide, dir, selected string) tea.Cmd {
+	containerID := picker.containerID
+	return func() tea.Msg {
+		var files []client.VolumeFile
+		var err error
+		if side == sideHost {
+			files, err = listHostDirectory(dir)
+		} else {
+			files, err = context.GetClient().ListContainerDirectory(containerID, dir)
+		}
+		return copyFilesMsg{side: side, dir: dir, files: files, selected: selected, err: err}
+	}
+}
+
+// listHostDirectory lists the entries of a directory of the host, like those of a container.
+func listHostDirectory(dir string) ([]client.VolumeFile, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]client.VolumeFile, 0, len(entries))
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil {
+			continue // Removed in the meantime.
+		}
+		files = append(files, client.VolumeFile{
+			Name:    entry.Name(),
+			Path:    filepath.Join(dir, entry.Name()),
+			Size:    info.Size(),
+			Mode:    info.Mode(),
+			ModTime: info.ModTime(),
+		})
+	}
+	return files, nil
+}
+
+func (picker CopyPicker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		picker.UpdateWindowDimensions(msg)
+		return picker, nil
+
+	case copyFilesMsg:
+		pane := &picker.panes[msg.side]
+		if msg.err != nil {
+			pane.status = msg.err.Error()
+			return picker, nil
+		}
+		pane.dir = msg.dir
+		pane.status = ""
+		pane.list.ResetFilter()
+
+		items := copyFileItems(msg.files)
+		cmd := pane.list.SetItems(items)
+		pane.list.Select(0)
+		for index, item := range items {
+			if item.(copyFileItem).file.Name == msg.selected {
+				pane.list.Select(index)
+				break
+			}
+		}
+		return picker, cmd
+
+	case copyProgressMsg:
+		picker.transferred = msg.transferred
+		picker.total = msg.total
+		return picker, waitForCopy(picker.updates)
+
+	case copyDoneMsg:
+		picker.transfer = nil
+		picker.status = ""
+		to := msg.transfer.from.other()
+		refresh := picker.listDirectory(to, picker.panes[to].dir, msg.transfer.source.Name)
+		if msg.err != nil {
+			return picker, tea.Batch(refresh, notifications.ShowError(msg.err))
+		}
+		return picker, tea.Batch(refresh, notifications.ShowSuccess(fmt.Sprintf("Copied %s to %s", msg.transfer.source.Name, picker.describeDir(to, msg.transfer.destDir))))
+
+	case tea.KeyMsg:
+		pane := &picker.panes[picker.focused]
+		if pane.list.FilterState() == list.Filtering {
+			break
+		}
+
+		if picker.overwrite != nil {
+			transfer := *picker.overwrite
+			picker.overwrite = nil
+			picker.status = ""
+			if key.Matches(msg, picker.keybindings.copy) {
+				return picker, picker.startCopy(transfer)
+			}
+			return picker, nil
+		}
+
+		switch {
+		case key.Matches(msg, picker.keybindings.close):
+			if pane.list.FilterState() == list.FilterApplied && msg.String() == "esc" {
+				break
+			}
+			if picker.transfer != nil {
+				picker.status = "Please wait until the copy completes."
+				return picker, nil
+			}
+			return picker, func() tea.Msg { return closeCopyMsg{} }
+
+		case key.Matches(msg, picker.keybindings.switchPane):
+			picker.focused = picker.focused.other()
+			return picker, nil
+
+		case key.Matches(msg, picker.keybindings.toggleModes):
+			picker.options.PreserveModes = !picker.options.PreserveModes
+			return picker, nil
+
+		case key.Matches(msg, picker.keybindings.toggleSymlink):
+			picker.options.PreserveSymlinks = !picker.options.PreserveSymlinks
+			return picker, nil
+
+		case key.Matches(msg, picker.keybindings.open):
+			item, ok := pane.list.SelectedItem().(copyFileItem)
+			if !ok || !picker.isDirectory(picker.focused, item.file) {
+				return picker, nil
+			}
+			return picker, picker.listDirectory(picker.focused, item.file.Path, "")
+
+		case key.Matches(msg, picker.keybindings.parent):
+			parent := picker.parentDir(picker.focused)
+			if parent == pane.dir {
+				return picker, nil
+			}
+			return picker, picker.listDirectory(picker.focused, parent, picker.baseName(picker.focused, pane.dir))
+
+		case key.Matches(msg, picker.keybindings.copy):
+			item, ok := pane.list.SelectedItem().(copyFileItem)
+			if !ok || picker.transfer != nil {
+				return picker, nil
+			}
+			to := picker.focused.other()
+			if picker.panes[to].status != "" {
+				return picker, notifications.ShowInfo("The other pane has no directory to copy to")
+			}
+			transfer := copyTransfer{from: picker.focused, source: item.file, destDir: picker.panes[to].dir}
+			if picker.panes[to].hasFile(item.file.Name) {
+				picker.overwrite = &transfer
+				picker.status = fmt.Sprintf("%s exists in %s, press c again to overwrite it.", item.file.Name, picker.describeDir(to, transfer.destDir))
+				return picker, nil
+			}
+			return picker, picker.startCopy(transfer)
+		}
+	}
+
+	var cmd tea.Cmd
+	picker.panes[picker.focused].list, cmd = picker.panes[picker.focused].list.Update(msg)
+	return picker, cmd
+}
+
+// isDirectory reports whether a file can be opened, following symlinks of the host.
+func (picker CopyPicker) isDirectory(side copySide, file client.VolumeFile) bool {
+	if side == sideHost && file.Mode&os.ModeSymlink != 0 {
+		info, err := os.Stat(file.Path)
+		return err == nil && info.IsDir()
+	}
+	return file.Mode.IsDir()
+}
+
+func (picker CopyPicker) parentDir(side copySide) string {
+	if side == sideHost {
+		return filepath.Dir(picker.panes[side].dir)
+	}
+	return path.Dir(picker.panes[side].dir)
+}
+
+func (picker CopyPicker) baseName(side copySide, dir string) string {
+	if side == sideHost {
+		return filepath.Base(dir)
+	}
+	return path.Base(dir)
+}
+
+// describeDir names a directory of a side, e.g. "web:/srv".
+func (picker CopyPicker) describeDir(side copySide, dir string) string {
+	if side == sideHost {
+		return dir
+	}
+	return picker.containerName + ":" + dir
+}
+
+// startCopy runs a copy in the background, reporting progress and completion through updates.
+func (picker *CopyPicker) startCopy(transfer copyTransfer) tea.Cmd {
+	picker.transfer = &transfer
+	picker.transferred, picker.total = 0, 0
+	picker.updates = make(chan tea.Msg, 1)
+
+	updates := picker.updates
+	containerID := picker.containerID
+	options := picker.options
+	run := func() tea.Msg {
+		progress := func(transferred, total int64) {
+			select {
+			case updates <- copyProgressMsg{transferred: transferred, total: total}:
+			default: // The previous update has not been rendered yet, skip this one.
+			}
+		}
+
+		var err error
+		if transfer.from == sideHost {
+			err = context.GetClient().CopyToContainer(containerID, transfer.source.Path, transfer.destDir, options, progress)
+		} else {
+			err = context.GetClient().CopyFromContainer(containerID, transfer.source.Path, transfer.destDir, options, progress)
+		}
+
+		// Make room for the final message, a pending progress update is stale by now.
+		select {
+		case <-updates:
+		default:
+		}
+		updates <- copyDoneMsg{transfer: transfer, err: err}
+		return nil
+	}
+	return tea.Batch(run, waitForCopy(updates))
+}
+
+func waitForCopy(updates <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		return <-updates
+	}
+}
+
+func (picker CopyPicker) View() string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(colors.Primary())
+	mutedStyle := lipgloss.NewStyle().Foreground(colors.Muted())
+
+	modes, symlinks := "modes reset", "symlinks followed"
+	if picker.options.PreserveModes {
+		modes = "modes preserved"
+	}
+	if picker.options.PreserveSymlinks {
+		symlinks = "symlinks preserved"
+	}
+	title := titleStyle.Render("Copy files of "+picker.containerName) + mutedStyle.Render(fmt.Sprintf("  %s • %s", modes, symlinks))
+
+	paneWidth, paneHeight := picker.paneSize()
+	panes := make([]string, len(picker.panes))
+	for side, pane := range picker.panes {
+		borderColor := colors.Muted()
+		if copySide(side) == picker.focused {
+			borderColor = colors.Primary()
+		}
+		content := pane.list.View()
+		if pane.status != "" {
+			content = mutedStyle.Render(pane.status)
+		}
+		header := titleStyle.Render(picker.describeDir(copySide(side), pane.dir))
+		panes[side] = lipgloss.NewStyle().
+			Width(shared.Max(paneWidth-2, 0)).
+			Height(shared.Max(paneHeight-2, 0)).
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(borderColor).
+			Render(lipgloss.JoinVertical(lipgloss.Left, header, content))
+	}
+
+	status := mutedStyle.Render(picker.status)
+	if picker.transfer != nil {
+		transferred := units.HumanSize(float64(picker.transferred))
+		var bar string
+		if picker.total > 0 {
+			bar = shared.RenderProgressBar(shared.Max(paneWidth, 0), float64(picker.transferred)/float64(picker.total)) + " "
+			transferred += " / " + units.HumanSize(float64(picker.total))
+		}
+		to := picker.transfer.from.other()
+		status = fmt.Sprintf("Copying %s to %s  %s%s", picker.transfer.source.Name, picker.describeDir(to, picker.transfer.destDir), bar, transferred)
+		if picker.status != "" {
+			status += "  " + mutedStyle.Render(picker.status)
+		}
+	}
+
+	return picker.style.Render(lipgloss.JoinVertical(lipgloss.Left,
+		title,
+		"",
+		lipgloss.JoinHorizontal(lipgloss.Top, panes...),
+		"",
+		status,
+	))
+}
+
+func (picker CopyPicker) ShortHelp() []key.Binding {
+	return []key.Binding{
+		picker.keybindings.open,
+		picker.keybindings.parent,
+		picker.keybindings.switchPane,
+		picker.keybindings.copy,
+		picker.keybindings.close,
+	}
+}
+
+func (picker CopyPicker) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{
+			picker.keybindings.open,
+			picker.keybindings.parent,
+			picker.keybindings.switchPane,
+		},
+		{
+			picker.keybindings.copy,
+			picker.keybindings.toggleModes,
+			picker.keybindings.toggleSymlink,
+			picker.keybindings.close,
+		},
+	}
+}
+
+// copyFileItems sorts the files, directories first, and converts them to list items.
+func copyFileItems(files []client.VolumeFile) []list.Item {
+	sort.Slice(files, func(i, j int) bool {
+		if files[i].Mode.IsDir() != files[j].Mode.IsDir() {
+			return files[i].Mode.IsDir()
+		}
+		return files[i].Name < files[j].Name
+	})
+
+	items := make([]list.Item, 0, len(files))
+	for _, file := range files {
+		items = append(items, copyFileItem{file: file})
+	}
+	return items
+}
+
+func (containerList *ContainerList) handleCopyFiles() tea.Cmd {
+	item, ok := containerList.list.SelectedItem().(ContainerItem)
+	if !ok || item.isWorking {
+		return nil
+	}
+
+	return func() tea.Msg {
+		return MessageOpenCopyFiles{container: item}
+	}
+}
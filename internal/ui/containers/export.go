@@ -0,0 +1,416 @@
+package containers
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aymanbagabas/go-osc52/v2"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/givensuman/containertui/internal/client"
+	"github.com/givensuman/containertui/internal/context"
+	"github.com/givensuman/containertui/internal/shellwords"
+	"github.com/givensuman/containertui/internal/ui/shared"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	exportRun     = "run"
+	exportCompose = "compose"
+)
+
+// anonymousVolumePattern matches the generated names of anonymous volumes.
+var anonymousVolumePattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// exportTarget is the containers exported by the export form.
+type exportTarget struct {
+	containerIDs []string
+}
+
+// MessageOpenExportForm indicates the user requested to export containers.
+type MessageOpenExportForm struct {
+	containerIDs []string
+}
+
+// exportedContainer is the configuration of a container which differs from its image's defaults.
+type exportedContainer struct {
+	name       string
+	options    client.RecreateOptions
+	resources  client.ContainerResources
+	entrypoint []string // Empty when it is the image's.
+}
+
+func newExportedContainer(inspection types.ContainerJSON, imageConfig *container.Config) exportedContainer {
+	exported := exportedContainer{
+		name:      strings.TrimPrefix(inspection.Name, "/"),
+		options:   client.NewRecreateOptions(inspection, imageConfig),
+		resources: client.NewContainerResources(inspection.HostConfig),
+	}
+	if imageConfig == nil {
+		imageConfig = &container.Config{}
+	}
+
+	for key := range exported.options.Labels {
+		if strings.HasPrefix(key, "com.docker.compose.") {
+			delete(exported.options.Labels, key)
+		}
+	}
+	if inspection.Config != nil && !slices.Equal(inspection.Config.Entrypoint, imageConfig.Entrypoint) {
+		exported.entrypoint = inspection.Config.Entrypoint
+	}
+
+	// Anonymous volumes are recreated by the daemon, only their destination matters.
+	for index, mount := range exported.options.Mounts {
+		if source, destination, found := strings.Cut(mount, ":"); found && anonymousVolumePattern.MatchString(source) {
+			exported.options.Mounts[index] = destination
+		}
+	}
+
+	return exported
+}
+
+// formatByteLimit formats a memory limit for docker run and compose, in
+// mebibytes when it is a whole number of them.
+func formatByteLimit(bytes int64) string {
+	const mebibyte = 1 << 20
+	if bytes > 0 && bytes%mebibyte == 0 {
+		return strconv.FormatInt(bytes/mebibyte, 10) + "m"
+	}
+	return strconv.FormatInt(bytes, 10)
+}
+
+func sortedLabels(labels map[string]string) []string {
+	pairs := make([]string, 0, len(labels))
+	for key, value := range labels {
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+	return pairs
+}
+
+// formatRunCommand formats the container as an equivalent `docker run` command, an option per line.
+func formatRunCommand(exported exportedContainer) string {
+	options := exported.options
+	resources := exported.resources
+	lines := [][]string{{"docker", "run", "--detach", "--name", exported.name}}
+
+	if resources.RestartPolicy != "no" {
+		lines = append(lines, []string{"--restart", formatResources(resources)["restart"]})
+	}
+	for index, network := range options.Networks {
+		if index == 0 && network == "bridge" {
+			continue // The default.
+		}
+		lines = append(lines, []string{"--network", network})
+	}
+	for _, port := range options.Ports {
+		lines = append(lines, []string{"--publish", port})
+	}
+	for _, mount := range options.Mounts {
+		lines = append(lines, []string{"--volume", mount})
+	}
+	for _, variable := range options.Env {
+		lines = append(lines, []string{"--env", variable})
+	}
+	for _, label := range sortedLabels(options.Labels) {
+		lines = append(lines, []string{"--label", label})
+	}
+
+	limits := []struct {
+		flag  string
+		value string
+		isSet bool
+	}{
+		{"--cpu-shares", strconv.FormatInt(resources.CPUShares, 10), resources.CPUShares > 0},
+		{"--cpu-period", strconv.FormatInt(resources.CPUPeriod, 10), resources.CPUPeriod > 0},
+		{"--cpu-quota", strconv.FormatInt(resources.CPUQuota, 10), resources.CPUQuota > 0},
+		{"--cpuset-cpus", resources.CpusetCpus, resources.CpusetCpus != ""},
+		{"--memory", formatByteLimit(resources.Memory), resources.Memory > 0},
+		{"--memory-swap", formatByteLimit(resources.MemorySwap), resources.MemorySwap != 0},
+		{"--pids-limit", strconv.FormatInt(resources.PidsLimit, 10), resources.PidsLimit > 0},
+	}
+	for _, limit := range limits {
+		if limit.isSet {
+			lines = append(lines, []string{limit.flag, limit.value})
+		}
+	}
+
+	// --entrypoint takes a single executable, its arguments go before the command.
+	command := options.Command
+	if len(exported.entrypoint) > 0 {
+		lines = append(lines, []string{"--entrypoint", exported.entrypoint[0]})
+		command = append(slices.Clone(exported.entrypoint[1:]), command...)
+	}
+	lines = append(lines, append([]string{options.Image}, command...))
+
+	formatted := make([]string, len(lines))
+	for index, line := range lines {
+		formatted[index] = shellwords.Join(line)
+	}
+	return strings.Join(formatted, " \\\n  ") + "\n"
+}
+
+type composeFile struct {
+	Services map[string]composeService  `yaml:"services"`
+	Networks map[string]composeExternal `yaml:"networks,omitempty"`
+	Volumes  map[string]composeExternal `yaml:"volumes,omitempty"`
+}
+
+// composeExternal declares a network or volume which already exists.
+type composeExternal struct {
+	External bool `yaml:"external"`
+}
+
+type composeService struct {
+	Image         string            `yaml:"image"`
+	ContainerName string            `yaml:"container_name"`
+	Entrypoint    []string          `yaml:"entrypoint,omitempty"`
+	Command       []string          `yaml:"command,omitempty"`
+	Restart       string            `yaml:"restart,omitempty"`
+	NetworkMode   string            `yaml:"network_mode,omitempty"`
+	Networks      []string          `yaml:"networks,omitempty"`
+	Ports         []string          `yaml:"ports,omitempty"`
+	Volumes       []string          `yaml:"volumes,omitempty"`
+	Environment   []string          `yaml:"environment,omitempty"`
+	Labels        map[string]string `yaml:"labels,omitempty"`
+	CPUShares     int64             `yaml:"cpu_shares,omitempty"`
+	CPUPeriod     int64             `yaml:"cpu_period,omitempty"`
+	CPUQuota      int64             `yaml:"cpu_quota,omitempty"`
+	Cpuset        string            `yaml:"cpuset,omitempty"`
+	MemLimit      string            `yaml:"mem_limit,omitempty"`
+	MemswapLimit  string            `yaml:"memswap_limit,omitempty"`
+	PidsLimit     int64             `yaml:"pids_limit,omitempty"`
+}
+
+// formatComposeFile formats the containers as the services of a compose
+// file. The networks and named volumes they use are declared external, as
+// they already exist. Compose interpolates variables in the values, so their
+// dollar signs are escaped.
+func formatComposeFile(containers []exportedContainer) (string, error) {
+	file := composeFile{
+		Services: make(map[string]composeService, len(containers)),
+		Networks: make(map[string]composeExternal),
+		Volumes:  make(map[string]composeExternal),
+	}
+
+	for _, exported := range containers {
+		options := exported.options
+		resources := exported.resources
+		service := composeService{
+			Image:         escapeInterpolation(options.Image),
+			ContainerName: escapeInterpolation(exported.name),
+			Entrypoint:    escapeInterpolations(exported.entrypoint),
+			Command:       escapeInterpolations(options.Command),
+			Ports:         escapeInterpolations(options.Ports),
+			Volumes:       escapeInterpolations(options.Mounts),
+			Environment:   escapeInterpolations(options.Env),
+			CPUShares:     resources.CPUShares,
+			CPUPeriod:     resources.CPUPeriod,
+			CPUQuota:      resources.CPUQuota,
+			Cpuset:        escapeInterpolation(resources.CpusetCpus),
+			PidsLimit:     max(resources.PidsLimit, 0),
+		}
+		if len(options.Labels) > 0 {
+			service.Labels = make(map[string]string, len(options.Labels))
+			for key, value := range options.Labels {
+				service.Labels[key] = escapeInterpolation(value)
+			}
+		}
+		if resources.RestartPolicy != "no" {
+			service.Restart = formatResources(resources)["restart"]
+		}
+		if resources.Memory > 0 {
+			service.MemLimit = formatByteLimit(resources.Memory)
+		}
+		if resources.MemorySwap != 0 {
+			service.MemswapLimit = formatByteLimit(resources.MemorySwap)
+		}
+
+		// Compose cannot attach a service to the default bridge along with other networks.
+		networks := slices.DeleteFunc(slices.Clone(options.Networks), func(network string) bool { return network == "bridge" })
+		var networkMode container.NetworkMode
+		if len(options.Networks) > 0 {
+			networkMode = container.NetworkMode(options.Networks[0])
+		}
+		switch {
+		case networkMode.IsHost() || networkMode.IsNone() || networkMode.IsContainer():
+			service.NetworkMode = escapeInterpolation(string(networkMode))
+		case len(networks) == 0:
+			service.NetworkMode = "bridge"
+		default:
+			service.Networks = escapeInterpolations(networks)
+			for _, network := range networks {
+				file.Networks[network] = composeExternal{External: true}
+			}
+		}
+
+		for _, mount := range options.Mounts {
+			if source, _, found := strings.Cut(mount, ":"); found && !strings.HasPrefix(source, "/") && !strings.HasPrefix(source, ".") {
+				file.Volumes[source] = composeExternal{External: true}
+			}
+		}
+
+		file.Services[composeServiceName(exported.name)] = service
+	}
+
+	var output strings.Builder
+	encoder := yaml.NewEncoder(&output)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(file); err != nil {
+		return "", err
+	}
+	return output.String(), encoder.Close()
+}
+
+// escapeInterpolation escapes the dollar signs of a value, which compose would
+// interpolate as variables.
+func escapeInterpolation(value string) string {
+	return strings.ReplaceAll(value, "$", "$$")
+}
+
+func escapeInterpolations(values []string) []string {
+	if values == nil {
+		return nil
+	}
+	escaped := make([]string, len(values))
+	for index, value := range values {
+		escaped[index] = escapeInterpolation(value)
+	}
+	return escaped
+}
+
+// composeServiceName converts a container name to a valid service name.
+func composeServiceName(name string) string {
+	return strings.Map(func(character rune) rune {
+		switch {
+		case character >= 'a' && character <= 'z', character >= '0' && character <= '9', character == '-', character == '_':
+			return character
+		case character >= 'A' && character <= 'Z':
+			return character - 'A' + 'a'
+		}
+		return '-'
+	}, name)
+}
+
+func newExportForm(containerIDs []string) shared.Form {
+	title := "Export container"
+	if len(containerIDs) > 1 {
+		title = fmt.Sprintf("Export %d containers", len(containerIDs))
+	}
+
+	return shared.NewForm(
+		title,
+		[]shared.FormField{
+			{Key: "format", Label: "Format", Value: exportRun, Hint: "run or compose"},
+			{Key: "path", Label: "File", Placeholder: "empty to copy to the clipboard", Hint: "must not exist"},
+		},
+		shared.SmartDialogAction{Type: "ExportContainers", Payload: exportTarget{containerIDs: containerIDs}},
+	)
+}
+
+// exportDoneMsg is the result of an export. The form stays open until then,
+// to show the error.
+type exportDoneMsg struct {
+	success string
+	err     error
+}
+
+func (exportDoneMsg) IsBackground() {}
+
+// runExport exports the containers to the file or the clipboard of the form.
+func runExport(target exportTarget, values map[string]string) tea.Cmd {
+	format, path := values["format"], values["path"]
+	return func() tea.Msg {
+		content, err := exportContainers(target.containerIDs, format)
+		switch {
+		case err != nil:
+			return exportDoneMsg{err: err}
+		case path != "":
+			return exportDoneMsg{success: "Exported to " + path, err: writeExport(path, content)}
+		}
+		return exportDoneMsg{success: "Copied to the clipboard", err: copyToClipboard(content)}
+	}
+}
+
+// exportContainers formats the containers in the requested format.
+func exportContainers(containerIDs []string, format string) (string, error) {
+	if format != exportRun && format != exportCompose {
+		return "", fmt.Errorf("unknown format %q, expected run or compose", format)
+	}
+
+	containers := make([]exportedContainer, 0, len(containerIDs))
+	for _, containerID := range containerIDs {
+		inspection, err := context.GetClient().InspectContainer(containerID)
+		if err != nil {
+			return "", err
+		}
+		// Without the image, every setting is exported, including the image's defaults.
+		imageConfig, _ := context.GetClient().InspectImageConfig(inspection.Config.Image)
+		containers = append(containers, newExportedContainer(inspection, imageConfig))
+	}
+	sort.Slice(containers, func(i, j int) bool {
+		return containers[i].name < containers[j].name
+	})
+
+	if format == exportCompose {
+		return formatComposeFile(containers)
+	}
+
+	commands := make([]string, len(containers))
+	for index, exported := range containers {
+		commands[index] = formatRunCommand(exported)
+	}
+	return strings.Join(commands, "\n"), nil
+}
+
+// writeExport writes the export to a new file.
+func writeExport(path, content string) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.WriteString(content); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// copyToClipboard sets the clipboard of the terminal through an OSC 52
+// escape sequence, which also works over SSH. Terminals which do not support
+// it ignore the sequence.
+func copyToClipboard(content string) error {
+	sequence := osc52.New(content)
+	if os.Getenv("TMUX") != "" {
+		sequence = sequence.Tmux()
+	} else if strings.HasPrefix(os.Getenv("TERM"), "screen") {
+		sequence = sequence.Screen()
+	}
+	// The sequence goes to stderr, which is also the terminal, so that it is
+	// not interleaved with the frames rendered to stdout.
+	if _, err := sequence.WriteTo(os.Stderr); err != nil {
+		return fmt.Errorf("copying to the clipboard: %w", err)
+	}
+	return nil
+}
+
+func (containerList *ContainerList) handleExportContainers() tea.Cmd {
+	containerIDs := containerList.getSelectedContainerIDs()
+	if len(containerIDs) == 0 {
+		item, ok := containerList.list.SelectedItem().(ContainerItem)
+		if !ok {
+			return nil
+		}
+		containerIDs = []string{item.ID}
+	}
+
+	return func() tea.Msg {
+		return MessageOpenExportForm{containerIDs: containerIDs}
+	}
+}
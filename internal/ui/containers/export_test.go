@@ -0,0 +1,115 @@
+package containers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/go-connections/nat"
+)
+
+func newTestExport() exportedContainer {
+	inspection := types.ContainerJSON{
+		ContainerJSONBase: &types.ContainerJSONBase{
+			Name: "/web",
+			HostConfig: &container.HostConfig{
+				NetworkMode:   "app",
+				RestartPolicy: container.RestartPolicy{Name: "unless-stopped"},
+				PortBindings:  nat.PortMap{"80/tcp": {{HostPort: "8080"}}},
+				Resources:     container.Resources{Memory: 256 << 20},
+			},
+		},
+		Config: &container.Config{
+			Image:  "nginx:1.25",
+			Env:    []string{"PATH=/usr/bin", "GREETING=hello world"},
+			Cmd:    []string{"nginx", "-g", "daemon off;"},
+			Labels: map[string]string{"maintainer": "nginx", "team": "web", "com.docker.compose.project": "site"},
+		},
+		Mounts: []types.MountPoint{
+			{Type: mount.TypeVolume, Name: "data", Destination: "/data", RW: true},
+			{Type: mount.TypeVolume, Name: strings.Repeat("ab", 32), Destination: "/cache", RW: true},
+		},
+		NetworkSettings: &types.NetworkSettings{
+			Networks: map[string]*network.EndpointSettings{"app": {}},
+		},
+	}
+	imageConfig := &container.Config{
+		Env:    []string{"PATH=/usr/bin"},
+		Cmd:    []string{"nginx", "-g", "daemon off;"},
+		Labels: map[string]string{"maintainer": "nginx"},
+	}
+
+	return newExportedContainer(inspection, imageConfig)
+}
+
+func TestFormatRunCommand(t *testing.T) {
+	expected := strings.Join([]string{
+		"docker run --detach --name web",
+		"--restart unless-stopped",
+		"--network app",
+		"--publish 8080:80/tcp",
+		"--volume data:/data",
+		"--volume /cache",
+		"--env 'GREETING=hello world'",
+		"--label team=web",
+		"--memory 256m",
+		"nginx:1.25",
+	}, " \\\n  ") + "\n"
+
+	if result := formatRunCommand(newTestExport()); result != expected {
+		t.Errorf("formatRunCommand() =\n%s\nwant\n%s", result, expected)
+	}
+}
+
+func TestFormatComposeFile(t *testing.T) {
+	result, err := formatComposeFile([]exportedContainer{newTestExport()})
+	if err != nil {
+		t.Fatalf("formatComposeFile() error = %v", err)
+	}
+
+	for _, expected := range []string{
+		"services:\n  web:\n    image: nginx:1.25\n    container_name: web\n    restart: unless-stopped\n",
+		"    networks:\n      - app\n",
+		"    mem_limit: 256m\n",
+		"networks:\n  app:\n    external: true\n",
+		"volumes:\n  data:\n    external: true\n",
+	} {
+		if !strings.Contains(result, expected) {
+			t.Errorf("formatComposeFile() =\n%s\nmissing\n%s", result, expected)
+		}
+	}
+	if strings.Contains(result, "command:") || strings.Contains(result, "maintainer") || strings.Contains(result, "com.docker.compose") {
+		t.Errorf("formatComposeFile() kept defaults of the image or compose labels:\n%s", result)
+	}
+}
+
+func TestFormatComposeFileEscapesInterpolation(t *testing.T) {
+	exported := newTestExport()
+	exported.options.Env = []string{"PASSWORD=pa$word", "PRICE=$5"}
+	exported.options.Labels = map[string]string{"traefik.rule": "PathRegexp(`^/api$`)"}
+	exported.options.Command = []string{"sh", "-c", "echo $HOME"}
+	exported.entrypoint = []string{"/entry$point"}
+
+	result, err := formatComposeFile([]exportedContainer{exported})
+	if err != nil {
+		t.Fatalf("formatComposeFile() error = %v", err)
+	}
+
+	for _, expected := range []string{
+		"      - PASSWORD=pa$$word\n",
+		"      - PRICE=$$5\n",
+		"traefik.rule: PathRegexp(`^/api$$`)\n",
+		"      - echo $$HOME\n",
+		"      - /entry$$point\n",
+	} {
+		if !strings.Contains(result, expected) {
+			t.Errorf("formatComposeFile() =\n%s\nmissing\n%s", result, expected)
+		}
+	}
+	if exported.options.Env[0] != "PASSWORD=pa$word" {
+		t.Errorf("formatComposeFile() changed the exported container: %q", exported.options.Env)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"slices"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/givensuman/containertui/internal/ui/logs"
 	"github.com/givensuman/containertui/internal/ui/notifications"
 )
 
@@ -162,31 +163,26 @@ func (containerList *ContainerList) handleRemoveContainers() tea.Cmd {
 	return nil
 }
 
+// handleShowLogs opens the logs of the selected containers merged together,
+// or of the current container when none is selected.
 func (containerList *ContainerList) handleShowLogs() tea.Cmd {
-	item, ok := containerList.list.SelectedItem().(ContainerItem)
-	if !ok || item.isWorking {
-		return nil
+	var sources []logs.Source
+	for _, item := range containerList.list.Items() {
+		if container, ok := item.(ContainerItem); ok && container.isSelected {
+			sources = append(sources, logs.Source{ContainerID: container.ID, Name: container.Name})
+		}
 	}
-
-	if item.State != "running" {
-		return notifications.ShowInfo(item.Name + " is not running")
+	if len(sources) == 0 {
+		item, ok := containerList.list.SelectedItem().(ContainerItem)
+		if !ok || item.isWorking {
+			return nil
+		}
+		sources = []logs.Source{{ContainerID: item.ID, Name: item.Name}}
 	}
 
-	// G204: Subprocess launched with a potential tainted input or cmd arguments.
-	// This is a local CLI tool interacting with Docker. The item.ID is from the Docker API.
-	// We trust the Docker API and the user's intent to view logs.
-	// The shell is fixed to "sh".
-	command := exec.Command("sh", "-c", "docker logs \"$0\" 2>&1 | less", item.ID) //nolint:gosec // Trusted input from Docker API
-	return tea.ExecProcess(command, func(err error) tea.Msg {
-		if err != nil {
-			return notifications.AddNotificationMsg{
-				Message:  err.Error(),
-				Level:    notifications.Error,
-				Duration: 10 * 1000 * 1000 * 1000, // 10s
-			}
-		}
-		return nil
-	})
+	return func() tea.Msg {
+		return MessageOpenLogs{sources: sources}
+	}
 }
 
 func (containerList *ContainerList) handleExecShell() tea.Cmd {
@@ -0,0 +1,153 @@
+package containers
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/docker/docker/api/types"
+	"github.com/givensuman/containertui/internal/client"
+	"github.com/givensuman/containertui/internal/colors"
+	"github.com/givensuman/containertui/internal/context"
+	"github.com/givensuman/containertui/internal/ui/notifications"
+)
+
+// healthPollInterval is how often the health of the containers is refreshed.
+const healthPollInterval = 5 * time.Second
+
+// healthLogLimit is the number of health probe results shown in the details.
+const healthLogLimit = 5
+
+// MsgHealthTick is sent to trigger a health refresh.
+type MsgHealthTick time.Time
+
+func (MsgHealthTick) IsBackground() {}
+
+// MsgContainerHealth contains the health status of every container, by ID.
+type MsgContainerHealth struct {
+	Health     map[string]string
+	Containers []client.Container // The containers the health was read from.
+	Err        error
+}
+
+func (MsgContainerHealth) IsBackground() {}
+
+func healthTickCmd() tea.Cmd {
+	return tea.Tick(healthPollInterval, func(t time.Time) tea.Msg {
+		return MsgHealthTick(t)
+	})
+}
+
+func fetchHealth() tea.Msg {
+	containers, err := context.GetClient().GetContainers()
+	if err != nil {
+		return MsgContainerHealth{Err: err}
+	}
+
+	health := make(map[string]string, len(containers))
+	for _, container := range containers {
+		health[container.ID] = container.Health
+	}
+	return MsgContainerHealth{Health: health, Containers: containers}
+}
+
+// handleContainerHealth updates the health of the items, and notifies of
+// containers which turned unhealthy since the last refresh.
+func (containerList *ContainerList) handleContainerHealth(msg MsgContainerHealth) tea.Cmd {
+	if msg.Err != nil {
+		return nil // Transient, the next refresh will try again.
+	}
+
+	var cmds []tea.Cmd
+	for index, item := range containerList.list.Items() {
+		containerItem, ok := item.(ContainerItem)
+		if !ok {
+			continue
+		}
+		health, ok := msg.Health[containerItem.ID]
+		if !ok || health == containerItem.Health {
+			continue
+		}
+
+		if health == "unhealthy" {
+			cmds = append(cmds, notifications.ShowError(fmt.Errorf("%s is unhealthy", containerItem.Name)))
+		}
+		containerItem.Health = health
+		containerList.list.SetItem(index, containerItem)
+	}
+
+	return tea.Batch(cmds...)
+}
+
+func (containerItem ContainerItem) getHealthIcon() string {
+	switch context.GetConfig().NoNerdFonts {
+	case true: // Don't use nerd fonts.
+		return "(" + containerItem.Health + ")"
+	case false: // Use nerd fonts.
+		switch containerItem.Health {
+		case "healthy":
+			return ""
+		case "starting":
+			return ""
+		case "unhealthy":
+			return ""
+		}
+	}
+
+	return ""
+}
+
+func healthColor(health string) lipgloss.Color {
+	switch health {
+	case "healthy":
+		return colors.Success()
+	case "starting":
+		return colors.Warning()
+	case "unhealthy":
+		return colors.Error()
+	}
+	return colors.Muted()
+}
+
+// formatHealth describes the health check of a container and its last results.
+func formatHealth(health *types.Health, sectionHeader lipgloss.Style) string {
+	if health == nil {
+		return ""
+	}
+
+	var builder strings.Builder
+	mutedStyle := lipgloss.NewStyle().Foreground(colors.Muted())
+
+	builder.WriteString(sectionHeader.Render("Health") + "\n")
+	builder.WriteString(fmt.Sprintf("Status: %s\n", lipgloss.NewStyle().Foreground(healthColor(health.Status)).Render(health.Status)))
+	if health.FailingStreak > 0 {
+		builder.WriteString(fmt.Sprintf("Failing streak: %d\n", health.FailingStreak))
+	}
+
+	logs := health.Log
+	if len(logs) > healthLogLimit {
+		logs = logs[len(logs)-healthLogLimit:]
+	}
+	for index := len(logs) - 1; index >= 0; index-- { // Most recent first.
+		result := logs[index]
+		exitColor := colors.Success()
+		if result.ExitCode != 0 {
+			exitColor = colors.Error()
+		}
+
+		builder.WriteString(fmt.Sprintf("%s %s %s\n",
+			result.Start.Local().Format("15:04:05"),
+			lipgloss.NewStyle().Foreground(exitColor).Render(fmt.Sprintf("exit %d", result.ExitCode)),
+			mutedStyle.Render(fmt.Sprintf("in %s", result.End.Sub(result.Start).Round(time.Millisecond))),
+		))
+		if output := strings.TrimSpace(result.Output); output != "" {
+			for _, line := range strings.Split(output, "\n") {
+				builder.WriteString("  " + mutedStyle.Render(line) + "\n")
+			}
+		}
+	}
+
+	return builder.String()
+}
@@ -18,6 +18,7 @@ type ContainerItem struct {
 	isSelected bool
 	isWorking  bool
 	spinner    spinner.Model
+	alertHits  alertHits
 }
 
 var (
@@ -120,6 +121,12 @@ func (containerItem ContainerItem) Title() string {
 	title = lipgloss.NewStyle().
 		Foreground(titleColor).
 		Render(title)
+	if containerItem.Health != "" {
+		title += lipgloss.NewStyle().
+			Foreground(healthColor(containerItem.Health)).
+			Render(" " + containerItem.getHealthIcon())
+	}
+	title += containerItem.getAlertBadge()
 
 	if !containerItem.isWorking {
 		var isSelectedColor lipgloss.Color
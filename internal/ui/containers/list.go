@@ -21,6 +21,12 @@ type keybindings struct {
 	removeContainer      key.Binding
 	showLogs             key.Binding
 	execShell            key.Binding
+	attachContainer      key.Binding
+	runCommand           key.Binding
+	copyFiles            key.Binding
+	checkpoints          key.Binding
+	recreateContainer    key.Binding
+	exportContainers     key.Binding
 	toggleSelection      key.Binding
 	toggleSelectionOfAll key.Binding
 	switchTab            key.Binding
@@ -50,12 +56,36 @@ func newKeybindings() *keybindings {
 		),
 		showLogs: key.NewBinding(
 			key.WithKeys("L"),
-			key.WithHelp("L", "show container logs"),
+			key.WithHelp("L", "show logs of selection"),
 		),
 		execShell: key.NewBinding(
 			key.WithKeys("x"),
 			key.WithHelp("x", "exec shell"),
 		),
+		attachContainer: key.NewBinding(
+			key.WithKeys("a"),
+			key.WithHelp("a", "attach to container"),
+		),
+		runCommand: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", "run command in selection"),
+		),
+		copyFiles: key.NewBinding(
+			key.WithKeys("F"),
+			key.WithHelp("F", "copy files"),
+		),
+		checkpoints: key.NewBinding(
+			key.WithKeys("C"),
+			key.WithHelp("C", "checkpoints"),
+		),
+		recreateContainer: key.NewBinding(
+			key.WithKeys("R"),
+			key.WithHelp("R", "recreate container"),
+		),
+		exportContainers: key.NewBinding(
+			key.WithKeys("E"),
+			key.WithHelp("E", "export as run/compose"),
+		),
 		toggleSelection: key.NewBinding(
 			key.WithKeys(tea.KeySpace.String()),
 			key.WithHelp("space", "toggle selection"),
@@ -65,8 +95,8 @@ func newKeybindings() *keybindings {
 			key.WithHelp("ctrl+a", "toggle selection of all"),
 		),
 		switchTab: key.NewBinding(
-			key.WithKeys("1", "2", "3", "4", "tab", "shift+tab"),
-			key.WithHelp("1-4/tab", "switch tab"),
+			key.WithKeys("1", "2", "3", "4", "5", "6", "7", "tab", "shift+tab"),
+			key.WithHelp("1-7/tab", "switch tab"),
 		),
 	}
 }
@@ -149,6 +179,12 @@ func newContainerList() ContainerList {
 			containerKeybindings.removeContainer,
 			containerKeybindings.showLogs,
 			containerKeybindings.execShell,
+			containerKeybindings.attachContainer,
+			containerKeybindings.runCommand,
+			containerKeybindings.copyFiles,
+			containerKeybindings.checkpoints,
+			containerKeybindings.recreateContainer,
+			containerKeybindings.exportContainers,
 			containerKeybindings.toggleSelection,
 			containerKeybindings.toggleSelectionOfAll,
 			containerKeybindings.switchTab,
@@ -186,6 +222,9 @@ func (containerList ContainerList) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case MessageConfirmDelete:
 		cmds = append(cmds, containerList.handleConfirmationOfRemoveContainers())
 
+	case MessageContainerRecreated:
+		cmds = append(cmds, containerList.handleContainerRecreated(msg))
+
 	case MessageContainerOperationResult:
 		if cmd := containerList.handleContainerOperationResult(msg); cmd != nil {
 			cmds = append(cmds, cmd)
@@ -221,6 +260,30 @@ func (containerList ContainerList) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if cmd := containerList.handleExecShell(); cmd != nil {
 				cmds = append(cmds, cmd)
 			}
+		case key.Matches(msg, containerList.keybindings.attachContainer):
+			if cmd := containerList.handleAttach(); cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+		case key.Matches(msg, containerList.keybindings.runCommand):
+			if cmd := containerList.handleRunCommand(); cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+		case key.Matches(msg, containerList.keybindings.copyFiles):
+			if cmd := containerList.handleCopyFiles(); cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+		case key.Matches(msg, containerList.keybindings.checkpoints):
+			if cmd := containerList.handleCheckpoints(); cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+		case key.Matches(msg, containerList.keybindings.recreateContainer):
+			if cmd := containerList.handleRecreateContainer(); cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+		case key.Matches(msg, containerList.keybindings.exportContainers):
+			if cmd := containerList.handleExportContainers(); cmd != nil {
+				cmds = append(cmds, cmd)
+			}
 		case key.Matches(msg, containerList.keybindings.toggleSelection):
 			containerList.handleToggleSelection()
 		case key.Matches(msg, containerList.keybindings.toggleSelectionOfAll):
@@ -3,6 +3,7 @@ package containers
 import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/givensuman/containertui/internal/context"
+	"github.com/givensuman/containertui/internal/ui/logs"
 )
 
 // MessageCloseOverlay indicates the overlay should display its background.
@@ -14,6 +15,11 @@ func CloseOverlay() tea.Cmd {
 	}
 }
 
+// MessageOpenLogs indicates the user requested the logs of containers.
+type MessageOpenLogs struct {
+	sources []logs.Source
+}
+
 // MessageOpenDeleteConfirmationDialog indicates the user
 // has requested to delete an item in the ContainerList.
 type MessageOpenDeleteConfirmationDialog struct {
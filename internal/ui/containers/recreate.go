@@ -0,0 +1,158 @@
+package containers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/givensuman/containertui/internal/client"
+	"github.com/givensuman/containertui/internal/context"
+	"github.com/givensuman/containertui/internal/shellwords"
+	"github.com/givensuman/containertui/internal/ui/notifications"
+	"github.com/givensuman/containertui/internal/ui/shared"
+)
+
+// recreateTarget is the container replaced by the recreate form.
+type recreateTarget struct {
+	containerID   string
+	containerName string
+}
+
+// MessageOpenRecreateForm indicates the user requested to recreate a container.
+type MessageOpenRecreateForm struct {
+	container ContainerItem
+}
+
+// MessageContainerRecreated contains the container which replaced the old one.
+type MessageContainerRecreated struct {
+	OldID     string
+	Name      string
+	Container client.Container
+	Err       error
+}
+
+func newRecreateForm(target recreateTarget, options client.RecreateOptions) shared.Form {
+	labels := make([]string, 0, len(options.Labels))
+	for key, value := range options.Labels {
+		labels = append(labels, key+"="+value)
+	}
+	sort.Strings(labels)
+
+	return shared.NewForm(
+		"Recreate "+target.containerName,
+		[]shared.FormField{
+			{Key: "image", Label: "Image", Placeholder: "nginx:latest", Value: options.Image, Hint: "pulled if missing"},
+			{Key: "env", Label: "Environment", Placeholder: "KEY=value", Value: shellwords.Join(options.Env), Hint: "space separated, quote values with spaces"},
+			{Key: "ports", Label: "Ports", Placeholder: "8080:80 127.0.0.1:5432:5432/tcp", Value: strings.Join(options.Ports, " "), Hint: "as in docker run -p"},
+			{Key: "mounts", Label: "Mounts", Placeholder: "data:/var/lib/data /srv/config:/config:ro", Value: shellwords.Join(options.Mounts), Hint: "as in docker run -v"},
+			{Key: "labels", Label: "Labels", Placeholder: "com.example.team=backend", Value: shellwords.Join(labels), Hint: "space separated key=value"},
+			{Key: "command", Label: "Command", Placeholder: "image default when empty", Value: shellwords.Join(options.Command)},
+			{Key: "networks", Label: "Networks", Placeholder: "bridge", Value: strings.Join(options.Networks, " "), Hint: "the first is the network mode"},
+		},
+		shared.SmartDialogAction{Type: "RecreateContainer", Payload: target},
+	)
+}
+
+// parseRecreateForm converts the values of the recreate form.
+func parseRecreateForm(values map[string]string) (client.RecreateOptions, error) {
+	options := client.RecreateOptions{
+		Image:    values["image"],
+		Ports:    strings.Fields(values["ports"]),
+		Networks: strings.Fields(values["networks"]),
+	}
+	if options.Image == "" {
+		return options, fmt.Errorf("image: required")
+	}
+	if len(options.Networks) == 0 {
+		return options, fmt.Errorf("networks: at least one network is required")
+	}
+
+	var err error
+	if options.Env, err = shellwords.Split(values["env"]); err != nil {
+		return options, fmt.Errorf("environment: %w", err)
+	}
+	for _, variable := range options.Env {
+		if strings.HasPrefix(variable, "=") || variable == "" {
+			return options, fmt.Errorf("environment: invalid variable %q, expected KEY=value", variable)
+		}
+	}
+	if options.Mounts, err = shellwords.Split(values["mounts"]); err != nil {
+		return options, fmt.Errorf("mounts: %w", err)
+	}
+	if options.Command, err = shellwords.Split(values["command"]); err != nil {
+		return options, fmt.Errorf("command: %w", err)
+	}
+
+	labels, err := shellwords.Split(values["labels"])
+	if err != nil {
+		return options, fmt.Errorf("labels: %w", err)
+	}
+	options.Labels = make(map[string]string, len(labels))
+	for _, label := range labels {
+		key, value, found := strings.Cut(label, "=")
+		if !found || key == "" {
+			return options, fmt.Errorf("labels: invalid pair %q, expected key=value", label)
+		}
+		options.Labels[key] = value
+	}
+
+	return options, nil
+}
+
+// recreateContainer replaces the container in the background.
+func recreateContainer(target recreateTarget, options client.RecreateOptions) tea.Cmd {
+	return func() tea.Msg {
+		msg := MessageContainerRecreated{OldID: target.containerID, Name: target.containerName}
+
+		newID, err := context.GetClient().RecreateContainer(target.containerID, options)
+		if newID == "" {
+			msg.Err = err
+			return msg
+		}
+
+		containers, listErr := context.GetClient().GetContainers()
+		for _, container := range containers {
+			if container.ID == newID {
+				msg.Container = container
+			}
+		}
+		// The new container may be running even though the old one could not be removed.
+		msg.Err = err
+		if msg.Container.ID == "" && listErr != nil {
+			msg.Err = listErr
+		}
+		return msg
+	}
+}
+
+func (containerList *ContainerList) handleRecreateContainer() tea.Cmd {
+	item, ok := containerList.list.SelectedItem().(ContainerItem)
+	if !ok || item.isWorking {
+		return nil
+	}
+
+	return func() tea.Msg {
+		return MessageOpenRecreateForm{container: item}
+	}
+}
+
+// handleContainerRecreated replaces the item of the old container with the new one.
+func (containerList *ContainerList) handleContainerRecreated(msg MessageContainerRecreated) tea.Cmd {
+	containerList.setWorkingState([]string{msg.OldID}, false)
+
+	if msg.Container.ID != "" {
+		for index, item := range containerList.list.Items() {
+			if containerItem, ok := item.(ContainerItem); ok && containerItem.ID == msg.OldID {
+				containerList.selectedContainers.unselectContainerInList(msg.OldID)
+				containerList.list.SetItem(index, ContainerItem{Container: msg.Container, spinner: newSpinner()})
+				break
+			}
+		}
+	}
+
+	if msg.Err != nil {
+		return notifications.ShowError(msg.Err)
+	}
+	return notifications.ShowSuccess("Recreated " + msg.Name)
+}
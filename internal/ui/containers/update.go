@@ -0,0 +1,177 @@
+package containers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/docker/go-units"
+	"github.com/givensuman/containertui/internal/client"
+	"github.com/givensuman/containertui/internal/context"
+	"github.com/givensuman/containertui/internal/ui/shared"
+)
+
+// updateTarget is the container edited by the update form, with its settings when the form was opened.
+type updateTarget struct {
+	containerID   string
+	containerName string
+	resources     client.ContainerResources
+}
+
+// formatResources converts the settings to the text of the update form's fields.
+func formatResources(resources client.ContainerResources) map[string]string {
+	restartPolicy := resources.RestartPolicy
+	if restartPolicy == "on-failure" && resources.MaximumRetryCount > 0 {
+		restartPolicy = fmt.Sprintf("on-failure:%d", resources.MaximumRetryCount)
+	}
+
+	return map[string]string{
+		"restart":    restartPolicy,
+		"cpuShares":  strconv.FormatInt(resources.CPUShares, 10),
+		"cpuPeriod":  strconv.FormatInt(resources.CPUPeriod, 10),
+		"cpuQuota":   strconv.FormatInt(resources.CPUQuota, 10),
+		"cpuset":     resources.CpusetCpus,
+		"memory":     formatMemory(resources.Memory),
+		"memorySwap": formatMemory(resources.MemorySwap),
+		"pidsLimit":  strconv.FormatInt(resources.PidsLimit, 10),
+	}
+}
+
+func formatMemory(bytes int64) string {
+	if bytes <= 0 {
+		return strconv.FormatInt(bytes, 10)
+	}
+	return units.BytesSize(float64(bytes))
+}
+
+// describeResource explains the special values of a setting, for the details and the form.
+func describeResource(key, value string) string {
+	switch {
+	case value == "":
+		return "any"
+	case value == "0" && key != "restart":
+		return "default"
+	case value == "-1" && (key == "memorySwap" || key == "pidsLimit"):
+		return "unlimited"
+	}
+	return value
+}
+
+func newUpdateForm(target updateTarget) shared.Form {
+	current := formatResources(target.resources)
+	field := func(key, label, placeholder string) shared.FormField {
+		return shared.FormField{
+			Key:         key,
+			Label:       label,
+			Placeholder: placeholder,
+			Value:       current[key],
+			Hint:        "current " + describeResource(key, current[key]),
+		}
+	}
+
+	return shared.NewForm(
+		"Update "+target.containerName,
+		[]shared.FormField{
+			field("restart", "Restart policy", "no, always, unless-stopped or on-failure[:retries]"),
+			field("cpuShares", "CPU shares", "1024"),
+			field("cpuPeriod", "CPU period (µs)", "100000"),
+			field("cpuQuota", "CPU quota (µs per period)", "50000"),
+			field("cpuset", "CPU set", "0-3"),
+			field("memory", "Memory limit", "512MiB"),
+			field("memorySwap", "Memory and swap limit", "1GiB, -1 for unlimited swap"),
+			field("pidsLimit", "PIDs limit", "-1 for unlimited"),
+		},
+		shared.SmartDialogAction{Type: "UpdateContainer", Payload: target},
+	)
+}
+
+// parseUpdateForm applies the fields which were changed to the settings the
+// form was opened with, so that untouched values are sent back as they were.
+func parseUpdateForm(current client.ContainerResources, values map[string]string) (client.ContainerResources, error) {
+	resources := current
+	initial := formatResources(current)
+
+	for _, key := range []string{"restart", "cpuShares", "cpuPeriod", "cpuQuota", "cpuset", "memory", "memorySwap", "pidsLimit"} {
+		value := values[key]
+		if value == initial[key] {
+			continue
+		}
+
+		var err error
+		switch key {
+		case "restart":
+			resources.RestartPolicy, resources.MaximumRetryCount, err = parseRestartPolicy(value)
+		case "cpuShares":
+			resources.CPUShares, err = parseInteger(value)
+		case "cpuPeriod":
+			resources.CPUPeriod, err = parseInteger(value)
+		case "cpuQuota":
+			resources.CPUQuota, err = parseInteger(value)
+		case "cpuset":
+			resources.CpusetCpus = value
+		case "memory":
+			resources.Memory, err = parseMemory(value)
+		case "memorySwap":
+			resources.MemorySwap, err = parseMemory(value)
+		case "pidsLimit":
+			resources.PidsLimit, err = parseInteger(value)
+		}
+		if err != nil {
+			return current, fmt.Errorf("%s: %w", key, err)
+		}
+	}
+
+	return resources, nil
+}
+
+func parseRestartPolicy(value string) (string, int, error) {
+	name, retries, hasRetries := strings.Cut(value, ":")
+	switch name {
+	case "no", "always", "unless-stopped":
+		if hasRetries {
+			return "", 0, fmt.Errorf("only on-failure takes a retry count")
+		}
+		return name, 0, nil
+	case "on-failure":
+		if !hasRetries {
+			return name, 0, nil
+		}
+		count, err := strconv.Atoi(retries)
+		if err != nil || count < 0 {
+			return "", 0, fmt.Errorf("invalid retry count %q", retries)
+		}
+		return name, count, nil
+	}
+	return "", 0, fmt.Errorf("unknown policy %q, expected no, always, unless-stopped or on-failure[:retries]", value)
+}
+
+func parseInteger(value string) (int64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	number, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number %q", value)
+	}
+	return number, nil
+}
+
+func parseMemory(value string) (int64, error) {
+	switch value {
+	case "", "0":
+		return 0, nil
+	case "-1":
+		return -1, nil
+	}
+	return units.RAMInBytes(value)
+}
+
+// updateContainer applies the form to the container, returning the daemon's warnings.
+func updateContainer(target updateTarget, values map[string]string) ([]string, error) {
+	resources, err := parseUpdateForm(target.resources, values)
+	if err != nil {
+		return nil, err
+	}
+
+	return context.GetClient().UpdateContainer(target.containerID, resources)
+}
@@ -0,0 +1,113 @@
+package containers
+
+import (
+	"testing"
+
+	"github.com/givensuman/containertui/internal/client"
+)
+
+func TestParseRestartPolicy(t *testing.T) {
+	tests := []struct {
+		value   string
+		policy  string
+		retries int
+	}{
+		{"no", "no", 0},
+		{"always", "always", 0},
+		{"unless-stopped", "unless-stopped", 0},
+		{"on-failure", "on-failure", 0},
+		{"on-failure:5", "on-failure", 5},
+		{"on-failure:0", "on-failure", 0},
+	}
+	for _, tt := range tests {
+		policy, retries, err := parseRestartPolicy(tt.value)
+		if err != nil || policy != tt.policy || retries != tt.retries {
+			t.Errorf("parseRestartPolicy(%q) = %q, %d, %v; want %q, %d", tt.value, policy, retries, err, tt.policy, tt.retries)
+		}
+	}
+
+	invalid := []string{"", "sometimes", "Always", "on-failure:", "on-failure:-1", "on-failure:many", "always:3", "no:1"}
+	for _, value := range invalid {
+		if _, _, err := parseRestartPolicy(value); err == nil {
+			t.Errorf("parseRestartPolicy(%q) expected error, got nil", value)
+		}
+	}
+}
+
+func TestParseMemory(t *testing.T) {
+	tests := map[string]int64{
+		"":       0,
+		"0":      0,
+		"-1":     -1,
+		"1024":   1024,
+		"512k":   512 << 10,
+		"512MiB": 512 << 20,
+		"1.5GB":  3 << 29,
+		"2g":     2 << 30,
+	}
+	for value, expected := range tests {
+		if bytes, err := parseMemory(value); err != nil || bytes != expected {
+			t.Errorf("parseMemory(%q) = %d, %v; want %d", value, bytes, err, expected)
+		}
+	}
+
+	invalid := []string{"-2", "-512m", "lots", "512XB", "1.5.GB", "12 apples"}
+	for _, value := range invalid {
+		if _, err := parseMemory(value); err == nil {
+			t.Errorf("parseMemory(%q) expected error, got nil", value)
+		}
+	}
+}
+
+func TestParseUpdateForm(t *testing.T) {
+	current := client.ContainerResources{
+		RestartPolicy:     "on-failure",
+		MaximumRetryCount: 3,
+		CPUShares:         1024,
+		Memory:            1536*1024*1024 + 1, // Not shown exactly by the form.
+		MemorySwap:        -1,
+	}
+
+	values := formatResources(current)
+	if resources, err := parseUpdateForm(current, values); err != nil || resources != current {
+		t.Errorf("parseUpdateForm with untouched fields = %+v, %v; want %+v", resources, err, current)
+	}
+
+	values["restart"] = "always"
+	values["memory"] = "2GiB"
+	values["pidsLimit"] = "-1"
+	values["cpuset"] = "0-3"
+	resources, err := parseUpdateForm(current, values)
+	if err != nil {
+		t.Fatalf("parseUpdateForm returned error: %v", err)
+	}
+	expected := current
+	expected.RestartPolicy = "always"
+	expected.MaximumRetryCount = 0
+	expected.Memory = 2 << 30
+	expected.PidsLimit = -1
+	expected.CpusetCpus = "0-3"
+	if resources != expected {
+		t.Errorf("parseUpdateForm = %+v; want %+v", resources, expected)
+	}
+
+	invalid := []map[string]string{
+		{"restart": "on-failure:x"},
+		{"restart": "always:2"},
+		{"memory": "2XB"},
+		{"memorySwap": "-512m"},
+		{"cpuShares": "1.5"},
+		{"pidsLimit": "many"},
+	}
+	for _, changes := range invalid {
+		values := formatResources(current)
+		for key, value := range changes {
+			values[key] = value
+		}
+		if resources, err := parseUpdateForm(current, values); err == nil {
+			t.Errorf("parseUpdateForm(%v) = %+v; expected error", changes, resources)
+		} else if resources != current {
+			t.Errorf("parseUpdateForm(%v) changed the settings to %+v on error", changes, resources)
+		}
+	}
+}
@@ -0,0 +1,278 @@
+// Package daemon defines the overlay describing the Docker daemon the client is connected to.
+package daemon
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/docker/go-units"
+	"github.com/givensuman/containertui/internal/client"
+	"github.com/givensuman/containertui/internal/colors"
+	"github.com/givensuman/containertui/internal/context"
+	"github.com/givensuman/containertui/internal/ui/shared"
+)
+
+// labelWidth aligns the values of the fields.
+const labelWidth = 20
+
+// CloseMsg is sent when the user leaves the panel.
+type CloseMsg struct{}
+
+type infoMsg struct {
+	info client.DaemonInfo
+	err  error
+}
+
+type keybindings struct {
+	scroll  key.Binding
+	refresh key.Binding
+	close   key.Binding
+}
+
+func newKeybindings() keybindings {
+	return keybindings{
+		scroll: key.NewBinding(
+			key.WithKeys("up", "down", "k", "j", "pgup", "pgdown"),
+			key.WithHelp("↑/↓", "scroll"),
+		),
+		refresh: key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", "refresh"),
+		),
+		close: key.NewBinding(
+			key.WithKeys("esc", "q", "I"),
+			key.WithHelp("esc", "close"),
+		),
+	}
+}
+
+// Panel shows the version and configuration of the daemon.
+type Panel struct {
+	shared.Component
+	style       lipgloss.Style
+	viewport    viewport.Model
+	info        client.DaemonInfo
+	err         error
+	isLoading   bool
+	keybindings keybindings
+}
+
+var (
+	_ tea.Model             = (*Panel)(nil)
+	_ shared.ComponentModel = (*Panel)(nil)
+)
+
+// New creates a panel, which loads the information of the daemon once initialized.
+func New() Panel {
+	width, height := context.GetWindowSize()
+
+	style := lipgloss.NewStyle().
+		Padding(1).
+		Border(lipgloss.RoundedBorder(), true, true).
+		BorderForeground(colors.Primary())
+
+	panel := Panel{
+		style:       style,
+		viewport:    viewport.New(0, 0),
+		isLoading:   true,
+		keybindings: newKeybindings(),
+	}
+	panel.UpdateWindowDimensions(tea.WindowSizeMsg{Width: width, Height: height})
+	return panel
+}
+
+func loadInfo() tea.Msg {
+	info, err := context.GetClient().GetDaemonInfo()
+	return infoMsg{info: info, err: err}
+}
+
+func (panel *Panel) UpdateWindowDimensions(msg tea.WindowSizeMsg) {
+	panel.WindowWidth = msg.Width
+	panel.WindowHeight = msg.Height
+
+	layoutManager := shared.NewLayoutManager(msg.Width, msg.Height)
+	dimensions := layoutManager.CalculateLargeOverlay(panel.style)
+
+	panel.style = panel.style.Width(dimensions.Width).Height(dimensions.Height)
+	panel.viewport.Width = shared.Max(dimensions.ContentWidth, 0)
+	panel.viewport.Height = shared.Max(dimensions.ContentHeight-2, 0) // Leave room for the title.
+	panel.refreshContent()
+}
+
+func (panel Panel) Init() tea.Cmd {
+	return loadInfo
+}
+
+func (panel Panel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		panel.UpdateWindowDimensions(msg)
+		return panel, nil
+
+	case infoMsg:
+		panel.info, panel.err, panel.isLoading = msg.info, msg.err, false
+		panel.refreshContent()
+		return panel, nil
+
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, panel.keybindings.close):
+			return panel, func() tea.Msg { return CloseMsg{} }
+		case key.Matches(msg, panel.keybindings.refresh):
+			panel.isLoading = true
+			panel.refreshContent()
+			return panel, loadInfo
+		}
+	}
+
+	var cmd tea.Cmd
+	panel.viewport, cmd = panel.viewport.Update(msg)
+	return panel, cmd
+}
+
+func (panel *Panel) refreshContent() {
+	mutedStyle := lipgloss.NewStyle().Foreground(colors.Muted())
+	switch {
+	case panel.isLoading:
+		panel.viewport.SetContent(mutedStyle.Render("Loading..."))
+	case panel.err != nil:
+		panel.viewport.SetContent(lipgloss.NewStyle().Foreground(colors.Error()).Width(panel.viewport.Width).Render(panel.err.Error()))
+	default:
+		panel.viewport.SetContent(formatInfo(panel.info, panel.viewport.Width))
+	}
+}
+
+// formatInfo renders the information of the daemon in sections.
+func formatInfo(info client.DaemonInfo, width int) string {
+	var builder strings.Builder
+
+	sectionStyle := lipgloss.NewStyle().Bold(true).Foreground(colors.Primary()).Underline(true)
+	section := func(title string) {
+		if builder.Len() > 0 {
+			builder.WriteString("\n")
+		}
+		builder.WriteString(sectionStyle.Render(title) + "\n")
+	}
+
+	section("Engine")
+	writeField(&builder, "Host", info.Name)
+	writeField(&builder, "Version", info.EngineVersion)
+	writeField(&builder, "API version", describeAPIVersion(info))
+	writeField(&builder, "Go version", info.GoVersion)
+	writeField(&builder, "Git commit", info.GitCommit)
+	writeField(&builder, "Operating system", info.OperatingSystem)
+	writeField(&builder, "Platform", strings.Trim(info.OSType+"/"+info.Architecture, "/"))
+	writeField(&builder, "Kernel", info.KernelVersion)
+	writeField(&builder, "Experimental", yesNo(info.Experimental))
+
+	section("Drivers")
+	writeField(&builder, "Storage driver", info.StorageDriver)
+	cgroupDriver := info.CgroupDriver
+	if info.CgroupVersion != "" {
+		cgroupDriver += " (cgroup v" + info.CgroupVersion + ")"
+	}
+	writeField(&builder, "Cgroup driver", cgroupDriver)
+	writeField(&builder, "Logging driver", info.LoggingDriver)
+	runtimes := make([]string, 0, len(info.Runtimes))
+	for _, runtime := range info.Runtimes {
+		if runtime == info.DefaultRuntime {
+			runtime += " (default)"
+		}
+		runtimes = append(runtimes, runtime)
+	}
+	writeList(&builder, "Runtimes", runtimes)
+
+	section("Security")
+	writeField(&builder, "Rootless", yesNo(info.Rootless))
+	writeList(&builder, "Security options", info.SecurityOptions)
+
+	section("Resources")
+	writeField(&builder, "CPUs", fmt.Sprint(info.CPUs))
+	writeField(&builder, "Memory", units.BytesSize(float64(info.MemoryTotal)))
+
+	section("Registries")
+	writeList(&builder, "Mirrors", info.RegistryMirrors)
+	writeList(&builder, "Insecure registries", info.InsecureRegistries)
+
+	section("Warnings")
+	if len(info.Warnings) == 0 {
+		builder.WriteString(lipgloss.NewStyle().Foreground(colors.Muted()).Render("None.") + "\n")
+	}
+	warningStyle := lipgloss.NewStyle().Foreground(colors.Warning()).Width(width)
+	for _, warning := range info.Warnings {
+		builder.WriteString(warningStyle.Render(warning) + "\n")
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n")
+}
+
+// describeAPIVersion renders the API version of the daemon with the range it
+// accepts and the version the client negotiated.
+func describeAPIVersion(info client.DaemonInfo) string {
+	var details []string
+	if info.MinAPIVersion != "" {
+		details = append(details, "minimum "+info.MinAPIVersion)
+	}
+	if info.ClientAPIVersion != "" {
+		details = append(details, "client "+info.ClientAPIVersion)
+	}
+	if len(details) == 0 {
+		return info.APIVersion
+	}
+	return info.APIVersion + " (" + strings.Join(details, ", ") + ")"
+}
+
+func writeField(builder *strings.Builder, label, value string) {
+	labelStyle := lipgloss.NewStyle().Foreground(colors.Muted()).Width(labelWidth)
+	if value == "" {
+		value = lipgloss.NewStyle().Foreground(colors.Muted()).Render("unknown")
+	}
+	builder.WriteString(labelStyle.Render(label) + value + "\n")
+}
+
+// writeList writes a field with a value per line.
+func writeList(builder *strings.Builder, label string, values []string) {
+	if len(values) == 0 {
+		writeField(builder, label, lipgloss.NewStyle().Foreground(colors.Muted()).Render("none"))
+		return
+	}
+	for index, value := range values {
+		if index > 0 {
+			label = ""
+		}
+		writeField(builder, label, value)
+	}
+}
+
+func yesNo(value bool) string {
+	if value {
+		return "yes"
+	}
+	return "no"
+}
+
+func (panel Panel) View() string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(colors.Primary())
+
+	return panel.style.Render(lipgloss.JoinVertical(lipgloss.Left,
+		titleStyle.Render("Docker daemon"),
+		"",
+		panel.viewport.View(),
+	))
+}
+
+func (panel Panel) ShortHelp() []key.Binding {
+	return []key.Binding{
+		panel.keybindings.scroll,
+		panel.keybindings.refresh,
+		panel.keybindings.close,
+	}
+}
+
+func (panel Panel) FullHelp() [][]key.Binding {
+	return [][]key.Binding{panel.ShortHelp()}
+}
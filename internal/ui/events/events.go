@@ -0,0 +1,542 @@
+// Package events defines the events component, a timeline of the events of the daemon.
+package events
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/givensuman/containertui/internal/client"
+	"github.com/givensuman/containertui/internal/colors"
+	contxt "github.com/givensuman/containertui/internal/context"
+	"github.com/givensuman/containertui/internal/ui/shared"
+	overlay "github.com/rmhubbert/bubbletea-overlay"
+)
+
+// maxEvents bounds the events kept in the timeline, the oldest are dropped first.
+const maxEvents = 1000
+
+type detailsKeybindings struct {
+	Up     key.Binding
+	Down   key.Binding
+	Switch key.Binding
+}
+
+func newDetailsKeybindings() detailsKeybindings {
+	return detailsKeybindings{
+		Up: key.NewBinding(
+			key.WithKeys("up", "k"),
+			key.WithHelp("↑/k", "up"),
+		),
+		Down: key.NewBinding(
+			key.WithKeys("down", "j"),
+			key.WithHelp("↓/j", "down"),
+		),
+		Switch: key.NewBinding(
+			key.WithKeys("tab"),
+			key.WithHelp("tab", "switch focus"),
+		),
+	}
+}
+
+type keybindings struct {
+	filter    key.Binding
+	replay    key.Binding
+	clear     key.Binding
+	switchTab key.Binding
+}
+
+func newKeybindings() *keybindings {
+	return &keybindings{
+		filter: key.NewBinding(
+			key.WithKeys("f"),
+			key.WithHelp("f", "filter"),
+		),
+		replay: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "replay since"),
+		),
+		clear: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", "clear"),
+		),
+		switchTab: key.NewBinding(
+			key.WithKeys("1", "2", "3", "4", "5", "6", "7", "tab", "shift+tab"),
+			key.WithHelp("1-7/tab", "switch tab"),
+		),
+	}
+}
+
+// stream carries the events of the daemon, until cancelled.
+type stream struct {
+	events chan client.Event
+	cancel context.CancelFunc
+	err    error // Why the stream ended, set before events is closed.
+}
+
+// eventMsg is an event of a stream, or the end of it when closed.
+type eventMsg struct {
+	stream *stream
+	event  client.Event
+	closed bool
+}
+
+func (eventMsg) IsBackground() {}
+
+type sessionState int
+
+const (
+	viewMain sessionState = iota
+	viewOverlay
+)
+
+const (
+	focusList = iota
+	focusDetails
+)
+
+// Model represents the events component state.
+type Model struct {
+	shared.Component
+	style       lipgloss.Style
+	list        list.Model
+	viewport    viewport.Model
+	keybindings *keybindings
+
+	sessionState       sessionState
+	focusedView        int
+	detailsKeybindings detailsKeybindings
+	foreground         tea.Model
+	overlayModel       *overlay.Model
+
+	events    []client.Event // Oldest first, including those hidden by the filter.
+	filter    filter
+	options   client.EventOptions
+	stream    *stream
+	streamErr error // Why the stream ended, nil while it is live.
+}
+
+var (
+	_ tea.Model             = (*Model)(nil)
+	_ shared.ComponentModel = (*Model)(nil)
+)
+
+func New() Model {
+	width, height := contxt.GetWindowSize()
+	style := lipgloss.NewStyle().
+		Width(width).
+		Height(height).
+		PaddingTop(1)
+
+	delegate := newDefaultDelegate()
+	listModel := list.New([]list.Item{}, delegate, width, height)
+	listModel.SetShowHelp(false)
+	listModel.SetShowTitle(false)
+	listModel.SetShowStatusBar(false)
+	listModel.SetFilteringEnabled(true)
+	listModel.Styles.FilterPrompt = lipgloss.NewStyle().Foreground(colors.Primary())
+	listModel.Styles.FilterCursor = lipgloss.NewStyle().Foreground(colors.Primary())
+	listModel.FilterInput.PromptStyle = lipgloss.NewStyle().Foreground(colors.Primary())
+	listModel.FilterInput.Cursor.Style = lipgloss.NewStyle().Foreground(colors.Primary())
+
+	eventKeybindings := newKeybindings()
+	listModel.AdditionalFullHelpKeys = func() []key.Binding {
+		return []key.Binding{
+			eventKeybindings.filter,
+			eventKeybindings.replay,
+			eventKeybindings.clear,
+			eventKeybindings.switchTab,
+		}
+	}
+
+	model := Model{
+		style:              style,
+		list:               listModel,
+		viewport:           viewport.New(0, 0),
+		keybindings:        eventKeybindings,
+		sessionState:       viewMain,
+		focusedView:        focusList,
+		detailsKeybindings: newDetailsKeybindings(),
+	}
+	model.start()
+
+	model.overlayModel = overlay.New(nil, model.list, overlay.Center, overlay.Center, 0, 0)
+	return model
+}
+
+// start streams the events with the current options, replacing the previous
+// stream and the events it received.
+func (model *Model) start() {
+	model.Stop()
+	model.events = nil
+	model.list.SetItems(nil)
+	model.refreshDetails()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	eventStream := &stream{events: make(chan client.Event), cancel: cancel}
+	model.stream = eventStream
+	model.streamErr = nil
+
+	go func(options client.EventOptions) {
+		err := contxt.GetClient().StreamEvents(ctx, options, eventStream.events)
+		if ctx.Err() == nil {
+			eventStream.err = err
+		}
+		close(eventStream.events)
+	}(model.options)
+}
+
+// waitForEvent receives the next event of the stream.
+func (stream *stream) waitForEvent() tea.Msg {
+	event, ok := <-stream.events
+	return eventMsg{stream: stream, event: event, closed: !ok}
+}
+
+// Stop cancels the stream of the events.
+func (model Model) Stop() {
+	if model.stream != nil {
+		model.stream.cancel()
+	}
+}
+
+func (model Model) Init() tea.Cmd {
+	return model.stream.waitForEvent
+}
+
+func newFilterForm(eventFilter filter) shared.Form {
+	return shared.NewForm("Filter Events", []shared.FormField{
+		{Key: "type", Label: "Type", Placeholder: strings.Join(client.EventTypes, " "), Value: strings.Join(eventFilter.types, " "), Hint: "any when empty"},
+		{Key: "action", Label: "Action", Placeholder: "die oom restart health_status", Value: strings.Join(eventFilter.actions, " "), Hint: "any when empty"},
+		{Key: "name", Label: "Name", Placeholder: "web db-*", Value: strings.Join(eventFilter.names, " "), Hint: "any when empty, * matches any text"},
+	}, shared.SmartDialogAction{Type: "FilterEvents"})
+}
+
+func newReplayForm(options client.EventOptions) shared.Form {
+	return shared.NewForm("Replay Events", []shared.FormField{
+		{Key: "since", Label: "Since", Placeholder: "1h or 2024-01-01T10:00:00", Value: options.Since, Hint: "only new events when empty"},
+	}, shared.SmartDialogAction{Type: "ReplayEvents"})
+}
+
+func (model Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmds []tea.Cmd
+
+	if msg, ok := msg.(eventMsg); ok {
+		if msg.stream != model.stream {
+			return model, nil
+		}
+		if msg.closed {
+			model.streamErr = msg.stream.err
+			return model, nil
+		}
+		model.addEvent(msg.event)
+		return model, model.stream.waitForEvent
+	}
+
+	switch model.sessionState {
+	case viewOverlay:
+		foregroundModel, foregroundCmd := model.foreground.Update(msg)
+		model.foreground = foregroundModel
+		cmds = append(cmds, foregroundCmd)
+
+		if _, ok := msg.(shared.CloseDialogMessage); ok {
+			model.sessionState = viewMain
+			model.foreground = nil
+		} else if submitMsg, ok := msg.(shared.FormSubmitMessage); ok {
+			switch submitMsg.Action.Type {
+			case "FilterEvents":
+				eventFilter, err := parseFilterForm(submitMsg.Values)
+				if err != nil {
+					model.foreground = shared.SetFormError(model.foreground, err)
+					break
+				}
+				model.filter = eventFilter
+				model.refreshList()
+				model.sessionState = viewMain
+				model.foreground = nil
+			case "ReplayEvents":
+				options := client.EventOptions{Since: strings.TrimSpace(submitMsg.Values["since"])}
+				if err := options.Validate(); err != nil {
+					model.foreground = shared.SetFormError(model.foreground, err)
+					break
+				}
+				model.options = options
+				model.start()
+				cmds = append(cmds, model.stream.waitForEvent)
+				model.sessionState = viewMain
+				model.foreground = nil
+			}
+		}
+	case viewMain:
+		if keyMsg, ok := msg.(tea.KeyMsg); ok {
+			if keyMsg.String() == "tab" && model.list.FilterState() != list.Filtering {
+				if model.focusedView == focusList {
+					model.focusedView = focusDetails
+				} else {
+					model.focusedView = focusList
+				}
+				return model, nil
+			}
+		}
+
+		isKeyMessage := false
+		if _, ok := msg.(tea.KeyMsg); ok {
+			isKeyMessage = true
+		}
+
+		if !isKeyMessage || model.focusedView == focusList {
+			switch msg := msg.(type) {
+			case tea.WindowSizeMsg:
+				model.UpdateWindowDimensions(msg)
+			case tea.KeyMsg:
+				if model.list.FilterState() == list.Filtering {
+					break
+				}
+
+				switch {
+				case key.Matches(msg, model.keybindings.switchTab):
+					return model, nil
+				case key.Matches(msg, model.keybindings.filter):
+					model.foreground = newFilterForm(model.filter)
+					model.sessionState = viewOverlay
+					return model, model.foreground.Init()
+				case key.Matches(msg, model.keybindings.replay):
+					model.foreground = newReplayForm(model.options)
+					model.sessionState = viewOverlay
+					return model, model.foreground.Init()
+				case key.Matches(msg, model.keybindings.clear):
+					model.events = nil
+					model.refreshList()
+					return model, nil
+				}
+			}
+			updatedList, listCmd := model.list.Update(msg)
+			model.list = updatedList
+			cmds = append(cmds, listCmd)
+		}
+
+		model.refreshDetails()
+
+		if !isKeyMessage || model.focusedView == focusDetails {
+			updatedViewport, viewportCmd := model.viewport.Update(msg)
+			model.viewport = updatedViewport
+			cmds = append(cmds, viewportCmd)
+		}
+	}
+
+	model.overlayModel.Foreground = model.foreground
+	model.overlayModel.Background = model.list
+
+	return model, tea.Batch(cmds...)
+}
+
+// addEvent records an event, adding it to the top of the timeline when it
+// matches the filter. The selected event stays selected, unless it is the newest.
+func (model *Model) addEvent(event client.Event) {
+	model.events = append(model.events, event)
+	if len(model.events) > maxEvents {
+		model.events = model.events[len(model.events)-maxEvents:]
+	}
+	if !model.filter.matches(event) {
+		return
+	}
+
+	index := model.list.Index()
+	model.list.InsertItem(0, EventItem{Event: event})
+	if items := model.list.Items(); len(items) > maxEvents {
+		model.list.RemoveItem(len(items) - 1)
+	}
+	if index > 0 && model.list.FilterState() == list.Unfiltered {
+		model.list.Select(index + 1)
+	}
+	model.refreshDetails()
+}
+
+// refreshList shows the events matching the filter, newest first.
+func (model *Model) refreshList() {
+	var items []list.Item
+	for index := len(model.events) - 1; index >= 0; index-- {
+		if model.filter.matches(model.events[index]) {
+			items = append(items, EventItem{Event: model.events[index]})
+		}
+	}
+	model.list.ResetFilter()
+	model.list.SetItems(items)
+	model.list.Select(0)
+	model.refreshDetails()
+}
+
+// refreshDetails shows the selected event.
+func (model *Model) refreshDetails() {
+	eventItem, ok := model.list.SelectedItem().(EventItem)
+	if !ok {
+		model.viewport.SetContent(lipgloss.NewStyle().Foreground(colors.Muted()).Render("No event selected."))
+		return
+	}
+	model.viewport.SetContent(formatEvent(eventItem.Event, model.viewport.Width))
+}
+
+func formatEvent(event client.Event, width int) string {
+	var builder strings.Builder
+
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(colors.Primary()).MarginBottom(1)
+	builder.WriteString(headerStyle.Render(event.Type+" "+event.Action) + "\n")
+
+	builder.WriteString(fmt.Sprintf("Time: %s\n", event.Time.Local().Format("2006-01-02 15:04:05.000 MST")))
+	builder.WriteString(fmt.Sprintf("Name: %s\n", event.Name))
+	builder.WriteString(lipgloss.NewStyle().Width(shared.Max(width, 0)).Render("ID: "+event.ActorID) + "\n")
+
+	sectionHeader := lipgloss.NewStyle().Bold(true).Foreground(colors.Primary()).Underline(true).MarginTop(1).MarginBottom(0)
+	builder.WriteString("\n" + sectionHeader.Render("Attributes") + "\n")
+
+	if len(event.Attributes) == 0 {
+		builder.WriteString(lipgloss.NewStyle().Foreground(colors.Muted()).Render("None."))
+		return builder.String()
+	}
+
+	keys := make([]string, 0, len(event.Attributes))
+	for key := range event.Attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	keyStyle := lipgloss.NewStyle().Foreground(colors.Muted())
+	valueStyle := lipgloss.NewStyle().Width(shared.Max(width, 0))
+	for _, key := range keys {
+		builder.WriteString(valueStyle.Render(keyStyle.Render(key+"=")+event.Attributes[key]) + "\n")
+	}
+	return strings.TrimSuffix(builder.String(), "\n")
+}
+
+// status describes the stream and the filter, on two lines above the timeline.
+func (model Model) status() string {
+	mutedStyle := lipgloss.NewStyle().Foreground(colors.Muted())
+
+	var parts []string
+	switch {
+	case model.streamErr != nil:
+		parts = append(parts, lipgloss.NewStyle().Foreground(colors.Error()).Render("disconnected: "+model.streamErr.Error()))
+	case model.options.Since != "":
+		parts = append(parts, lipgloss.NewStyle().Foreground(colors.Success()).Render("● live")+mutedStyle.Render(", since "+model.options.Since))
+	default:
+		parts = append(parts, lipgloss.NewStyle().Foreground(colors.Success()).Render("● live"))
+	}
+	parts = append(parts, mutedStyle.Render(fmt.Sprintf("%d/%d events", len(model.list.Items()), len(model.events))))
+	status := strings.Join(parts, mutedStyle.Render(" • "))
+
+	if description := model.filter.String(); description != "" {
+		return status + "\n" + mutedStyle.Render("filter: "+description)
+	}
+	return status + "\n" + mutedStyle.Render("no filter, press f to filter")
+}
+
+func (model Model) View() string {
+	if model.sessionState == viewOverlay && model.foreground != nil {
+		return model.overlayModel.View()
+	}
+
+	layoutManager := shared.NewLayoutManager(model.WindowWidth, model.WindowHeight)
+	_, detailLayout := layoutManager.CalculateMasterDetail(lipgloss.NewStyle())
+
+	timeline := model.list.View()
+	if len(model.list.Items()) == 0 {
+		timeline = lipgloss.NewStyle().Foreground(colors.Muted()).Padding(1, 2).
+			Render("No events yet.\n\nPress s to replay the events since a time.")
+	}
+	statusView := lipgloss.NewStyle().MaxWidth(shared.Max(model.list.Width(), 0)).Padding(0, 2).Render(model.status())
+	listView := model.style.Render(lipgloss.JoinVertical(lipgloss.Left, statusView, "", timeline))
+
+	borderColor := colors.Muted()
+	if model.focusedView == focusDetails {
+		borderColor = colors.Primary()
+	}
+
+	detailStyle := lipgloss.NewStyle().
+		Width(detailLayout.Width - 2).
+		Height(detailLayout.Height).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(borderColor).
+		Padding(1)
+
+	detailView := detailStyle.Render(model.viewport.View())
+
+	return lipgloss.JoinHorizontal(lipgloss.Top, listView, detailView)
+}
+
+// statusHeight is the height of the status above the timeline.
+const statusHeight = 3
+
+func (model *Model) UpdateWindowDimensions(msg tea.WindowSizeMsg) {
+	model.WindowWidth = msg.Width
+	model.WindowHeight = msg.Height
+
+	layoutManager := shared.NewLayoutManager(msg.Width, msg.Height)
+	masterLayout, detailLayout := layoutManager.CalculateMasterDetail(model.style)
+
+	model.style = model.style.Width(masterLayout.Width).Height(masterLayout.Height)
+
+	model.viewport.Width = shared.Max(detailLayout.Width-4, 0)
+	model.viewport.Height = shared.Max(detailLayout.Height-2, 0)
+
+	listHeight := shared.Max(masterLayout.ContentHeight-statusHeight, 0)
+	if model.list.Width() != masterLayout.ContentWidth || model.list.Height() != listHeight {
+		model.list.SetWidth(masterLayout.ContentWidth)
+		model.list.SetHeight(listHeight)
+	}
+	model.refreshDetails()
+
+	if model.sessionState == viewOverlay {
+		if form, ok := model.foreground.(shared.Form); ok {
+			form.UpdateWindowDimensions(msg)
+			model.foreground = form
+		}
+	}
+}
+
+// IsCapturingInput reports whether keys are being typed into a filter or a form.
+func (model Model) IsCapturingInput() bool {
+	if model.sessionState == viewOverlay {
+		return true // Only forms are shown over the timeline.
+	}
+	return model.list.FilterState() == list.Filtering
+}
+
+func (model Model) ShortHelp() []key.Binding {
+	switch model.focusedView {
+	case focusList:
+		return []key.Binding{
+			model.keybindings.filter,
+			model.keybindings.replay,
+			model.keybindings.clear,
+			model.keybindings.switchTab,
+		}
+	case focusDetails:
+		return []key.Binding{
+			model.detailsKeybindings.Up,
+			model.detailsKeybindings.Down,
+			model.detailsKeybindings.Switch,
+		}
+	}
+	return nil
+}
+
+func (model Model) FullHelp() [][]key.Binding {
+	switch model.focusedView {
+	case focusList:
+		return model.list.FullHelp()
+	case focusDetails:
+		return [][]key.Binding{
+			{
+				model.detailsKeybindings.Up,
+				model.detailsKeybindings.Down,
+				model.detailsKeybindings.Switch,
+			},
+		}
+	}
+	return nil
+}
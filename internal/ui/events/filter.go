@@ -0,0 +1,75 @@
+package events
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/givensuman/containertui/internal/client"
+)
+
+// filter selects the events shown in the timeline. An event matches when it
+// matches one of the terms of each non-empty field.
+type filter struct {
+	types   []string
+	actions []string // Prefixes of actions, so that "health_status" matches "health_status: unhealthy".
+	names   []string // Patterns as in path.Match, e.g. "web-*".
+}
+
+// parseFilterForm converts the values of the filter form, whose terms are separated by spaces or commas.
+func parseFilterForm(values map[string]string) (filter, error) {
+	eventFilter := filter{
+		types:   splitTerms(values["type"]),
+		actions: splitTerms(values["action"]),
+		names:   splitTerms(values["name"]),
+	}
+
+	for _, eventType := range eventFilter.types {
+		if !slices.Contains(client.EventTypes, eventType) {
+			return filter{}, fmt.Errorf("type: unknown type %q, expected one of %s", eventType, strings.Join(client.EventTypes, ", "))
+		}
+	}
+	for _, name := range eventFilter.names {
+		if _, err := path.Match(name, ""); err != nil {
+			return filter{}, fmt.Errorf("name: invalid pattern %q", name)
+		}
+	}
+	return eventFilter, nil
+}
+
+func splitTerms(value string) []string {
+	return strings.FieldsFunc(value, func(r rune) bool { return r == ' ' || r == ',' })
+}
+
+func (eventFilter filter) matches(event client.Event) bool {
+	if len(eventFilter.types) > 0 && !slices.Contains(eventFilter.types, event.Type) {
+		return false
+	}
+	if len(eventFilter.actions) > 0 && !slices.ContainsFunc(eventFilter.actions, func(action string) bool {
+		return event.Action == action || strings.HasPrefix(event.Action, action+":")
+	}) {
+		return false
+	}
+	if len(eventFilter.names) > 0 && !slices.ContainsFunc(eventFilter.names, func(name string) bool {
+		matched, _ := path.Match(name, event.Name)
+		return matched
+	}) {
+		return false
+	}
+	return true
+}
+
+// String describes the filter, empty when every event matches.
+func (eventFilter filter) String() string {
+	var terms []string
+	for _, field := range []struct {
+		name   string
+		values []string
+	}{{"type", eventFilter.types}, {"action", eventFilter.actions}, {"name", eventFilter.names}} {
+		if len(field.values) > 0 {
+			terms = append(terms, field.name+"="+strings.Join(field.values, ","))
+		}
+	}
+	return strings.Join(terms, " ")
+}
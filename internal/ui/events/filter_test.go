@@ -0,0 +1,56 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/givensuman/containertui/internal/client"
+)
+
+func TestParseFilterForm(t *testing.T) {
+	eventFilter, err := parseFilterForm(map[string]string{"type": "container, image", "action": "die oom", "name": "web-*"})
+	if err != nil {
+		t.Fatalf("parseFilterForm returned error: %v", err)
+	}
+	if got := eventFilter.String(); got != "type=container,image action=die,oom name=web-*" {
+		t.Errorf("unexpected filter %q", got)
+	}
+
+	if eventFilter, err := parseFilterForm(map[string]string{}); err != nil || eventFilter.String() != "" {
+		t.Errorf("expected an empty filter, got %q, %v", eventFilter.String(), err)
+	}
+
+	invalid := []map[string]string{
+		{"type": "service"},
+		{"name": "web-["},
+	}
+	for _, values := range invalid {
+		if _, err := parseFilterForm(values); err == nil {
+			t.Errorf("parseFilterForm(%v) expected error, got nil", values)
+		}
+	}
+}
+
+func TestFilterMatches(t *testing.T) {
+	eventFilter := filter{types: []string{"container"}, actions: []string{"die", "health_status"}, names: []string{"web-*"}}
+
+	tests := []struct {
+		event client.Event
+		want  bool
+	}{
+		{client.Event{Type: "container", Action: "die", Name: "web-1"}, true},
+		{client.Event{Type: "container", Action: "health_status: unhealthy", Name: "web-2"}, true},
+		{client.Event{Type: "container", Action: "health_statuses", Name: "web-2"}, false},
+		{client.Event{Type: "container", Action: "start", Name: "web-1"}, false},
+		{client.Event{Type: "container", Action: "die", Name: "db"}, false},
+		{client.Event{Type: "image", Action: "die", Name: "web-1"}, false},
+	}
+	for _, test := range tests {
+		if got := eventFilter.matches(test.event); got != test.want {
+			t.Errorf("matches(%+v) = %v, want %v", test.event, got, test.want)
+		}
+	}
+
+	if !(filter{}).matches(client.Event{Type: "volume", Action: "mount"}) {
+		t.Error("expected an empty filter to match every event")
+	}
+}
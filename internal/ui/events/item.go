@@ -0,0 +1,68 @@
+package events
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/givensuman/containertui/internal/client"
+	"github.com/givensuman/containertui/internal/colors"
+	"github.com/givensuman/containertui/internal/ui/shared"
+)
+
+// timeLayout shows the time of the events in the timeline, the day is in the description.
+const timeLayout = "15:04:05"
+
+// EventItem is an event of the timeline.
+type EventItem struct {
+	Event client.Event
+}
+
+var (
+	_ list.Item        = (*EventItem)(nil)
+	_ list.DefaultItem = (*EventItem)(nil)
+)
+
+func newDefaultDelegate() list.DefaultDelegate {
+	delegate := list.NewDefaultDelegate()
+	delegate = shared.ChangeDelegateStyles(delegate)
+
+	return delegate
+}
+
+// actionColor highlights the events worth investigating, such as crashes and OOM kills.
+func actionColor(action string) lipgloss.Color {
+	action, _, _ = strings.Cut(action, ":")
+	switch action {
+	case "oom", "die", "kill":
+		return colors.Error()
+	case "restart", "stop", "pause", "health_status":
+		return colors.Warning()
+	case "start", "create", "unpause", "pull", "connect", "mount":
+		return colors.Success()
+	}
+	return colors.Muted()
+}
+
+func (eventItem EventItem) FilterValue() string {
+	return eventItem.Event.Type + " " + eventItem.Event.Action + " " + eventItem.Event.Name
+}
+
+func (eventItem EventItem) Title() string {
+	event := eventItem.Event
+	action := lipgloss.NewStyle().Foreground(actionColor(event.Action)).Render(event.Action)
+	return fmt.Sprintf("%s %s %s", event.Time.Local().Format(timeLayout), action, event.Name)
+}
+
+func (eventItem EventItem) Description() string {
+	event := eventItem.Event
+	description := event.Time.Local().Format("2006-01-02") + " • " + event.Type
+	if image := event.Attributes["image"]; image != "" && event.Type == "container" {
+		description += " • " + image
+	}
+	if exitCode := event.Attributes["exitCode"]; exitCode != "" {
+		description += " • exit code " + exitCode
+	}
+	return description
+}
@@ -61,8 +61,8 @@ func newKeybindings() *keybindings {
 			key.WithHelp("r", "remove"),
 		),
 		switchTab: key.NewBinding(
-			key.WithKeys("1", "2", "3", "4", "tab", "shift+tab"),
-			key.WithHelp("1-4/tab", "switch tab"),
+			key.WithKeys("1", "2", "3", "4", "5", "6", "7", "tab", "shift+tab"),
+			key.WithHelp("1-7/tab", "switch tab"),
 		),
 	}
 }
@@ -0,0 +1,353 @@
+package logs
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/givensuman/containertui/internal/client"
+	contxt "github.com/givensuman/containertui/internal/context"
+	"github.com/givensuman/containertui/internal/ui/notifications"
+	"github.com/givensuman/containertui/internal/ui/shared"
+)
+
+// saveOptions select the lines written to a file and how.
+type saveOptions struct {
+	path       string
+	isFull     bool // Reads all the lines of the time range again, rather than the buffer.
+	isFiltered bool // Leaves out hidden sources and lines not matching the filter.
+	isSplit    bool // Writes stdout and stderr to separate files.
+}
+
+func newSaveForm() shared.Form {
+	return shared.NewForm("Save Logs", []shared.FormField{
+		{Key: "path", Label: "File", Placeholder: "logs.txt", Hint: "must not exist"},
+		{Key: "content", Label: "Content", Value: "buffer", Hint: "buffer or full"},
+		{Key: "filtered", Label: "Filtered", Value: "yes", Hint: "yes or no"},
+		{Key: "split", Label: "Split stdout and stderr", Value: "no", Hint: "yes or no"},
+	}, shared.SmartDialogAction{Type: "SaveLogs"})
+}
+
+func parseSaveForm(values map[string]string) (saveOptions, error) {
+	options := saveOptions{path: strings.TrimSpace(values["path"])}
+	if options.path == "" {
+		return saveOptions{}, errors.New("a file is required")
+	}
+
+	switch content := strings.TrimSpace(values["content"]); content {
+	case "buffer":
+	case "full":
+		options.isFull = true
+	default:
+		return saveOptions{}, fmt.Errorf("unknown content %q, expected buffer or full", content)
+	}
+
+	var err error
+	if options.isFiltered, err = parseYesNo("filtered", values["filtered"]); err != nil {
+		return saveOptions{}, err
+	}
+	if options.isSplit, err = parseYesNo("split", values["split"]); err != nil {
+		return saveOptions{}, err
+	}
+	return options, nil
+}
+
+func parseYesNo(name, value string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "yes", "y":
+		return true, nil
+	case "no", "n", "":
+		return false, nil
+	}
+	return false, fmt.Errorf("invalid %s %q, expected yes or no", name, value)
+}
+
+// keeps returns whether a line is written, leaving out hidden sources and
+// lines not matching the filter when isFiltered.
+func (viewer Viewer) keeps(isFiltered bool) func(entry) bool {
+	hidden := make(map[string]bool, len(viewer.sources))
+	for _, logSource := range viewer.sources {
+		hidden[logSource.ContainerID] = logSource.isHidden
+	}
+	filter := viewer.filter
+
+	return func(line entry) bool {
+		return !isFiltered || (!hidden[line.ContainerID] && filter.matches(line))
+	}
+}
+
+// selectLines copies the lines kept by keeps.
+func (viewer Viewer) selectLines(lines []entry, isFiltered bool) []entry {
+	keep := viewer.keeps(isFiltered)
+	kept := make([]entry, 0, len(lines))
+	for _, line := range lines {
+		if keep(line) {
+			kept = append(kept, line)
+		}
+	}
+	return kept
+}
+
+// formatter renders lines for a file, without styling but prefixed like in the viewer.
+func (viewer Viewer) formatter() func(entry) string {
+	prefixWidth := 0
+	names := make(map[string]string, len(viewer.sources))
+	for _, logSource := range viewer.sources {
+		prefixWidth = shared.Max(prefixWidth, len(logSource.Name))
+		names[logSource.ContainerID] = logSource.Name
+	}
+	timestamps := viewer.timestamps
+	isPrefixed := len(viewer.sources) > 1
+
+	return func(line entry) string {
+		text := line.Text
+		switch timestamps {
+		case "local":
+			text = line.Timestamp.Local().Format(timestampLayout) + " " + text
+		case "utc":
+			text = line.Timestamp.UTC().Format(timestampLayout) + " " + text
+		}
+		if isPrefixed {
+			text = fmt.Sprintf("%-*s | %s", prefixWidth, names[line.ContainerID], text)
+		}
+		return text
+	}
+}
+
+// save writes the logs to files in the background, notifying the result.
+func (viewer Viewer) save(options saveOptions) tea.Cmd {
+	var buffer []entry
+	if !options.isFull {
+		buffer = viewer.selectLines(viewer.lines, options.isFiltered)
+	}
+	format := viewer.formatter()
+	keep := viewer.keeps(options.isFiltered)
+
+	return func() tea.Msg {
+		produce := func(write func(entry) error) error {
+			for _, line := range buffer {
+				if err := write(line); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		if options.isFull {
+			produce = func(write func(entry) error) error {
+				return viewer.streamAll(func(line entry) error {
+					if !keep(line) {
+						return nil
+					}
+					return write(line)
+				})
+			}
+		}
+
+		paths, count, err := writeLogs(options, format, produce)
+		if err != nil {
+			return notifications.ShowError(err)()
+		}
+		return notifications.ShowSuccess(fmt.Sprintf("Saved %d lines to %s", count, strings.Join(paths, " and ")))()
+	}
+}
+
+// streamAll reads all the lines of the sources in the time range of the
+// viewer and hands them to write, ordered by time, as they arrive.
+func (viewer Viewer) streamAll(write func(entry) error) error {
+	options := viewer.options
+	options.Follow = false
+	options.Tail = "all"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel() // Stops the streams when writing fails.
+
+	streams := make([]<-chan client.LogLine, len(viewer.sources))
+	errs := make([]chan error, len(viewer.sources))
+	for index, logSource := range viewer.sources {
+		lines := make(chan client.LogLine)
+		errs[index] = make(chan error, 1)
+		streams[index] = lines
+		go func(logSource Source, errs chan<- error) {
+			errs <- contxt.GetClient().StreamLogs(ctx, logSource.ContainerID, options, lines)
+			close(lines)
+		}(logSource.Source, errs[index])
+	}
+
+	if err := mergeByTime(streams, write); err != nil {
+		return err
+	}
+	for index, logSource := range viewer.sources {
+		if err := <-errs[index]; err != nil {
+			return fmt.Errorf("%s: %w", logSource.Name, err)
+		}
+	}
+	return nil
+}
+
+// mergeByTime hands the lines of the streams to write ordered by time. The
+// lines of each stream are in order, so only the next line of each is held.
+func mergeByTime(streams []<-chan client.LogLine, write func(entry) error) error {
+	next := make([]*entry, len(streams))
+	receive := func(index int) {
+		next[index] = nil
+		if line, ok := <-streams[index]; ok {
+			logEntry := newEntry(line)
+			next[index] = &logEntry
+		}
+	}
+	for index := range streams {
+		receive(index)
+	}
+
+	for {
+		earliest := -1
+		for index, line := range next {
+			if line != nil && (earliest == -1 || line.Timestamp.Before(next[earliest].Timestamp)) {
+				earliest = index
+			}
+		}
+		if earliest == -1 {
+			return nil
+		}
+		if err := write(*next[earliest]); err != nil {
+			return err
+		}
+		receive(earliest)
+	}
+}
+
+// paths returns the files written, one per stream when split: logs.txt
+// becomes logs.stdout.txt and logs.stderr.txt.
+func (options saveOptions) paths() []string {
+	if !options.isSplit {
+		return []string{options.path}
+	}
+	extension := filepath.Ext(options.path)
+	base := strings.TrimSuffix(options.path, extension)
+	return []string{base + ".stdout" + extension, base + ".stderr" + extension}
+}
+
+// checkPaths refuses to overwrite existing files.
+func (options saveOptions) checkPaths() error {
+	for _, path := range options.paths() {
+		if _, err := os.Stat(path); err == nil {
+			return fmt.Errorf("%s already exists", path)
+		}
+	}
+	return nil
+}
+
+// writeLogs creates the files of the logs and writes the lines produce
+// hands it, returning their paths and the number of lines. Lines reporting
+// errors of the viewer go with stderr. The files are removed on failure.
+func writeLogs(options saveOptions, format func(entry) string, produce func(write func(entry) error) error) ([]string, int, error) {
+	paths := options.paths()
+	files := make([]*os.File, 0, len(paths))
+	writers := make([]*bufio.Writer, 0, len(paths))
+	remove := func() {
+		for _, file := range files {
+			file.Close()
+			os.Remove(file.Name())
+		}
+	}
+
+	for _, path := range paths {
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err != nil {
+			remove()
+			return nil, 0, err
+		}
+		files = append(files, file)
+		writers = append(writers, bufio.NewWriter(file))
+	}
+
+	count := 0
+	err := produce(func(line entry) error {
+		writer := writers[0]
+		if options.isSplit && line.Stream != "stdout" {
+			writer = writers[1]
+		}
+		count++
+		_, err := fmt.Fprintln(writer, format(line))
+		return err
+	})
+	for index, writer := range writers {
+		if err == nil {
+			err = writer.Flush()
+		}
+		if closeErr := files[index].Close(); err == nil {
+			err = closeErr
+		}
+	}
+	if err != nil {
+		for _, path := range paths {
+			os.Remove(path)
+		}
+		return nil, 0, err
+	}
+	return paths, count, nil
+}
+
+func writeEntries(writer io.Writer, lines []entry, format func(entry) string) error {
+	buffered := bufio.NewWriter(writer)
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(buffered, format(line)); err != nil {
+			return err
+		}
+	}
+	return buffered.Flush()
+}
+
+// pagerCommand returns the program the user reads files with: $PAGER, then
+// $EDITOR, then less when installed.
+func pagerCommand() (string, error) {
+	for _, variable := range []string{"PAGER", "EDITOR"} {
+		if command := strings.TrimSpace(os.Getenv(variable)); command != "" {
+			return command, nil
+		}
+	}
+	if _, err := exec.LookPath("less"); err == nil {
+		return "less", nil
+	}
+	return "", errors.New("set $PAGER or $EDITOR to open the logs")
+}
+
+// openInPager writes the lines shown to a temporary file and hands it to the
+// pager of the user, suspending the interface until it exits.
+func (viewer Viewer) openInPager() tea.Cmd {
+	command, err := pagerCommand()
+	if err != nil {
+		return notifications.ShowError(err)
+	}
+
+	file, err := os.CreateTemp("", "containertui-logs-*.log")
+	if err != nil {
+		return notifications.ShowError(err)
+	}
+	path := file.Name()
+	err = writeEntries(file, viewer.selectLines(viewer.lines, true), viewer.formatter())
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+		return notifications.ShowError(err)
+	}
+
+	// The command is run by a shell, as $PAGER and $EDITOR may hold arguments.
+	process := exec.Command("sh", "-c", command+` "$1"`, "sh", path) //nolint:gosec // The command comes from the environment of the user.
+	return tea.ExecProcess(process, func(err error) tea.Msg {
+		os.Remove(path)
+		if err != nil {
+			return notifications.ShowError(err)()
+		}
+		return nil
+	})
+}
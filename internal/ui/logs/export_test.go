@@ -0,0 +1,72 @@
+package logs
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/givensuman/containertui/internal/client"
+)
+
+func TestParseSaveForm(t *testing.T) {
+	options, err := parseSaveForm(map[string]string{"path": "logs.txt", "content": "full", "filtered": "no", "split": "yes"})
+	if err != nil {
+		t.Fatalf("parseSaveForm returned error: %v", err)
+	}
+	expected := saveOptions{path: "logs.txt", isFull: true, isSplit: true}
+	if options != expected {
+		t.Errorf("parseSaveForm = %+v; want %+v", options, expected)
+	}
+
+	invalid := []map[string]string{
+		{"path": "", "content": "buffer"},
+		{"path": "logs.txt", "content": "everything"},
+		{"path": "logs.txt", "content": "buffer", "split": "maybe"},
+	}
+	for _, values := range invalid {
+		if _, err := parseSaveForm(values); err == nil {
+			t.Errorf("parseSaveForm(%v) expected error, got nil", values)
+		}
+	}
+}
+
+func TestSaveOptionsPaths(t *testing.T) {
+	tests := []struct {
+		options  saveOptions
+		expected []string
+	}{
+		{saveOptions{path: "logs.txt"}, []string{"logs.txt"}},
+		{saveOptions{path: "out/logs.txt", isSplit: true}, []string{"out/logs.stdout.txt", "out/logs.stderr.txt"}},
+		{saveOptions{path: "logs", isSplit: true}, []string{"logs.stdout", "logs.stderr"}},
+	}
+
+	for _, tt := range tests {
+		if result := tt.options.paths(); !reflect.DeepEqual(result, tt.expected) {
+			t.Errorf("%+v.paths() = %v; want %v", tt.options, result, tt.expected)
+		}
+	}
+}
+
+func TestMergeByTime(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	stream := func(containerID string, seconds ...int) <-chan client.LogLine {
+		lines := make(chan client.LogLine, len(seconds))
+		for _, second := range seconds {
+			lines <- client.LogLine{ContainerID: containerID, Timestamp: start.Add(time.Duration(second) * time.Second), Text: containerID}
+		}
+		close(lines)
+		return lines
+	}
+
+	var merged []string
+	err := mergeByTime([]<-chan client.LogLine{stream("a", 1, 4, 5), stream("b"), stream("c", 2, 3, 6)}, func(line entry) error {
+		merged = append(merged, line.Text)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("mergeByTime returned error: %v", err)
+	}
+	if expected := []string{"a", "c", "c", "a", "a", "c"}; !reflect.DeepEqual(merged, expected) {
+		t.Errorf("mergeByTime = %v; want %v", merged, expected)
+	}
+}
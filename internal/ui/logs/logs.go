@@ -0,0 +1,678 @@
+// Package logs defines the log viewer, which follows the logs of one or more
+// containers merged by time.
+package logs
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/givensuman/containertui/internal/client"
+	"github.com/givensuman/containertui/internal/colors"
+	contxt "github.com/givensuman/containertui/internal/context"
+	"github.com/givensuman/containertui/internal/ui/shared"
+	overlay "github.com/rmhubbert/bubbletea-overlay"
+)
+
+// maxLines bounds the lines kept in the viewer.
+const maxLines = 10000
+
+// lineBatchSize bounds the lines received before the viewer is rendered again.
+const lineBatchSize = 500
+
+// errorStream is the stream of the lines reporting why the logs of a container stopped.
+const errorStream = "error"
+
+// timestampLayout shows the offset of the time zone, "Z" in UTC.
+const timestampLayout = "2006-01-02T15:04:05.000Z07:00"
+
+// timestampModes are the ways the time of the lines is shown, cycled through by the user.
+var timestampModes = []string{"", "local", "utc"}
+
+// sourcePalette colors the prefixes of the sources, like docker compose does.
+var sourcePalette = []func() lipgloss.Color{
+	colors.Cyan, colors.Yellow, colors.Green, colors.Magenta, colors.Blue,
+	colors.BrightCyan, colors.BrightYellow, colors.BrightGreen, colors.BrightMagenta, colors.BrightBlue,
+}
+
+// Source is a container whose logs are shown.
+type Source struct {
+	ContainerID string
+	Name        string // Prefixes the lines of the container.
+}
+
+type source struct {
+	Source
+	color    lipgloss.Color
+	isHidden bool
+}
+
+// stream carries the lines of the containers, until cancelled.
+type stream struct {
+	lines  chan client.LogLine
+	cancel context.CancelFunc
+}
+
+// linesMsg are the lines of a stream received together, ending with the end of it when closed.
+type linesMsg struct {
+	stream *stream
+	lines  []client.LogLine
+	closed bool
+}
+
+func (linesMsg) IsBackground() {}
+
+// CloseMsg is sent when the user leaves the viewer.
+type CloseMsg struct{}
+
+type keybindings struct {
+	scroll         key.Binding
+	follow         key.Binding
+	previousSource key.Binding
+	nextSource     key.Binding
+	toggleSource   key.Binding
+	showAll        key.Binding
+	options        key.Binding
+	timestamps     key.Binding
+	filter         key.Binding
+	raw            key.Binding
+	save           key.Binding
+	pager          key.Binding
+	close          key.Binding
+}
+
+func newKeybindings() keybindings {
+	return keybindings{
+		scroll: key.NewBinding(
+			key.WithKeys("up", "down", "pgup", "pgdown"),
+			key.WithHelp("↑/↓", "scroll"),
+		),
+		follow: key.NewBinding(
+			key.WithKeys("G", "end"),
+			key.WithHelp("G", "follow"),
+		),
+		previousSource: key.NewBinding(
+			key.WithKeys("left", "h"),
+			key.WithHelp("←/h", "previous source"),
+		),
+		nextSource: key.NewBinding(
+			key.WithKeys("right", "l"),
+			key.WithHelp("→/l", "next source"),
+		),
+		toggleSource: key.NewBinding(
+			key.WithKeys(tea.KeySpace.String()),
+			key.WithHelp("space", "toggle source"),
+		),
+		showAll: key.NewBinding(
+			key.WithKeys("a"),
+			key.WithHelp("a", "show all sources"),
+		),
+		options: key.NewBinding(
+			key.WithKeys("o"),
+			key.WithHelp("o", "tail/since/until"),
+		),
+		timestamps: key.NewBinding(
+			key.WithKeys("t"),
+			key.WithHelp("t", "cycle timestamps"),
+		),
+		filter: key.NewBinding(
+			key.WithKeys("f"),
+			key.WithHelp("f", "filter"),
+		),
+		raw: key.NewBinding(
+			key.WithKeys("J"),
+			key.WithHelp("J", "raw/pretty json"),
+		),
+		save: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "save to file"),
+		),
+		pager: key.NewBinding(
+			key.WithKeys("e"),
+			key.WithHelp("e", "open in $PAGER/$EDITOR"),
+		),
+		close: key.NewBinding(
+			key.WithKeys("esc", "q"),
+			key.WithHelp("esc", "close logs"),
+		),
+	}
+}
+
+// Viewer follows the logs of containers. With several containers, their
+// lines are ordered by time and prefixed with the name of their source, and
+// sources can be hidden.
+type Viewer struct {
+	shared.Component
+	style          lipgloss.Style
+	viewport       viewport.Model
+	keybindings    keybindings
+	title          string
+	sources        []source
+	selectedSource int
+	options        client.LogOptions
+	timestamps     string // "local" or "utc" to show the time of the lines.
+	columns        []string
+	filter         filter
+	isRaw          bool // Shows JSON lines as written.
+	stream         *stream
+	lines          []entry
+	rendered       []string // The shown lines, rendered.
+	isFollowing    bool
+	isEnded        bool
+	form           shared.Form
+	isEditing      bool
+}
+
+var (
+	_ tea.Model             = (*Viewer)(nil)
+	_ shared.ComponentModel = (*Viewer)(nil)
+)
+
+// New starts following the logs of the sources, with the options of the configuration.
+func New(title string, sources []Source) Viewer {
+	width, height := contxt.GetWindowSize()
+	config := contxt.GetConfig().Logs
+
+	viewer := Viewer{
+		style:       lipgloss.NewStyle().PaddingTop(1).PaddingLeft(2),
+		viewport:    viewport.New(0, 0),
+		keybindings: newKeybindings(),
+		title:       title,
+		options: client.LogOptions{
+			Follow: true,
+			Tail:   config.Tail,
+			Since:  config.Since,
+			Until:  config.Until,
+		},
+		timestamps: strings.ToLower(config.Timestamps),
+		columns:    config.Columns,
+	}
+	for index, logSource := range sources {
+		viewer.sources = append(viewer.sources, source{
+			Source: logSource,
+			color:  sourcePalette[index%len(sourcePalette)](),
+		})
+	}
+
+	viewer.start()
+	viewer.UpdateWindowDimensions(tea.WindowSizeMsg{Width: width, Height: height})
+	return viewer
+}
+
+// start follows the logs of the sources with the current options, replacing the previous stream.
+func (viewer *Viewer) start() {
+	viewer.Stop()
+	viewer.lines = nil
+	viewer.isEnded = false
+	viewer.isFollowing = true
+	viewer.refresh()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	lines := make(chan client.LogLine, lineBatchSize)
+	viewer.stream = &stream{lines: lines, cancel: cancel}
+
+	var group sync.WaitGroup
+	for _, logSource := range viewer.sources {
+		group.Add(1)
+		go func(containerID string, options client.LogOptions) {
+			defer group.Done()
+			followContainer(ctx, containerID, options, lines)
+		}(logSource.ContainerID, viewer.options)
+	}
+	go func() {
+		group.Wait()
+		close(lines)
+	}()
+}
+
+// followContainer forwards the lines of a container, ending with the error which stopped them, if any.
+func followContainer(ctx context.Context, containerID string, options client.LogOptions, lines chan<- client.LogLine) {
+	err := contxt.GetClient().StreamLogs(ctx, containerID, options, lines)
+	if err == nil || ctx.Err() != nil {
+		return
+	}
+
+	select {
+	case lines <- client.LogLine{ContainerID: containerID, Stream: errorStream, Timestamp: time.Now(), Text: err.Error()}:
+	case <-ctx.Done():
+	}
+}
+
+// waitForLines receives the next line of the stream, with the lines already
+// waiting behind it, so that a burst of lines is rendered once.
+func (stream *stream) waitForLines() tea.Msg {
+	line, ok := <-stream.lines
+	if !ok {
+		return linesMsg{stream: stream, closed: true}
+	}
+
+	msg := linesMsg{stream: stream, lines: []client.LogLine{line}}
+	for len(msg.lines) < lineBatchSize {
+		select {
+		case line, ok := <-stream.lines:
+			if !ok {
+				msg.closed = true
+				return msg
+			}
+			msg.lines = append(msg.lines, line)
+		default:
+			return msg
+		}
+	}
+	return msg
+}
+
+// Stop cancels the streams of the logs.
+func (viewer Viewer) Stop() {
+	if viewer.stream != nil {
+		viewer.stream.cancel()
+	}
+}
+
+func (viewer Viewer) Init() tea.Cmd {
+	return viewer.stream.waitForLines
+}
+
+// newOptionsForm edits the lines the logs are read from.
+func newOptionsForm(options client.LogOptions) shared.Form {
+	return shared.NewForm("Log Options", []shared.FormField{
+		{Key: "tail", Label: "Tail", Placeholder: "all", Value: options.Tail, Hint: "lines from the end"},
+		{Key: "since", Label: "Since", Placeholder: "15m or 2024-01-02T15:04:05Z", Value: options.Since},
+		{Key: "until", Label: "Until", Placeholder: "5m or 2024-01-02T15:04:05Z", Value: options.Until},
+	}, shared.SmartDialogAction{Type: "LogOptions"})
+}
+
+// newFilterForm edits the conditions the shown lines match.
+func newFilterForm(terms filter) shared.Form {
+	return shared.NewForm("Filter Logs", []shared.FormField{
+		{Key: "filter", Label: "Filter", Placeholder: "level=error user_id=42 timeout", Value: terms.String(), Hint: "field=value, field!=value or text"},
+	}, shared.SmartDialogAction{Type: "LogFilter"})
+}
+
+func (viewer Viewer) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		viewer.UpdateWindowDimensions(msg)
+		viewer.form.UpdateWindowDimensions(msg)
+		return viewer, nil
+
+	case shared.CloseDialogMessage:
+		viewer.isEditing = false
+		return viewer, nil
+
+	case shared.FormSubmitMessage:
+		switch msg.Action.Type {
+		case "LogOptions":
+			options := client.LogOptions{
+				Follow: true,
+				Tail:   strings.TrimSpace(msg.Values["tail"]),
+				Since:  strings.TrimSpace(msg.Values["since"]),
+				Until:  strings.TrimSpace(msg.Values["until"]),
+			}
+			if err := options.Validate(); err != nil {
+				viewer.form.SetError(err)
+				return viewer, nil
+			}
+			viewer.options = options
+			viewer.isEditing = false
+			viewer.start()
+			return viewer, viewer.stream.waitForLines
+
+		case "LogFilter":
+			terms, err := parseFilter(msg.Values["filter"])
+			if err != nil {
+				viewer.form.SetError(err)
+				return viewer, nil
+			}
+			viewer.filter = terms
+			viewer.isEditing = false
+			viewer.refresh()
+
+		case "SaveLogs":
+			options, err := parseSaveForm(msg.Values)
+			if err == nil {
+				err = options.checkPaths()
+			}
+			if err != nil {
+				viewer.form.SetError(err)
+				return viewer, nil
+			}
+			viewer.isEditing = false
+			return viewer, viewer.save(options)
+		}
+		return viewer, nil
+
+	case linesMsg:
+		if msg.stream != viewer.stream {
+			return viewer, nil
+		}
+		viewer.addLines(msg.lines)
+		if msg.closed {
+			viewer.isEnded = true
+			return viewer, nil
+		}
+		return viewer, viewer.stream.waitForLines
+
+	case tea.KeyMsg:
+		if viewer.isEditing {
+			form, cmd := viewer.form.Update(msg)
+			viewer.form = form.(shared.Form)
+			return viewer, cmd
+		}
+
+		switch {
+		case key.Matches(msg, viewer.keybindings.options):
+			viewer.form = newOptionsForm(viewer.options)
+			viewer.isEditing = true
+			return viewer, viewer.form.Init()
+		case key.Matches(msg, viewer.keybindings.filter):
+			viewer.form = newFilterForm(viewer.filter)
+			viewer.isEditing = true
+			return viewer, viewer.form.Init()
+		case key.Matches(msg, viewer.keybindings.save):
+			viewer.form = newSaveForm()
+			viewer.isEditing = true
+			return viewer, viewer.form.Init()
+		case key.Matches(msg, viewer.keybindings.pager):
+			return viewer, viewer.openInPager()
+		case key.Matches(msg, viewer.keybindings.raw):
+			viewer.isRaw = !viewer.isRaw
+			viewer.refresh()
+			return viewer, nil
+		case key.Matches(msg, viewer.keybindings.timestamps):
+			viewer.timestamps = nextTimestampMode(viewer.timestamps)
+			viewer.refresh()
+			return viewer, nil
+		case key.Matches(msg, viewer.keybindings.close):
+			viewer.Stop()
+			return viewer, func() tea.Msg { return CloseMsg{} }
+		case key.Matches(msg, viewer.keybindings.follow):
+			viewer.isFollowing = true
+			viewer.viewport.GotoBottom()
+			return viewer, nil
+		}
+
+		if len(viewer.sources) > 1 {
+			switch {
+			case key.Matches(msg, viewer.keybindings.previousSource):
+				viewer.selectedSource = (viewer.selectedSource + len(viewer.sources) - 1) % len(viewer.sources)
+				return viewer, nil
+			case key.Matches(msg, viewer.keybindings.nextSource):
+				viewer.selectedSource = (viewer.selectedSource + 1) % len(viewer.sources)
+				return viewer, nil
+			case key.Matches(msg, viewer.keybindings.toggleSource):
+				viewer.sources[viewer.selectedSource].isHidden = !viewer.sources[viewer.selectedSource].isHidden
+				viewer.refresh()
+				return viewer, nil
+			case key.Matches(msg, viewer.keybindings.showAll):
+				for index := range viewer.sources {
+					viewer.sources[index].isHidden = false
+				}
+				viewer.refresh()
+				return viewer, nil
+			}
+		}
+	}
+
+	if viewer.isEditing {
+		form, cmd := viewer.form.Update(msg)
+		viewer.form = form.(shared.Form)
+		return viewer, cmd
+	}
+
+	var cmd tea.Cmd
+	viewer.viewport, cmd = viewer.viewport.Update(msg)
+	viewer.isFollowing = viewer.viewport.AtBottom()
+	return viewer, cmd
+}
+
+// nextTimestampMode returns the mode shown after the given one.
+func nextTimestampMode(mode string) string {
+	for index, timestampMode := range timestampModes {
+		if timestampMode == mode {
+			return timestampModes[(index+1)%len(timestampModes)]
+		}
+	}
+	return timestampModes[0]
+}
+
+// IsCapturingInput reports whether keys are being typed into the options.
+func (viewer Viewer) IsCapturingInput() bool {
+	return viewer.isEditing
+}
+
+// addLines adds lines in the order of the timestamps. Lines following the
+// last one are rendered on their own; the past lines of the containers
+// arrive concurrently though, and a line older than the last renders all
+// of them again.
+func (viewer *Viewer) addLines(lines []client.LogLine) {
+	isReordered := false
+	for _, line := range lines {
+		index := sort.Search(len(viewer.lines), func(index int) bool {
+			return viewer.lines[index].Timestamp.After(line.Timestamp)
+		})
+		logEntry := newEntry(line)
+		viewer.lines = append(viewer.lines, entry{})
+		copy(viewer.lines[index+1:], viewer.lines[index:])
+		viewer.lines[index] = logEntry
+
+		if index < len(viewer.lines)-1 {
+			isReordered = true
+		} else if !isReordered && viewer.isShown(logEntry) {
+			viewer.rendered = append(viewer.rendered, viewer.renderLine(logEntry))
+		}
+
+		if len(viewer.lines) > maxLines {
+			if !isReordered && viewer.isShown(viewer.lines[0]) {
+				viewer.rendered = viewer.rendered[1:]
+			}
+			viewer.lines = viewer.lines[1:]
+		}
+	}
+
+	if isReordered {
+		viewer.refresh()
+	} else {
+		viewer.setContent()
+	}
+}
+
+// refresh renders the lines of the visible sources again, after the lines,
+// the filter or the way they are shown changed.
+func (viewer *Viewer) refresh() {
+	viewer.rendered = viewer.rendered[:0]
+	for _, line := range viewer.lines {
+		if viewer.isShown(line) {
+			viewer.rendered = append(viewer.rendered, viewer.renderLine(line))
+		}
+	}
+	viewer.setContent()
+}
+
+// setContent shows the rendered lines, keeping the end in view while following.
+func (viewer *Viewer) setContent() {
+	viewer.viewport.SetContent(strings.Join(viewer.rendered, "\n"))
+	if viewer.isFollowing {
+		viewer.viewport.GotoBottom()
+	}
+}
+
+// source returns the source of a line.
+func (viewer Viewer) source(line entry) source {
+	for _, logSource := range viewer.sources {
+		if logSource.ContainerID == line.ContainerID {
+			return logSource
+		}
+	}
+	return source{}
+}
+
+// isShown reports whether a line is of a visible source and matches the filter.
+func (viewer Viewer) isShown(line entry) bool {
+	return !viewer.source(line).isHidden && viewer.filter.matches(line)
+}
+
+// renderLine styles a line, prefixed with its time and source as configured.
+func (viewer Viewer) renderLine(line entry) string {
+	errorStyle := lipgloss.NewStyle().Foreground(colors.Error())
+	timestampStyle := lipgloss.NewStyle().Foreground(colors.Muted())
+
+	text := line.Text
+	switch {
+	case line.Stream == errorStream:
+		text = errorStyle.Render(text)
+	case line.fields != nil && !viewer.isRaw:
+		text = renderFields(line, viewer.columns)
+	}
+	switch viewer.timestamps {
+	case "local":
+		text = timestampStyle.Render(line.Timestamp.Local().Format(timestampLayout)) + " " + text
+	case "utc":
+		text = timestampStyle.Render(line.Timestamp.UTC().Format(timestampLayout)) + " " + text
+	}
+	if len(viewer.sources) > 1 {
+		prefixWidth := 0
+		for _, logSource := range viewer.sources {
+			prefixWidth = shared.Max(prefixWidth, lipgloss.Width(logSource.Name))
+		}
+		logSource := viewer.source(line)
+		prefix := lipgloss.NewStyle().Foreground(logSource.color).Render(fmt.Sprintf("%-*s |", prefixWidth, logSource.Name))
+		text = prefix + " " + text
+	}
+	return text
+}
+
+func (viewer *Viewer) UpdateWindowDimensions(msg tea.WindowSizeMsg) {
+	viewer.WindowWidth = msg.Width
+	viewer.WindowHeight = msg.Height
+
+	headerHeight := 3 // The title, the options and a blank line.
+	if len(viewer.sources) > 1 {
+		headerHeight++ // The sources.
+	}
+
+	viewer.style = viewer.style.Width(msg.Width).Height(msg.Height)
+	viewer.viewport.Width = shared.Max(msg.Width-viewer.style.GetHorizontalFrameSize(), 0)
+	viewer.viewport.Height = shared.Max(msg.Height-viewer.style.GetVerticalFrameSize()-headerHeight, 0)
+}
+
+func (viewer Viewer) View() string {
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(colors.Primary())
+	mutedStyle := lipgloss.NewStyle().Foreground(colors.Muted())
+
+	status := "following"
+	switch {
+	case viewer.isEnded:
+		status = "ended"
+	case !viewer.isFollowing:
+		status = "paused, G to follow"
+	}
+	count := fmt.Sprintf("%d lines", len(viewer.lines))
+	if len(viewer.rendered) != len(viewer.lines) {
+		count = fmt.Sprintf("%d of %d lines", len(viewer.rendered), len(viewer.lines))
+	}
+	header := []string{
+		headerStyle.Render(viewer.title) + mutedStyle.Render(fmt.Sprintf("  %s • %s", count, status)),
+		mutedStyle.Render(viewer.describeOptions()),
+	}
+
+	if len(viewer.sources) > 1 {
+		chips := make([]string, len(viewer.sources))
+		for index, logSource := range viewer.sources {
+			style := lipgloss.NewStyle().Foreground(logSource.color)
+			if logSource.isHidden {
+				style = lipgloss.NewStyle().Foreground(colors.Muted()).Strikethrough(true)
+			}
+			if index == viewer.selectedSource {
+				style = style.Underline(true).Bold(true)
+			}
+			chips[index] = style.Render(logSource.Name)
+		}
+		header = append(header, strings.Join(chips, "  "))
+	}
+
+	content := viewer.viewport.View()
+	if len(viewer.lines) == 0 {
+		content = mutedStyle.Render("Waiting for logs...")
+		if viewer.isEnded {
+			content = mutedStyle.Render("No logs.")
+		}
+	}
+
+	view := viewer.style.Render(lipgloss.JoinVertical(lipgloss.Left, append(header, "", content)...))
+	if viewer.isEditing {
+		return overlay.New(viewer.form, staticView(view), overlay.Center, overlay.Center, 0, 0).View()
+	}
+	return view
+}
+
+// describeOptions summarizes the lines the logs are read from and how they are shown.
+func (viewer Viewer) describeOptions() string {
+	tail := viewer.options.Tail
+	if tail == "" {
+		tail = "all"
+	}
+	parts := []string{"tail " + tail}
+	if viewer.options.Since != "" {
+		parts = append(parts, "since "+viewer.options.Since)
+	}
+	if viewer.options.Until != "" {
+		parts = append(parts, "until "+viewer.options.Until)
+	}
+	switch viewer.timestamps {
+	case "local":
+		parts = append(parts, "local time")
+	case "utc":
+		parts = append(parts, "UTC time")
+	default:
+		parts = append(parts, "no timestamps")
+	}
+	if len(viewer.filter) > 0 {
+		parts = append(parts, "filter "+viewer.filter.String())
+	}
+	return strings.Join(parts, " • ")
+}
+
+// staticView is a rendered view, drawn behind the options form.
+type staticView string
+
+func (view staticView) Init() tea.Cmd                       { return nil }
+func (view staticView) Update(tea.Msg) (tea.Model, tea.Cmd) { return view, nil }
+func (view staticView) View() string                        { return string(view) }
+
+func (viewer Viewer) ShortHelp() []key.Binding {
+	bindings := []key.Binding{viewer.keybindings.scroll, viewer.keybindings.follow, viewer.keybindings.filter, viewer.keybindings.options}
+	if len(viewer.sources) > 1 {
+		bindings = append(bindings, viewer.keybindings.nextSource, viewer.keybindings.toggleSource)
+	}
+	return append(bindings, viewer.keybindings.close)
+}
+
+func (viewer Viewer) FullHelp() [][]key.Binding {
+	bindings := []key.Binding{
+		viewer.keybindings.scroll,
+		viewer.keybindings.follow,
+		viewer.keybindings.options,
+		viewer.keybindings.timestamps,
+		viewer.keybindings.filter,
+		viewer.keybindings.raw,
+		viewer.keybindings.save,
+		viewer.keybindings.pager,
+	}
+	if len(viewer.sources) > 1 {
+		bindings = append(bindings,
+			viewer.keybindings.previousSource,
+			viewer.keybindings.nextSource,
+			viewer.keybindings.toggleSource,
+			viewer.keybindings.showAll,
+		)
+	}
+	return [][]key.Binding{append(bindings, viewer.keybindings.close)}
+}
@@ -0,0 +1,64 @@
+package logs
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	"github.com/givensuman/containertui/internal/client"
+)
+
+func TestAddLines(t *testing.T) {
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	line := func(containerID string, second int, text string) client.LogLine {
+		return client.LogLine{ContainerID: containerID, Timestamp: start.Add(time.Duration(second) * time.Second), Text: text}
+	}
+
+	viewer := Viewer{
+		viewport: viewport.New(80, 10),
+		sources:  []source{{Source: Source{ContainerID: "a", Name: "a"}}, {Source: Source{ContainerID: "b", Name: "b"}, isHidden: true}},
+	}
+	viewer.addLines([]client.LogLine{line("a", 1, "one"), line("b", 2, "two"), line("a", 3, "three")})
+	viewer.addLines([]client.LogLine{line("a", 4, "four")})
+	// Older than the last line, so the lines are rendered again.
+	viewer.addLines([]client.LogLine{line("a", 0, "zero"), line("a", 5, "five")})
+
+	var texts []string
+	for _, logEntry := range viewer.lines {
+		texts = append(texts, logEntry.Text)
+	}
+	if want := []string{"zero", "one", "two", "three", "four", "five"}; !reflect.DeepEqual(texts, want) {
+		t.Errorf("lines = %q; want %q", texts, want)
+	}
+
+	// The lines of the hidden source are left out.
+	expected := make([]string, 0, 5)
+	for _, logEntry := range viewer.lines {
+		if logEntry.ContainerID == "a" {
+			expected = append(expected, viewer.renderLine(logEntry))
+		}
+	}
+	if !reflect.DeepEqual(viewer.rendered, expected) {
+		t.Errorf("rendered = %q; want %q", viewer.rendered, expected)
+	}
+}
+
+func TestAddLinesKeepsMaxLines(t *testing.T) {
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	viewer := Viewer{viewport: viewport.New(80, 10), sources: []source{{Source: Source{ContainerID: "a", Name: "a"}}}}
+
+	lines := make([]client.LogLine, maxLines+10)
+	for index := range lines {
+		lines[index] = client.LogLine{ContainerID: "a", Timestamp: start.Add(time.Duration(index) * time.Millisecond), Text: "line"}
+	}
+	viewer.addLines(lines[:maxLines])
+	viewer.addLines(lines[maxLines:])
+
+	if len(viewer.lines) != maxLines || len(viewer.rendered) != maxLines {
+		t.Errorf("kept %d lines, rendered %d; want %d", len(viewer.lines), len(viewer.rendered), maxLines)
+	}
+	if first := viewer.lines[0].Timestamp; !first.Equal(lines[10].Timestamp) {
+		t.Errorf("first line at %v; want %v", first, lines[10].Timestamp)
+	}
+}
@@ -0,0 +1,196 @@
+package logs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/givensuman/containertui/internal/client"
+	"github.com/givensuman/containertui/internal/colors"
+)
+
+// fieldAliases are the keys loggers commonly write the standard columns under.
+var fieldAliases = map[string][]string{
+	"time":  {"time", "ts", "timestamp", "@timestamp"},
+	"level": {"level", "lvl", "severity", "@level"},
+	"msg":   {"msg", "message", "@message"},
+}
+
+// entry is a line of the logs, with its fields when it is a JSON object.
+type entry struct {
+	client.LogLine
+	fields map[string]any
+}
+
+func newEntry(line client.LogLine) entry {
+	logEntry := entry{LogLine: line}
+
+	text := strings.TrimSpace(line.Text)
+	if !strings.HasPrefix(text, "{") || !strings.HasSuffix(text, "}") {
+		return logEntry
+	}
+	decoder := json.NewDecoder(strings.NewReader(text))
+	decoder.UseNumber()
+	var fields map[string]any
+	if err := decoder.Decode(&fields); err == nil {
+		logEntry.fields = fields
+	}
+	return logEntry
+}
+
+// lookup finds a field by its name, or by an alias of a standard column.
+func (logEntry entry) lookup(name string) (string, any, bool) {
+	if value, ok := logEntry.fields[name]; ok {
+		return name, value, true
+	}
+	for _, alias := range fieldAliases[name] {
+		if value, ok := logEntry.fields[alias]; ok {
+			return alias, value, true
+		}
+	}
+	return "", nil, false
+}
+
+// formatValue renders a field as written in the line, without the quotes of strings.
+func formatValue(value any) string {
+	switch value := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return value
+	case json.Number:
+		return value.String()
+	case bool:
+		return strconv.FormatBool(value)
+	}
+
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return fmt.Sprint(value)
+	}
+	return strings.TrimSuffix(buffer.String(), "\n")
+}
+
+// levelColor colors the common severities of loggers.
+func levelColor(level string) lipgloss.Color {
+	switch strings.ToLower(level) {
+	case "fatal", "panic", "crit", "critical", "error", "err", "alert", "emerg", "emergency":
+		return colors.Error()
+	case "warn", "warning":
+		return colors.Warning()
+	case "info", "notice":
+		return colors.Success()
+	default:
+		return colors.Muted()
+	}
+}
+
+// renderFields renders the columns of a JSON line, then its other fields as key=value.
+func renderFields(logEntry entry, columns []string) string {
+	mutedStyle := lipgloss.NewStyle().Foreground(colors.Muted())
+
+	shown := make(map[string]bool, len(columns))
+	parts := make([]string, 0, len(logEntry.fields))
+	for _, column := range columns {
+		key, value, ok := logEntry.lookup(column)
+		if !ok {
+			continue
+		}
+		shown[key] = true
+
+		text := formatValue(value)
+		switch column {
+		case "time":
+			parts = append(parts, mutedStyle.Render(text))
+		case "level":
+			parts = append(parts, lipgloss.NewStyle().Foreground(levelColor(text)).Bold(true).Render(fmt.Sprintf("%-5s", strings.ToUpper(text))))
+		default:
+			parts = append(parts, text)
+		}
+	}
+
+	keys := make([]string, 0, len(logEntry.fields))
+	for key := range logEntry.fields {
+		if !shown[key] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		parts = append(parts, mutedStyle.Render(key+"=")+formatValue(logEntry.fields[key]))
+	}
+	return strings.Join(parts, " ")
+}
+
+// term is a condition of a filter: a field compared to a value, or a text
+// searched in the line when there is no field.
+type term struct {
+	field     string
+	value     string
+	isNegated bool
+}
+
+// filter keeps the lines matching all of its terms.
+type filter []term
+
+// parseFilter reads space separated terms like level=error, user_id!=42 or timeout.
+func parseFilter(expression string) (filter, error) {
+	var terms filter
+	for _, word := range strings.Fields(expression) {
+		field, value, found := strings.Cut(word, "=")
+		if !found {
+			terms = append(terms, term{value: word})
+			continue
+		}
+
+		condition := term{field: field, value: value}
+		if strings.HasSuffix(field, "!") {
+			condition.field = strings.TrimSuffix(field, "!")
+			condition.isNegated = true
+		}
+		if condition.field == "" {
+			return nil, fmt.Errorf("invalid filter %q: expected field=value", word)
+		}
+		terms = append(terms, condition)
+	}
+	return terms, nil
+}
+
+func (terms filter) matches(logEntry entry) bool {
+	for _, condition := range terms {
+		if condition.field == "" {
+			if !strings.Contains(strings.ToLower(logEntry.Text), strings.ToLower(condition.value)) {
+				return false
+			}
+			continue
+		}
+
+		_, value, ok := logEntry.lookup(condition.field)
+		isEqual := ok && strings.EqualFold(formatValue(value), condition.value)
+		if isEqual == condition.isNegated {
+			return false
+		}
+	}
+	return true
+}
+
+func (terms filter) String() string {
+	words := make([]string, len(terms))
+	for index, condition := range terms {
+		switch {
+		case condition.field == "":
+			words[index] = condition.value
+		case condition.isNegated:
+			words[index] = condition.field + "!=" + condition.value
+		default:
+			words[index] = condition.field + "=" + condition.value
+		}
+	}
+	return strings.Join(words, " ")
+}
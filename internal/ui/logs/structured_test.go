@@ -0,0 +1,69 @@
+package logs
+
+import (
+	"testing"
+
+	"github.com/givensuman/containertui/internal/client"
+)
+
+func TestNewEntry(t *testing.T) {
+	tests := []struct {
+		text     string
+		isJSON   bool
+		expected string // The message, for JSON lines.
+	}{
+		{`{"level":"info","msg":"started","port":8080}`, true, "started"},
+		{`  {"lvl":"warn","message":"slow"}`, true, "slow"},
+		{`GET / 200`, false, ""},
+		{`{not json}`, false, ""},
+		{`{"msg":"truncated"`, false, ""},
+	}
+
+	for _, tt := range tests {
+		logEntry := newEntry(client.LogLine{Text: tt.text})
+		if (logEntry.fields != nil) != tt.isJSON {
+			t.Errorf("newEntry(%q) parsed fields %v; want JSON %v", tt.text, logEntry.fields, tt.isJSON)
+			continue
+		}
+		if !tt.isJSON {
+			continue
+		}
+		if _, msg, _ := logEntry.lookup("msg"); formatValue(msg) != tt.expected {
+			t.Errorf("newEntry(%q) message = %v; want %q", tt.text, msg, tt.expected)
+		}
+	}
+}
+
+func TestFilterMatches(t *testing.T) {
+	errorLine := newEntry(client.LogLine{Text: `{"severity":"ERROR","msg":"query timeout","user_id":42}`})
+	infoLine := newEntry(client.LogLine{Text: `{"level":"info","msg":"ok","user_id":7}`})
+	rawLine := newEntry(client.LogLine{Text: "connection timeout"})
+
+	tests := []struct {
+		expression string
+		expected   [3]bool // Whether the error, info and raw lines match.
+	}{
+		{"", [3]bool{true, true, true}},
+		{"level=error", [3]bool{true, false, false}},
+		{"user_id=42", [3]bool{true, false, false}},
+		{"level!=error", [3]bool{false, true, true}},
+		{"timeout", [3]bool{true, false, true}},
+		{"level=error timeout", [3]bool{true, false, false}},
+	}
+
+	for _, tt := range tests {
+		terms, err := parseFilter(tt.expression)
+		if err != nil {
+			t.Fatalf("parseFilter(%q) returned error: %v", tt.expression, err)
+		}
+		for index, logEntry := range []entry{errorLine, infoLine, rawLine} {
+			if result := terms.matches(logEntry); result != tt.expected[index] {
+				t.Errorf("parseFilter(%q).matches(%q) = %v; want %v", tt.expression, logEntry.Text, result, tt.expected[index])
+			}
+		}
+	}
+
+	if _, err := parseFilter("=error"); err == nil {
+		t.Error("expected error for a filter without a field, got nil")
+	}
+}
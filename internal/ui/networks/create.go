@@ -0,0 +1,156 @@
+package networks
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/givensuman/containertui/internal/client"
+	"github.com/givensuman/containertui/internal/context"
+	"github.com/givensuman/containertui/internal/ui/shared"
+)
+
+// endpointTarget identifies a container and the network it is connected to, or disconnected from.
+type endpointTarget struct {
+	networkID     string
+	networkName   string
+	containerID   string
+	containerName string
+}
+
+func newCreateNetworkForm() shared.Form {
+	return shared.NewForm(
+		"Create network",
+		[]shared.FormField{
+			{Key: "name", Label: "Name"},
+			{Key: "driver", Label: "Driver", Placeholder: "bridge"},
+			{Key: "subnet", Label: "Subnet", Placeholder: "172.28.0.0/16", Hint: "allocated by the daemon when empty"},
+			{Key: "gateway", Label: "Gateway", Placeholder: "172.28.0.1"},
+			{Key: "ipRange", Label: "IP range", Placeholder: "172.28.5.0/24"},
+			{Key: "flags", Label: "Flags", Placeholder: "internal attachable ipv6", Hint: "any of internal, attachable, ipv6"},
+			{Key: "labels", Label: "Labels", Placeholder: "com.example.team=backend", Hint: "space separated key=value"},
+		},
+		shared.SmartDialogAction{Type: "CreateNetwork"},
+	)
+}
+
+// parseCreateNetworkOptions converts the values of the create network form.
+func parseCreateNetworkOptions(values map[string]string) (client.CreateNetworkOptions, error) {
+	options := client.CreateNetworkOptions{
+		Name:    values["name"],
+		Driver:  values["driver"],
+		Subnet:  values["subnet"],
+		Gateway: values["gateway"],
+		IPRange: values["ipRange"],
+	}
+
+	if options.Name == "" {
+		return options, fmt.Errorf("a name is required")
+	}
+
+	for _, flag := range strings.Fields(strings.ToLower(values["flags"])) {
+		switch flag {
+		case "internal":
+			options.Internal = true
+		case "attachable":
+			options.Attachable = true
+		case "ipv6":
+			options.EnableIPv6 = true
+		default:
+			return options, fmt.Errorf("unknown flag %q, expected internal, attachable or ipv6", flag)
+		}
+	}
+
+	labels, err := shared.ParseKeyValuePairs(values["labels"])
+	if err != nil {
+		return options, fmt.Errorf("labels: %w", err)
+	}
+	options.Labels = labels
+
+	return options, nil
+}
+
+func createNetwork(values map[string]string) (client.Network, error) {
+	options, err := parseCreateNetworkOptions(values)
+	if err != nil {
+		return client.Network{}, err
+	}
+
+	return context.GetClient().CreateNetwork(options)
+}
+
+// newContainerPicker lists the containers which can be connected to the
+// network, or, with connected set, those which can be disconnected from it.
+func newContainerPicker(network client.Network, connected bool) (shared.Picker, error) {
+	containers, err := context.GetClient().GetContainers()
+	if err != nil {
+		return shared.Picker{}, err
+	}
+	connectedNames, err := context.GetClient().GetContainersUsingNetwork(network.ID)
+	if err != nil {
+		return shared.Picker{}, err
+	}
+
+	var items []shared.PickerItem
+	for _, container := range containers {
+		if slices.Contains(connectedNames, container.Name) != connected {
+			continue
+		}
+		items = append(items, shared.PickerItem{
+			Label:  container.Name,
+			Detail: fmt.Sprintf("%s • %s", container.State, container.Image),
+			Value: endpointTarget{
+				networkID:     network.ID,
+				networkName:   network.Name,
+				containerID:   container.ID,
+				containerName: container.Name,
+			},
+		})
+	}
+
+	if connected {
+		return shared.NewPicker(
+			"Disconnect a container from "+network.Name,
+			items,
+			shared.SmartDialogAction{Type: "PickContainerToDisconnect"},
+		), nil
+	}
+
+	return shared.NewPicker(
+		"Connect a container to "+network.Name,
+		items,
+		shared.SmartDialogAction{Type: "PickContainerToConnect"},
+	), nil
+}
+
+func newConnectForm(target endpointTarget) shared.Form {
+	return shared.NewForm(
+		fmt.Sprintf("Connect %s to %s", target.containerName, target.networkName),
+		[]shared.FormField{
+			{Key: "ipAddress", Label: "IP address", Placeholder: "172.28.5.10", Hint: "optional, must be in the network's subnet"},
+			{Key: "aliases", Label: "Aliases", Placeholder: "db primary-db", Hint: "optional, space or comma separated"},
+		},
+		shared.SmartDialogAction{Type: "ConnectContainer", Payload: target},
+	)
+}
+
+func connectContainer(target endpointTarget, values map[string]string) error {
+	aliases := strings.FieldsFunc(values["aliases"], func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+
+	return context.GetClient().ConnectContainer(target.networkID, target.containerID, client.ConnectContainerOptions{
+		IPAddress: values["ipAddress"],
+		Aliases:   aliases,
+	})
+}
+
+func newDisconnectDialog(target endpointTarget) shared.SmartDialog {
+	return shared.NewSmartDialog(
+		fmt.Sprintf("Disconnect %s from network %s?", target.containerName, target.networkName),
+		[]shared.DialogButton{
+			{Label: "Cancel", IsSafe: true},
+			{Label: "Disconnect", IsSafe: false, Action: shared.SmartDialogAction{Type: "DisconnectContainer", Payload: target}},
+		},
+	)
+}
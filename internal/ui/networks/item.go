@@ -60,6 +60,14 @@ func (networkItem NetworkItem) getTitleOrnament() string {
 	return ""
 }
 
+// getBuiltinOrnament marks the networks created by the daemon, which cannot be removed.
+func (networkItem NetworkItem) getBuiltinOrnament() string {
+	if context.GetConfig().NoNerdFonts {
+		return "*"
+	}
+	return "\uf023"
+}
+
 func (networkItem NetworkItem) Title() string {
 	titleOrnament := networkItem.getTitleOrnament()
 
@@ -67,6 +75,11 @@ func (networkItem NetworkItem) Title() string {
 	title = lipgloss.NewStyle().
 		Foreground(colors.Muted()).
 		Render(title)
+	if networkItem.Network.IsBuiltin() {
+		title += lipgloss.NewStyle().
+			Foreground(colors.Warning()).
+			Render(" " + networkItem.getBuiltinOrnament())
+	}
 
 	statusIcon := networkItem.getIsSelectedIcon()
 	var isSelectedColor lipgloss.Color
@@ -88,7 +101,11 @@ func (networkItem NetworkItem) Description() string {
 	if len(networkItem.Network.ID) > 12 {
 		shortID = networkItem.Network.ID[:12]
 	}
-	return "   " + shortID
+	description := fmt.Sprintf("   %s • %s", shortID, networkItem.Network.Driver)
+	if networkItem.Network.IsBuiltin() {
+		description += " • built-in"
+	}
+	return description
 }
 
 func (networkItem NetworkItem) FilterValue() string {
@@ -3,23 +3,30 @@ package networks
 
 import (
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/docker/go-units"
 	"github.com/givensuman/containertui/internal/client"
 	"github.com/givensuman/containertui/internal/colors"
 	"github.com/givensuman/containertui/internal/context"
+	"github.com/givensuman/containertui/internal/ui/notifications"
 	"github.com/givensuman/containertui/internal/ui/shared"
 	overlay "github.com/rmhubbert/bubbletea-overlay"
 )
 
 type detailsKeybindings struct {
-	Up     key.Binding
-	Down   key.Binding
-	Switch key.Binding
+	Up         key.Binding
+	Down       key.Binding
+	Connect    key.Binding
+	Disconnect key.Binding
+	Switch     key.Binding
 }
 
 func newDetailsKeybindings() detailsKeybindings {
@@ -32,6 +39,14 @@ func newDetailsKeybindings() detailsKeybindings {
 			key.WithKeys("down", "j"),
 			key.WithHelp("↓/j", "down"),
 		),
+		Connect: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", "connect container"),
+		),
+		Disconnect: key.NewBinding(
+			key.WithKeys("D"),
+			key.WithHelp("D", "disconnect container"),
+		),
 		Switch: key.NewBinding(
 			key.WithKeys("tab"),
 			key.WithHelp("tab", "switch focus"),
@@ -43,6 +58,9 @@ type keybindings struct {
 	toggleSelection      key.Binding
 	toggleSelectionOfAll key.Binding
 	remove               key.Binding
+	create               key.Binding
+	topology             key.Binding
+	ports                key.Binding
 	switchTab            key.Binding
 }
 
@@ -60,9 +78,21 @@ func newKeybindings() *keybindings {
 			key.WithKeys("r"),
 			key.WithHelp("r", "remove"),
 		),
+		create: key.NewBinding(
+			key.WithKeys("n"),
+			key.WithHelp("n", "new network"),
+		),
+		topology: key.NewBinding(
+			key.WithKeys("t"),
+			key.WithHelp("t", "topology"),
+		),
+		ports: key.NewBinding(
+			key.WithKeys("p"),
+			key.WithHelp("p", "published ports"),
+		),
 		switchTab: key.NewBinding(
-			key.WithKeys("1", "2", "3", "4", "tab", "shift+tab"),
-			key.WithHelp("1-4/tab", "switch tab"),
+			key.WithKeys("1", "2", "3", "4", "5", "6", "7", "tab", "shift+tab"),
+			key.WithHelp("1-7/tab", "switch tab"),
 		),
 	}
 }
@@ -91,6 +121,8 @@ type sessionState int
 const (
 	viewMain sessionState = iota
 	viewOverlay
+	viewTopology
+	viewPorts
 )
 
 const (
@@ -112,6 +144,9 @@ type Model struct {
 	detailsKeybindings detailsKeybindings
 	foreground         tea.Model
 	overlayModel       *overlay.Model
+	detailsNetworkID   string // ID of the network whose details are shown.
+	topology           Topology
+	ports              Ports
 }
 
 var (
@@ -152,6 +187,9 @@ func New() Model {
 			networkKeybindings.toggleSelection,
 			networkKeybindings.toggleSelectionOfAll,
 			networkKeybindings.remove,
+			networkKeybindings.create,
+			networkKeybindings.topology,
+			networkKeybindings.ports,
 			networkKeybindings.switchTab,
 		}
 	}
@@ -168,19 +206,49 @@ func New() Model {
 		focusedView:        focusList,
 		detailsKeybindings: newDetailsKeybindings(),
 	}
+	// The details of the first network are loaded by Init.
+	model.refreshDetails(false)
 
 	model.overlayModel = overlay.New(nil, model.list, overlay.Center, overlay.Center, 0, 0)
 	return model
 }
 
 func (model Model) Init() tea.Cmd {
-	return nil
+	if model.detailsNetworkID == "" {
+		return nil
+	}
+	return loadNetworkDetails(model.detailsNetworkID)
 }
 
 func (model Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 
+	if detailsMsg, ok := msg.(networkDetailsMsg); ok {
+		model.showDetails(detailsMsg)
+		return model, nil
+	}
+
 	switch model.sessionState {
+	case viewTopology:
+		if _, ok := msg.(closeTopologyMsg); ok {
+			model.sessionState = viewMain
+			model.topology = Topology{}
+			break
+		}
+
+		updatedTopology, topologyCmd := model.topology.Update(msg)
+		model.topology = updatedTopology.(Topology)
+		cmds = append(cmds, topologyCmd)
+	case viewPorts:
+		if _, ok := msg.(closePortsMsg); ok {
+			model.sessionState = viewMain
+			model.ports = Ports{}
+			break
+		}
+
+		updatedPorts, portsCmd := model.ports.Update(msg)
+		model.ports = updatedPorts.(Ports)
+		cmds = append(cmds, portsCmd)
 	case viewOverlay:
 		foregroundModel, foregroundCmd := model.foreground.Update(msg)
 		model.foreground = foregroundModel
@@ -190,15 +258,55 @@ func (model Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			model.sessionState = viewMain
 			model.foreground = nil
 		} else if confirmMsg, ok := msg.(shared.ConfirmationMessage); ok {
-			if confirmMsg.Action.Type == "DeleteNetwork" {
+			switch confirmMsg.Action.Type {
+			case "DeleteNetwork":
 				networkID := confirmMsg.Action.Payload.(string)
 				err := context.GetClient().RemoveNetwork(networkID)
 				if err != nil {
 					break
 				}
+			case "PickContainerToConnect":
+				form := newConnectForm(confirmMsg.Action.Payload.(endpointTarget))
+				model.foreground = form
+				cmds = append(cmds, form.Init())
+				return model, tea.Batch(cmds...)
+			case "PickContainerToDisconnect":
+				model.foreground = newDisconnectDialog(confirmMsg.Action.Payload.(endpointTarget))
+				return model, tea.Batch(cmds...)
+			case "DisconnectContainer":
+				target := confirmMsg.Action.Payload.(endpointTarget)
+				if err := context.GetClient().DisconnectContainer(target.networkID, target.containerID, false); err != nil {
+					cmds = append(cmds, notifications.ShowError(err))
+					break
+				}
+				cmds = append(cmds, notifications.ShowSuccess(fmt.Sprintf("Disconnected %s from %s", target.containerName, target.networkName)))
+				cmds = append(cmds, model.refreshDetails(true))
 			}
 			model.sessionState = viewMain
 			model.foreground = nil
+		} else if submitMsg, ok := msg.(shared.FormSubmitMessage); ok {
+			switch submitMsg.Action.Type {
+			case "CreateNetwork":
+				network, err := createNetwork(submitMsg.Values)
+				if err != nil {
+					model.foreground = shared.SetFormError(model.foreground, err)
+					break
+				}
+				cmds = append(cmds, model.insertNetwork(network))
+				cmds = append(cmds, notifications.ShowSuccess("Created network "+network.Name))
+				model.sessionState = viewMain
+				model.foreground = nil
+			case "ConnectContainer":
+				target := submitMsg.Action.Payload.(endpointTarget)
+				if err := connectContainer(target, submitMsg.Values); err != nil {
+					model.foreground = shared.SetFormError(model.foreground, err)
+					break
+				}
+				cmds = append(cmds, notifications.ShowSuccess(fmt.Sprintf("Connected %s to %s", target.containerName, target.networkName)))
+				cmds = append(cmds, model.refreshDetails(true))
+				model.sessionState = viewMain
+				model.foreground = nil
+			}
 		}
 	case viewMain:
 		if keyMsg, ok := msg.(tea.KeyMsg); ok {
@@ -217,6 +325,20 @@ func (model Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			isKeyMessage = true
 		}
 
+		if keyMsg, ok := msg.(tea.KeyMsg); ok && model.focusedView == focusDetails {
+			networkItem, hasNetwork := model.list.SelectedItem().(NetworkItem)
+			connect := key.Matches(keyMsg, model.detailsKeybindings.Connect)
+			if hasNetwork && (connect || key.Matches(keyMsg, model.detailsKeybindings.Disconnect)) {
+				picker, err := newContainerPicker(networkItem.Network, !connect)
+				if err != nil {
+					return model, notifications.ShowError(err)
+				}
+				model.foreground = picker
+				model.sessionState = viewOverlay
+				return model, nil
+			}
+		}
+
 		if !isKeyMessage || model.focusedView == focusList {
 			switch msg := msg.(type) {
 			case tea.WindowSizeMsg:
@@ -233,10 +355,33 @@ func (model Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					model.handleToggleSelection()
 				case key.Matches(msg, model.keybindings.toggleSelectionOfAll):
 					model.handleToggleSelectionOfAll()
+				case key.Matches(msg, model.keybindings.create):
+					form := newCreateNetworkForm()
+					model.foreground = form
+					model.sessionState = viewOverlay
+					cmds = append(cmds, form.Init())
+				case key.Matches(msg, model.keybindings.topology):
+					model.topology = newTopology()
+					model.sessionState = viewTopology
+					return model, model.topology.Init()
+				case key.Matches(msg, model.keybindings.ports):
+					model.ports = newPorts()
+					model.sessionState = viewPorts
+					return model, model.ports.Init()
 				case key.Matches(msg, model.keybindings.remove):
 					selectedItem := model.list.SelectedItem()
 					if selectedItem != nil {
 						if networkItem, ok := selectedItem.(NetworkItem); ok {
+							if networkItem.Network.IsBuiltin() {
+								model.foreground = shared.NewSmartDialog(
+									fmt.Sprintf("Network %s is built into Docker.\nCannot delete.", networkItem.Network.Name),
+									[]shared.DialogButton{
+										{Label: "OK", IsSafe: true},
+									},
+								)
+								model.sessionState = viewOverlay
+								break
+							}
 							containersUsingNetwork, _ := context.GetClient().GetContainersUsingNetwork(networkItem.Network.ID)
 							if len(containersUsingNetwork) > 0 {
 								warningDialog := shared.NewSmartDialog(
@@ -267,15 +412,9 @@ func (model Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			cmds = append(cmds, listCmd)
 		}
 
-		selectedItem := model.list.SelectedItem()
-		if selectedItem != nil {
-			if networkItem, ok := selectedItem.(NetworkItem); ok {
-				detailsContent := fmt.Sprintf(
-					"ID: %s\nName: %s\nDriver: %s\nScope: %s",
-					networkItem.Network.ID, networkItem.Network.Name, networkItem.Network.Driver, networkItem.Network.Scope,
-				)
-				model.viewport.SetContent(detailsContent)
-			}
+		// The parent drops the commands of resizes.
+		if _, ok := msg.(tea.WindowSizeMsg); !ok {
+			cmds = append(cmds, model.refreshDetails(false))
 		}
 
 		if !isKeyMessage || model.focusedView == focusDetails {
@@ -291,6 +430,175 @@ func (model Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return model, tea.Batch(cmds...)
 }
 
+// networkDetailsMsg carries the details of a network, with the containers attached to it.
+type networkDetailsMsg struct {
+	networkID string
+	details   client.NetworkDetails
+	attached  []string
+	err       error
+}
+
+func (networkDetailsMsg) IsBackground() {}
+
+func loadNetworkDetails(networkID string) tea.Cmd {
+	return func() tea.Msg {
+		details, err := context.GetClient().InspectNetwork(networkID)
+		if err != nil {
+			return networkDetailsMsg{networkID: networkID, err: err}
+		}
+		// Stopped containers stay attached, but only running ones have an endpoint.
+		attached, err := context.GetClient().GetContainersUsingNetwork(networkID)
+		return networkDetailsMsg{networkID: networkID, details: details, attached: attached, err: err}
+	}
+}
+
+// refreshDetails loads the details of the selected network. They are only
+// fetched again when the selection changes, unless forced.
+func (model *Model) refreshDetails(force bool) tea.Cmd {
+	networkItem, ok := model.list.SelectedItem().(NetworkItem)
+	if !ok {
+		model.detailsNetworkID = ""
+		return nil
+	}
+	if networkItem.Network.ID == model.detailsNetworkID {
+		if !force {
+			return nil
+		}
+	} else {
+		model.viewport.SetContent(lipgloss.NewStyle().Foreground(colors.Muted()).Render("Loading..."))
+	}
+
+	model.detailsNetworkID = networkItem.Network.ID
+	return loadNetworkDetails(networkItem.Network.ID)
+}
+
+// showDetails shows the loaded details, unless another network was selected in the meantime.
+func (model *Model) showDetails(msg networkDetailsMsg) {
+	if msg.networkID != model.detailsNetworkID {
+		return
+	}
+	if msg.err != nil {
+		model.viewport.SetContent(lipgloss.NewStyle().Foreground(colors.Error()).Render(msg.err.Error()))
+		return
+	}
+	model.viewport.SetContent(formatNetwork(msg.details, msg.attached))
+}
+
+func formatNetwork(details client.NetworkDetails, attached []string) string {
+	var builder strings.Builder
+
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(colors.Primary())
+	mutedStyle := lipgloss.NewStyle().Foreground(colors.Muted())
+
+	builder.WriteString(headerStyle.Render(details.Name))
+	if details.IsBuiltin() {
+		builder.WriteString(lipgloss.NewStyle().Foreground(colors.Warning()).Render("  built-in, cannot be removed"))
+	}
+	builder.WriteString("\n\n")
+	builder.WriteString(fmt.Sprintf("ID: %s\nDriver: %s\nScope: %s\n", details.ID, details.Driver, details.Scope))
+	if !details.Created.IsZero() {
+		builder.WriteString(fmt.Sprintf("Created: %s (%s ago)\n",
+			details.Created.Local().Format("2006-01-02 15:04:05"),
+			units.HumanDuration(time.Since(details.Created)),
+		))
+	}
+	builder.WriteString(fmt.Sprintf("Internal: %t\nAttachable: %t\nIngress: %t\nIPv6: %t\n",
+		details.Internal, details.Attachable, details.Ingress, details.EnableIPv6,
+	))
+
+	ipamDriver := details.IPAMDriver
+	if ipamDriver == "" {
+		ipamDriver = "default"
+	}
+	builder.WriteString("\n" + headerStyle.Render("IPAM") + mutedStyle.Render(" "+ipamDriver) + "\n")
+	if len(details.IPAM) == 0 {
+		builder.WriteString(mutedStyle.Render("No address pools.") + "\n")
+	}
+	for index, ipamConfig := range details.IPAM {
+		if index > 0 {
+			builder.WriteString("\n")
+		}
+		builder.WriteString(fmt.Sprintf("Subnet: %s\n", ipamConfig.Subnet))
+		if ipamConfig.Gateway != "" {
+			builder.WriteString(fmt.Sprintf("Gateway: %s\n", ipamConfig.Gateway))
+		}
+		if ipamConfig.IPRange != "" {
+			builder.WriteString(fmt.Sprintf("IP range: %s\n", ipamConfig.IPRange))
+		}
+		for _, host := range sortedKeys(ipamConfig.AuxAddresses) {
+			builder.WriteString(fmt.Sprintf("Reserved: %s %s\n", ipamConfig.AuxAddresses[host], mutedStyle.Render(host)))
+		}
+	}
+
+	writeKeyValues(&builder, "Options", "No options.", details.Options)
+	writeKeyValues(&builder, "Labels", "No labels.", details.Labels)
+
+	builder.WriteString("\n" + headerStyle.Render("Containers") + "\n")
+	if len(details.Endpoints) == 0 && len(attached) == 0 {
+		builder.WriteString(mutedStyle.Render("No containers connected.") + "\n")
+	}
+	running := make(map[string]bool, len(details.Endpoints))
+	for _, endpoint := range details.Endpoints {
+		running[endpoint.ContainerName] = true
+
+		addresses := []string{}
+		for _, address := range []string{endpoint.IPv4Address, endpoint.IPv6Address} {
+			if address != "" {
+				addresses = append(addresses, address)
+			}
+		}
+		builder.WriteString(lipgloss.NewStyle().Bold(true).Render(endpoint.ContainerName) + "\n")
+		if len(addresses) > 0 {
+			builder.WriteString("  " + strings.Join(addresses, "  ") + "\n")
+		}
+		if endpoint.MacAddress != "" {
+			builder.WriteString("  " + mutedStyle.Render("MAC "+endpoint.MacAddress) + "\n")
+		}
+	}
+	for _, name := range attached {
+		if !running[name] {
+			builder.WriteString(lipgloss.NewStyle().Bold(true).Render(name) + mutedStyle.Render("  not running") + "\n")
+		}
+	}
+	builder.WriteString("\n" + mutedStyle.Render("c connect • D disconnect (in details focus)"))
+
+	return builder.String()
+}
+
+// writeKeyValues writes a section of sorted key=value pairs, or the placeholder when there are none.
+func writeKeyValues(builder *strings.Builder, title, placeholder string, values map[string]string) {
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(colors.Primary())
+	mutedStyle := lipgloss.NewStyle().Foreground(colors.Muted())
+
+	builder.WriteString("\n" + headerStyle.Render(title) + "\n")
+	if len(values) == 0 {
+		builder.WriteString(mutedStyle.Render(placeholder) + "\n")
+	}
+	for _, valueKey := range sortedKeys(values) {
+		builder.WriteString(fmt.Sprintf("%s=%s\n", valueKey, values[valueKey]))
+	}
+}
+
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for valueKey := range values {
+		keys = append(keys, valueKey)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// insertNetwork adds a newly created network to the end of the list and selects it.
+func (model *Model) insertNetwork(network client.Network) tea.Cmd {
+	model.list.ResetFilter()
+
+	index := len(model.list.Items())
+	cmd := model.list.InsertItem(index, NetworkItem{Network: network})
+	model.list.Select(index)
+
+	return tea.Batch(cmd, model.refreshDetails(true))
+}
+
 func (model *Model) handleToggleSelection() {
 	currentIndex := model.list.Index()
 	selectedItem, ok := model.list.SelectedItem().(NetworkItem)
@@ -350,6 +658,13 @@ func (model Model) View() string {
 		return model.overlayModel.View()
 	}
 
+	switch model.sessionState {
+	case viewTopology:
+		return model.topology.View()
+	case viewPorts:
+		return model.ports.View()
+	}
+
 	layoutManager := shared.NewLayoutManager(model.WindowWidth, model.WindowHeight)
 	_, detailLayout := layoutManager.CalculateMasterDetail(lipgloss.NewStyle())
 
@@ -406,14 +721,54 @@ func (model *Model) UpdateWindowDimensions(msg tea.WindowSizeMsg) {
 			model.list.SetHeight(masterLayout.ContentHeight)
 		}
 	case viewOverlay:
-		if smartDialog, ok := model.foreground.(shared.SmartDialog); ok {
-			smartDialog.UpdateWindowDimensions(msg)
-			model.foreground = smartDialog
+		switch foregroundModel := model.foreground.(type) {
+		case shared.SmartDialog:
+			foregroundModel.UpdateWindowDimensions(msg)
+			model.foreground = foregroundModel
+		case shared.Form:
+			foregroundModel.UpdateWindowDimensions(msg)
+			model.foreground = foregroundModel
+		case shared.Picker:
+			foregroundModel.UpdateWindowDimensions(msg)
+			model.foreground = foregroundModel
 		}
+	case viewTopology:
+		model.topology.UpdateWindowDimensions(msg)
+	case viewPorts:
+		model.ports.UpdateWindowDimensions(msg)
 	}
 }
 
+// IsCapturingInput reports whether keys are being typed into a filter or a form.
+func (model Model) IsCapturingInput() bool {
+	if model.sessionState == viewOverlay {
+		switch foregroundModel := model.foreground.(type) {
+		case shared.Form:
+			return true
+		case shared.Picker:
+			return foregroundModel.IsFiltering()
+		}
+	}
+	return model.list.FilterState() == list.Filtering
+}
+
 func (model Model) ShortHelp() []key.Binding {
+	switch model.sessionState {
+	case viewTopology:
+		return []key.Binding{
+			model.topology.keybindings.scroll,
+			model.topology.keybindings.refresh,
+			model.topology.keybindings.close,
+		}
+	case viewPorts:
+		return []key.Binding{
+			model.ports.keybindings.scroll,
+			model.ports.keybindings.probe,
+			model.ports.keybindings.refresh,
+			model.ports.keybindings.close,
+		}
+	}
+
 	switch model.focusedView {
 	case focusList:
 		return model.list.ShortHelp()
@@ -421,6 +776,8 @@ func (model Model) ShortHelp() []key.Binding {
 		return []key.Binding{
 			model.detailsKeybindings.Up,
 			model.detailsKeybindings.Down,
+			model.detailsKeybindings.Connect,
+			model.detailsKeybindings.Disconnect,
 			model.detailsKeybindings.Switch,
 		}
 	}
@@ -428,6 +785,10 @@ func (model Model) ShortHelp() []key.Binding {
 }
 
 func (model Model) FullHelp() [][]key.Binding {
+	if model.sessionState == viewTopology || model.sessionState == viewPorts {
+		return [][]key.Binding{model.ShortHelp()}
+	}
+
 	switch model.focusedView {
 	case focusList:
 		return model.list.FullHelp()
@@ -436,6 +797,8 @@ func (model Model) FullHelp() [][]key.Binding {
 			{
 				model.detailsKeybindings.Up,
 				model.detailsKeybindings.Down,
+				model.detailsKeybindings.Connect,
+				model.detailsKeybindings.Disconnect,
 				model.detailsKeybindings.Switch,
 			},
 		}
@@ -0,0 +1,337 @@
+package networks
+
+import (
+	"fmt"
+	"net"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/givensuman/containertui/internal/client"
+	"github.com/givensuman/containertui/internal/colors"
+	"github.com/givensuman/containertui/internal/context"
+	"github.com/givensuman/containertui/internal/ui/shared"
+)
+
+// probeTimeout bounds how long a port probe waits for a connection.
+const probeTimeout = time.Second
+
+// portRow is a published port of a running container.
+type portRow struct {
+	container string
+	binding   client.PortBinding
+	conflicts []string // Names of the other containers publishing an overlapping port.
+}
+
+// probeKey identifies the address of a probe.
+func (row portRow) probeKey() string {
+	return row.binding.Protocol + "/" + probeAddress(row.binding)
+}
+
+type portsMsg struct {
+	rows []portRow
+	err  error
+}
+
+// probeResultsMsg maps probe keys to the error of the connection, nil if it succeeded.
+type probeResultsMsg struct {
+	results map[string]error
+}
+
+// closePortsMsg is sent when the user leaves the ports view.
+type closePortsMsg struct{}
+
+type portsKeybindings struct {
+	scroll  key.Binding
+	probe   key.Binding
+	refresh key.Binding
+	close   key.Binding
+}
+
+func newPortsKeybindings() portsKeybindings {
+	return portsKeybindings{
+		scroll: key.NewBinding(
+			key.WithKeys("up", "down"),
+			key.WithHelp("↑/↓", "scroll"),
+		),
+		probe: key.NewBinding(
+			key.WithKeys("p"),
+			key.WithHelp("p", "probe ports"),
+		),
+		refresh: key.NewBinding(
+			key.WithKeys("ctrl+r"),
+			key.WithHelp("ctrl+r", "refresh"),
+		),
+		close: key.NewBinding(
+			key.WithKeys("esc", "q"),
+			key.WithHelp("esc", "close ports"),
+		),
+	}
+}
+
+// Ports lists the host ports published by running containers.
+type Ports struct {
+	shared.Component
+	style       lipgloss.Style
+	viewport    viewport.Model
+	keybindings portsKeybindings
+	rows        []portRow
+	probes      map[string]error // Results of the last probe, by probe key.
+	isProbing   bool
+	status      string
+}
+
+func newPorts() Ports {
+	width, height := context.GetWindowSize()
+
+	ports := Ports{
+		style:       lipgloss.NewStyle().PaddingTop(1).PaddingLeft(2),
+		viewport:    viewport.New(0, 0),
+		keybindings: newPortsKeybindings(),
+		status:      "Loading...",
+	}
+	ports.UpdateWindowDimensions(tea.WindowSizeMsg{Width: width, Height: height})
+
+	return ports
+}
+
+func loadPorts() tea.Msg {
+	containers, err := context.GetClient().GetContainerEndpoints()
+	if err != nil {
+		return portsMsg{err: err}
+	}
+	return portsMsg{rows: newPortRows(containers)}
+}
+
+// newPortRows collects the published ports of running containers, sorted by
+// host port, and marks overlapping bindings of different containers.
+func newPortRows(containers []client.ContainerEndpoints) []portRow {
+	var rows []portRow
+	for _, container := range containers {
+		if container.State != "running" {
+			continue
+		}
+		for _, binding := range container.Ports {
+			if binding.HostPort != 0 {
+				rows = append(rows, portRow{container: container.Name, binding: binding})
+			}
+		}
+	}
+
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].binding.HostPort != rows[j].binding.HostPort {
+			return rows[i].binding.HostPort < rows[j].binding.HostPort
+		}
+		if rows[i].binding.Protocol != rows[j].binding.Protocol {
+			return rows[i].binding.Protocol < rows[j].binding.Protocol
+		}
+		if rows[i].binding.HostIP != rows[j].binding.HostIP {
+			return rows[i].binding.HostIP < rows[j].binding.HostIP
+		}
+		return rows[i].container < rows[j].container
+	})
+
+	for i := range rows {
+		for j := range rows {
+			if rows[i].container != rows[j].container && rows[i].binding.Overlaps(rows[j].binding) &&
+				!slices.Contains(rows[i].conflicts, rows[j].container) {
+				rows[i].conflicts = append(rows[i].conflicts, rows[j].container)
+			}
+		}
+	}
+
+	return rows
+}
+
+// probeAddress is the local address to connect to for a binding, wildcard
+// addresses are probed on the loopback interface.
+func probeAddress(binding client.PortBinding) string {
+	host := binding.HostIP
+	switch host {
+	case "", "0.0.0.0":
+		host = "127.0.0.1"
+	case "::":
+		host = "::1"
+	}
+	return net.JoinHostPort(host, strconv.Itoa(int(binding.HostPort)))
+}
+
+// probePorts attempts a TCP connection to each distinct address concurrently.
+func probePorts(rows []portRow) tea.Cmd {
+	return func() tea.Msg {
+		var (
+			mutex   sync.Mutex
+			group   sync.WaitGroup
+			results = make(map[string]error)
+		)
+
+		// Addresses are deduplicated before probing, as the results are written concurrently.
+		addresses := make(map[string]string)
+		for _, row := range rows {
+			if row.binding.Protocol == "tcp" {
+				addresses[row.probeKey()] = probeAddress(row.binding)
+			}
+		}
+
+		for probeKey, address := range addresses {
+			group.Add(1)
+			go func(probeKey, address string) {
+				defer group.Done()
+				connection, err := net.DialTimeout("tcp", address, probeTimeout)
+				if err == nil {
+					connection.Close()
+				}
+				mutex.Lock()
+				results[probeKey] = err
+				mutex.Unlock()
+			}(probeKey, address)
+		}
+
+		group.Wait()
+		return probeResultsMsg{results: results}
+	}
+}
+
+func (ports Ports) Init() tea.Cmd {
+	return loadPorts
+}
+
+func (ports Ports) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		ports.UpdateWindowDimensions(msg)
+		return ports, nil
+
+	case portsMsg:
+		if msg.err != nil {
+			ports.status = msg.err.Error()
+			return ports, nil
+		}
+		ports.status = ""
+		ports.rows = msg.rows
+		ports.probes = nil
+		ports.viewport.SetContent(ports.render())
+		return ports, nil
+
+	case probeResultsMsg:
+		ports.isProbing = false
+		ports.probes = msg.results
+		ports.viewport.SetContent(ports.render())
+		return ports, nil
+
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, ports.keybindings.close):
+			return ports, func() tea.Msg { return closePortsMsg{} }
+		case key.Matches(msg, ports.keybindings.refresh):
+			return ports, loadPorts
+		case key.Matches(msg, ports.keybindings.probe):
+			if ports.isProbing || len(ports.rows) == 0 {
+				return ports, nil
+			}
+			ports.isProbing = true
+			ports.viewport.SetContent(ports.render())
+			return ports, probePorts(ports.rows)
+		}
+	}
+
+	var cmd tea.Cmd
+	ports.viewport, cmd = ports.viewport.Update(msg)
+	return ports, cmd
+}
+
+func (ports *Ports) UpdateWindowDimensions(msg tea.WindowSizeMsg) {
+	ports.WindowWidth = msg.Width
+	ports.WindowHeight = msg.Height
+
+	ports.style = ports.style.Width(msg.Width).Height(msg.Height)
+	ports.viewport.Width = shared.Max(msg.Width-ports.style.GetHorizontalFrameSize(), 0)
+	ports.viewport.Height = shared.Max(msg.Height-ports.style.GetVerticalFrameSize()-2, 0) // Leave room for the header.
+}
+
+func (ports Ports) View() string {
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(colors.Primary())
+	mutedStyle := lipgloss.NewStyle().Foreground(colors.Muted())
+
+	header := headerStyle.Render("Published ports")
+	if ports.status == "" {
+		conflicts := 0
+		for _, row := range ports.rows {
+			if len(row.conflicts) > 0 {
+				conflicts++
+			}
+		}
+		summary := fmt.Sprintf("  %d bindings", len(ports.rows))
+		if conflicts > 0 {
+			summary += fmt.Sprintf(" • %d conflicting", conflicts)
+		}
+		header += mutedStyle.Render(summary)
+	}
+
+	content := ports.viewport.View()
+	if ports.status != "" {
+		content = mutedStyle.Render(ports.status)
+	}
+
+	return ports.style.Render(lipgloss.JoinVertical(lipgloss.Left, header, "", content))
+}
+
+// render formats the bindings as a table, with the result of the last probe.
+func (ports Ports) render() string {
+	mutedStyle := lipgloss.NewStyle().Foreground(colors.Muted())
+	columnStyle := lipgloss.NewStyle().Bold(true)
+	successStyle := lipgloss.NewStyle().Foreground(colors.Success())
+	errorStyle := lipgloss.NewStyle().Foreground(colors.Error())
+
+	if len(ports.rows) == 0 {
+		return mutedStyle.Render("No running container publishes a port.")
+	}
+
+	hostWidth, targetWidth := len("HOST"), len("CONTAINER")
+	hosts := make([]string, len(ports.rows))
+	targets := make([]string, len(ports.rows))
+	for index, row := range ports.rows {
+		hosts[index] = net.JoinHostPort(row.binding.HostIP, strconv.Itoa(int(row.binding.HostPort)))
+		targets[index] = fmt.Sprintf("%s:%d/%s", row.container, row.binding.ContainerPort, row.binding.Protocol)
+		hostWidth = shared.Max(hostWidth, lipgloss.Width(hosts[index]))
+		targetWidth = shared.Max(targetWidth, lipgloss.Width(targets[index]))
+	}
+
+	var builder strings.Builder
+	builder.WriteString(columnStyle.Render(fmt.Sprintf("%-*s    %-*s  %s", hostWidth, "HOST", targetWidth, "CONTAINER", "STATUS")) + "\n")
+
+	for index, row := range ports.rows {
+		var status []string
+		if len(row.conflicts) > 0 {
+			status = append(status, errorStyle.Render("conflicts with "+strings.Join(row.conflicts, ", ")))
+		}
+		switch {
+		case ports.isProbing:
+			status = append(status, mutedStyle.Render("probing..."))
+		case ports.probes == nil:
+		case row.binding.Protocol != "tcp":
+			status = append(status, mutedStyle.Render("not probed, "+row.binding.Protocol))
+		default:
+			if err := ports.probes[row.probeKey()]; err != nil {
+				status = append(status, errorStyle.Render("unreachable"))
+			} else {
+				status = append(status, successStyle.Render("reachable"))
+			}
+		}
+
+		builder.WriteString(fmt.Sprintf("%-*s  → %-*s  %s\n", hostWidth, hosts[index], targetWidth, targets[index], strings.Join(status, mutedStyle.Render(" • "))))
+	}
+
+	if ports.probes == nil && !ports.isProbing {
+		builder.WriteString("\n" + mutedStyle.Render("Press p to probe each TCP port with a local connection."))
+	}
+
+	return builder.String()
+}
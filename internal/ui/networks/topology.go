@@ -0,0 +1,376 @@
+package networks
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/givensuman/containertui/internal/client"
+	"github.com/givensuman/containertui/internal/colors"
+	"github.com/givensuman/containertui/internal/context"
+	"github.com/givensuman/containertui/internal/ui/shared"
+)
+
+// maxLaneLabelWidth bounds the width of the network names heading the lanes.
+const maxLaneLabelWidth = 14
+
+type topologyMsg struct {
+	networks   []client.Network
+	containers []client.ContainerEndpoints
+	err        error
+}
+
+// closeTopologyMsg is sent when the user leaves the topology view.
+type closeTopologyMsg struct{}
+
+type topologyKeybindings struct {
+	scroll  key.Binding
+	refresh key.Binding
+	close   key.Binding
+}
+
+func newTopologyKeybindings() topologyKeybindings {
+	return topologyKeybindings{
+		scroll: key.NewBinding(
+			key.WithKeys("up", "down", "left", "right"),
+			key.WithHelp("←↓↑→", "scroll"),
+		),
+		refresh: key.NewBinding(
+			key.WithKeys("ctrl+r"),
+			key.WithHelp("ctrl+r", "refresh"),
+		),
+		close: key.NewBinding(
+			key.WithKeys("esc", "q"),
+			key.WithHelp("esc", "close topology"),
+		),
+	}
+}
+
+// Topology draws the networks as vertical lanes, and each container as a row
+// joining the lanes of the networks it is attached to.
+type Topology struct {
+	shared.Component
+	style       lipgloss.Style
+	viewport    viewport.Model
+	keybindings topologyKeybindings
+	networks    []client.Network
+	containers  []client.ContainerEndpoints
+	status      string
+}
+
+func newTopology() Topology {
+	width, height := context.GetWindowSize()
+
+	topology := Topology{
+		style:       lipgloss.NewStyle().PaddingTop(1).PaddingLeft(2),
+		viewport:    viewport.New(0, 0),
+		keybindings: newTopologyKeybindings(),
+		status:      "Loading...",
+	}
+	topology.viewport.SetHorizontalStep(4)
+	topology.UpdateWindowDimensions(tea.WindowSizeMsg{Width: width, Height: height})
+
+	return topology
+}
+
+func loadTopology() tea.Msg {
+	networks, err := context.GetClient().GetNetworks()
+	if err != nil {
+		return topologyMsg{err: err}
+	}
+	containers, err := context.GetClient().GetContainerEndpoints()
+	return topologyMsg{networks: networks, containers: containers, err: err}
+}
+
+func (topology Topology) Init() tea.Cmd {
+	return loadTopology
+}
+
+func (topology Topology) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		topology.UpdateWindowDimensions(msg)
+		return topology, nil
+
+	case topologyMsg:
+		if msg.err != nil {
+			topology.status = msg.err.Error()
+			return topology, nil
+		}
+		topology.status = ""
+		topology.networks = msg.networks
+		topology.containers = msg.containers
+		topology.viewport.SetContent(renderTopology(topology.networks, topology.containers))
+		return topology, nil
+
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, topology.keybindings.close):
+			return topology, func() tea.Msg { return closeTopologyMsg{} }
+		case key.Matches(msg, topology.keybindings.refresh):
+			return topology, loadTopology
+		}
+	}
+
+	var cmd tea.Cmd
+	topology.viewport, cmd = topology.viewport.Update(msg)
+	return topology, cmd
+}
+
+func (topology *Topology) UpdateWindowDimensions(msg tea.WindowSizeMsg) {
+	topology.WindowWidth = msg.Width
+	topology.WindowHeight = msg.Height
+
+	topology.style = topology.style.Width(msg.Width).Height(msg.Height)
+	topology.viewport.Width = shared.Max(msg.Width-topology.style.GetHorizontalFrameSize(), 0)
+	topology.viewport.Height = shared.Max(msg.Height-topology.style.GetVerticalFrameSize()-2, 0) // Leave room for the header.
+}
+
+func (topology Topology) View() string {
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(colors.Primary())
+	mutedStyle := lipgloss.NewStyle().Foreground(colors.Muted())
+
+	header := headerStyle.Render("Network topology")
+	if topology.status == "" {
+		header += mutedStyle.Render(fmt.Sprintf("  %d networks • %d containers", len(topology.networks), len(topology.containers)))
+	}
+
+	content := topology.viewport.View()
+	if topology.status != "" {
+		content = mutedStyle.Render(topology.status)
+	}
+
+	return topology.style.Render(lipgloss.JoinVertical(lipgloss.Left, header, "", content))
+}
+
+// lane is the column of a network in the topology.
+type lane struct {
+	network client.Network
+	label   string
+	start   int // Column of the label.
+	center  int // Column of the vertical line.
+}
+
+// topologyRow accumulates the cells of one line of the graph.
+type topologyRow struct {
+	cells []string
+}
+
+func newTopologyRow(width int, lanes []lane, laneStyle lipgloss.Style) topologyRow {
+	row := topologyRow{cells: make([]string, width)}
+	for column := range row.cells {
+		row.cells[column] = " "
+	}
+	for _, lane := range lanes {
+		row.cells[lane.center] = laneStyle.Render("│")
+	}
+	return row
+}
+
+func (row topologyRow) String() string {
+	return strings.Join(row.cells, "")
+}
+
+// renderTopology draws the networks as lanes, with a row per container marking
+// the networks it is attached to. A container attached to several networks
+// bridges their lanes with a horizontal line.
+func renderTopology(networks []client.Network, containers []client.ContainerEndpoints) string {
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(colors.Primary())
+	mutedStyle := lipgloss.NewStyle().Foreground(colors.Muted())
+	laneStyle := lipgloss.NewStyle().Foreground(colors.Border())
+	linkStyle := lipgloss.NewStyle().Foreground(colors.Primary())
+
+	if len(networks) == 0 {
+		return mutedStyle.Render("No networks.")
+	}
+
+	networks = append([]client.Network(nil), networks...)
+	sort.Slice(networks, func(i, j int) bool {
+		return networks[i].Name < networks[j].Name
+	})
+
+	var builder strings.Builder
+
+	// The legend describes each network, the graph below only has room for names.
+	nameWidth := 0
+	for _, network := range networks {
+		nameWidth = shared.Max(nameWidth, lipgloss.Width(network.Name))
+	}
+	for _, network := range networks {
+		description := network.Driver
+		if len(network.Subnets) > 0 {
+			description += " • " + strings.Join(network.Subnets, ", ")
+		}
+		if network.IsBuiltin() {
+			description += " • built-in"
+		}
+		builder.WriteString(fmt.Sprintf("%-*s  %s\n", nameWidth, network.Name, mutedStyle.Render(description)))
+	}
+	builder.WriteString("\n")
+
+	lanes := make([]lane, 0, len(networks))
+	laneIndex := make(map[string]int, len(networks)*2)
+	column := 0
+	for index, network := range networks {
+		label := truncate(network.Name, maxLaneLabelWidth)
+		width := lipgloss.Width(label)
+		lanes = append(lanes, lane{
+			network: network,
+			label:   label,
+			start:   column,
+			center:  column + (width-1)/2,
+		})
+		laneIndex[network.ID] = index
+		laneIndex[network.Name] = index
+		column += width + 2
+	}
+	graphWidth := column
+
+	var labels strings.Builder
+	for _, lane := range lanes {
+		labels.WriteString(headerStyle.Render(lane.label) + "  ")
+	}
+	builder.WriteString(labels.String() + "\n")
+	builder.WriteString(newTopologyRow(graphWidth, lanes, laneStyle).String() + "\n")
+
+	var detached []client.ContainerEndpoints
+	for _, container := range containers {
+		attached := make(map[int]client.Endpoint, len(container.Endpoints))
+		low, high := len(lanes), -1
+		for _, endpoint := range container.Endpoints {
+			index, ok := laneIndex[endpoint.NetworkID]
+			if !ok {
+				index, ok = laneIndex[endpoint.NetworkName]
+			}
+			if !ok {
+				continue
+			}
+			attached[index] = endpoint
+			low = min(low, index)
+			high = max(high, index)
+		}
+		if len(attached) == 0 {
+			detached = append(detached, container)
+			continue
+		}
+
+		row := newTopologyRow(graphWidth, lanes, laneStyle)
+		for column := lanes[low].center; column <= lanes[high].center; column++ {
+			row.cells[column] = linkStyle.Render("─")
+		}
+		for index, lane := range lanes {
+			if _, ok := attached[index]; ok {
+				row.cells[lane.center] = linkStyle.Render("●")
+			} else if index > low && index < high {
+				row.cells[lane.center] = linkStyle.Render("┼")
+			}
+		}
+		builder.WriteString(row.String() + " " + formatTopologyContainer(container.Container) + "\n")
+
+		for _, endpoint := range container.Endpoints {
+			if _, ok := laneIndex[endpoint.NetworkID]; !ok {
+				if _, ok := laneIndex[endpoint.NetworkName]; !ok {
+					continue
+				}
+			}
+			builder.WriteString(newTopologyRow(graphWidth, lanes, laneStyle).String() + "   " + formatTopologyEndpoint(container.Container, endpoint, nameWidth) + "\n")
+		}
+		if ports := formatTopologyPorts(container.Ports); ports != "" {
+			builder.WriteString(newTopologyRow(graphWidth, lanes, laneStyle).String() + "   " + fmt.Sprintf("%-*s  %s", nameWidth, "ports", ports) + "\n")
+		}
+		builder.WriteString(newTopologyRow(graphWidth, lanes, laneStyle).String() + "\n")
+	}
+
+	if len(detached) > 0 {
+		builder.WriteString("\n" + headerStyle.Render("Not attached to a network") + "\n")
+		for _, container := range detached {
+			builder.WriteString(formatTopologyContainer(container.Container) + "\n")
+		}
+	}
+
+	return builder.String()
+}
+
+func formatTopologyContainer(container client.Container) string {
+	color := colors.Muted()
+	switch container.State {
+	case "running":
+		color = colors.Success()
+	case "paused", "restarting":
+		color = colors.Warning()
+	}
+
+	return lipgloss.NewStyle().Bold(true).Render(container.Name) +
+		lipgloss.NewStyle().Foreground(color).Render(" "+container.State)
+}
+
+func formatTopologyEndpoint(container client.Container, endpoint client.Endpoint, nameWidth int) string {
+	mutedStyle := lipgloss.NewStyle().Foreground(colors.Muted())
+
+	addresses := []string{}
+	if endpoint.IPv4Address != "" {
+		addresses = append(addresses, endpoint.IPv4Address)
+	}
+	if endpoint.IPv6Address != "" {
+		addresses = append(addresses, endpoint.IPv6Address)
+	}
+	address := strings.Join(addresses, ", ")
+	if address == "" {
+		address = mutedStyle.Render("no address")
+	}
+
+	line := fmt.Sprintf("%-*s  %s", nameWidth, endpoint.NetworkName, address)
+
+	// The daemon adds the container's name and short ID as aliases, they add nothing here.
+	var aliases []string
+	for _, alias := range endpoint.Aliases {
+		if alias != container.Name && !client.IsShortIDAlias(container.ID, alias) {
+			aliases = append(aliases, alias)
+		}
+	}
+	if len(aliases) > 0 {
+		line += mutedStyle.Render("  aliases " + strings.Join(aliases, ", "))
+	}
+
+	return line
+}
+
+// formatTopologyPorts lists the ports of a container, omitting the wildcard
+// host addresses, so that a port published on both IPv4 and IPv6 shows once.
+func formatTopologyPorts(ports []client.PortBinding) string {
+	seen := make(map[string]bool, len(ports))
+	var formatted []string
+	for _, port := range ports {
+		if port.HostIP == "0.0.0.0" || port.HostIP == "::" {
+			port.HostIP = ""
+		}
+		text := port.String()
+		if port.HostPort != 0 && port.HostIP == "" {
+			text = strings.TrimPrefix(text, ":")
+		}
+		if seen[text] {
+			continue
+		}
+		seen[text] = true
+		formatted = append(formatted, text)
+	}
+	sort.Strings(formatted)
+
+	return strings.Join(formatted, ", ")
+}
+
+// truncate shortens text to width cells, marking the cut with an ellipsis.
+func truncate(text string, width int) string {
+	if lipgloss.Width(text) <= width {
+		return text
+	}
+	runes := []rune(text)
+	for len(runes) > 0 && lipgloss.Width(string(runes))+1 > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "…"
+}
@@ -14,6 +14,7 @@ const (
 	Info Level = iota
 	Error
 	Success
+	Warning
 )
 
 type Notification struct {
@@ -149,6 +150,14 @@ var (
 			MarginBottom(1).
 			Border(lipgloss.RoundedBorder()).
 			BorderForeground(lipgloss.Color("#56E095"))
+
+	warningStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FFF")).
+			Background(lipgloss.Color("#E0A856")). // Amber
+			Padding(0, 2).
+			MarginBottom(1).
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("#E0A856"))
 )
 
 func (m Model) View() string {
@@ -169,6 +178,8 @@ func (m Model) View() string {
 			style = errorStyle
 		case Success:
 			style = successStyle
+		case Warning:
+			style = warningStyle
 		}
 
 		content = lipgloss.JoinVertical(lipgloss.Left, content, style.Render(n.Message))
@@ -0,0 +1,222 @@
+package shared
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/givensuman/containertui/internal/colors"
+	"github.com/givensuman/containertui/internal/context"
+)
+
+// FormField defines a labelled text input in a Form.
+type FormField struct {
+	Key         string // Key of the field's value in FormSubmitMessage.Values.
+	Label       string
+	Placeholder string
+	Value       string
+	Hint        string // Rendered next to the label, e.g. the current value.
+}
+
+// Form is a modal of labelled text inputs. It sends a FormSubmitMessage when
+// submitted, and stays open until the parent closes it, so that errors can be
+// shown inline through SetError.
+type Form struct {
+	Component
+	style        lipgloss.Style
+	title        string
+	fields       []FormField
+	inputs       []textinput.Model
+	focusedInput int
+	action       SmartDialogAction
+	err          string
+}
+
+var (
+	_ tea.Model      = (*Form)(nil)
+	_ ComponentModel = (*Form)(nil)
+)
+
+// NewForm creates a form which sends the given action along with its values when submitted.
+func NewForm(title string, fields []FormField, action SmartDialogAction) Form {
+	width, height := context.GetWindowSize()
+
+	style := lipgloss.NewStyle().
+		Padding(1, 2).
+		Border(lipgloss.RoundedBorder(), true, true).
+		BorderForeground(colors.Primary())
+
+	inputs := make([]textinput.Model, 0, len(fields))
+	for _, field := range fields {
+		input := textinput.New()
+		input.Placeholder = field.Placeholder
+		input.SetValue(field.Value)
+		input.Prompt = "> "
+		input.PromptStyle = lipgloss.NewStyle().Foreground(colors.Muted())
+		input.Cursor.Style = lipgloss.NewStyle().Foreground(colors.Primary())
+		inputs = append(inputs, input)
+	}
+
+	form := Form{
+		style:  style,
+		title:  title,
+		fields: fields,
+		inputs: inputs,
+		action: action,
+	}
+	form.UpdateWindowDimensions(tea.WindowSizeMsg{Width: width, Height: height})
+	form.focusInput(0)
+
+	return form
+}
+
+func (form *Form) UpdateWindowDimensions(msg tea.WindowSizeMsg) {
+	form.WindowWidth = msg.Width
+	form.WindowHeight = msg.Height
+
+	layoutManager := NewLayoutManager(msg.Width, msg.Height)
+	dimensions := layoutManager.Calculate(RatioForm, form.style)
+
+	form.style = form.style.Width(dimensions.Width)
+	for index := range form.inputs {
+		form.inputs[index].Width = Max(dimensions.ContentWidth-4, 0)
+	}
+}
+
+// SetError shows an error message above the form's inputs.
+func (form *Form) SetError(err error) {
+	if err == nil {
+		form.err = ""
+		return
+	}
+	form.err = err.Error()
+}
+
+// SetFormError shows the error in the foreground when it is a form, keeping it
+// open so that the input can be corrected, and returns the updated foreground.
+func SetFormError(foreground tea.Model, err error) tea.Model {
+	if form, ok := foreground.(Form); ok {
+		form.SetError(err)
+		return form
+	}
+	return foreground
+}
+
+// Action returns the action the form sends when submitted.
+func (form Form) Action() SmartDialogAction {
+	return form.action
+}
+
+// Value returns the current value of the field with the given key.
+func (form Form) Value(key string) string {
+	for index, field := range form.fields {
+		if field.Key == key {
+			return form.inputs[index].Value()
+		}
+	}
+	return ""
+}
+
+func (form *Form) focusInput(index int) {
+	if len(form.inputs) == 0 {
+		return
+	}
+
+	form.inputs[form.focusedInput].Blur()
+	form.focusedInput = (index + len(form.inputs)) % len(form.inputs)
+	form.inputs[form.focusedInput].Focus()
+}
+
+func (form Form) submit() tea.Cmd {
+	values := make(map[string]string, len(form.fields))
+	for index, field := range form.fields {
+		values[field.Key] = strings.TrimSpace(form.inputs[index].Value())
+	}
+
+	action := form.action
+	return func() tea.Msg {
+		return FormSubmitMessage{Action: action, Values: values}
+	}
+}
+
+func (form Form) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (form Form) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		form.UpdateWindowDimensions(msg)
+		return form, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc":
+			return form, func() tea.Msg { return CloseDialogMessage{} }
+
+		case "tab", "down":
+			form.focusInput(form.focusedInput + 1)
+			return form, nil
+
+		case "shift+tab", "up":
+			form.focusInput(form.focusedInput - 1)
+			return form, nil
+
+		case "ctrl+s":
+			return form, form.submit()
+
+		case "enter":
+			if form.focusedInput == len(form.inputs)-1 {
+				return form, form.submit()
+			}
+			form.focusInput(form.focusedInput + 1)
+			return form, nil
+		}
+	}
+
+	if len(form.inputs) == 0 {
+		return form, nil
+	}
+
+	var cmd tea.Cmd
+	form.inputs[form.focusedInput], cmd = form.inputs[form.focusedInput].Update(msg)
+	return form, cmd
+}
+
+func (form Form) View() string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(colors.Primary())
+	labelStyle := lipgloss.NewStyle().Foreground(colors.Text())
+	focusedLabelStyle := lipgloss.NewStyle().Foreground(colors.Primary()).Bold(true)
+	hintStyle := lipgloss.NewStyle().Foreground(colors.Muted())
+	errorStyle := lipgloss.NewStyle().Foreground(colors.Error())
+
+	rows := []string{titleStyle.Render(form.title), ""}
+
+	if form.err != "" {
+		rows = append(rows, errorStyle.Render(form.err), "")
+	}
+
+	for index, field := range form.fields {
+		style := labelStyle
+		if index == form.focusedInput {
+			style = focusedLabelStyle
+		}
+
+		label := style.Render(field.Label)
+		if field.Hint != "" {
+			label += " " + hintStyle.Render("("+field.Hint+")")
+		}
+
+		rows = append(rows, label, form.inputs[index].View())
+	}
+
+	rows = append(rows, "", hintStyle.Render("tab next • shift+tab previous • ctrl+s submit • esc cancel"))
+
+	renderStyle := form.style
+	if form.err != "" {
+		renderStyle = renderStyle.BorderForeground(colors.Error())
+	}
+
+	return renderStyle.Render(lipgloss.JoinVertical(lipgloss.Left, rows...))
+}
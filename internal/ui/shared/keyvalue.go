@@ -0,0 +1,21 @@
+package shared
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseKeyValuePairs parses whitespace separated key=value pairs, e.g.
+// "type=nfs o=addr=10.0.0.1,rw". Values may contain '=' and ','.
+func ParseKeyValuePairs(input string) (map[string]string, error) {
+	pairs := make(map[string]string)
+	for _, field := range strings.Fields(input) {
+		key, value, found := strings.Cut(field, "=")
+		if !found || key == "" {
+			return nil, fmt.Errorf("invalid pair %q, expected key=value", field)
+		}
+		pairs[key] = value
+	}
+
+	return pairs, nil
+}
@@ -0,0 +1,39 @@
+package shared
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseKeyValuePairs(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected map[string]string
+		wantErr  bool
+	}{
+		{"empty", "", map[string]string{}, false},
+		{"single pair", "type=tmpfs", map[string]string{"type": "tmpfs"}, false},
+		{
+			"nfs options",
+			"type=nfs  o=addr=10.0.0.1,rw device=:/exports",
+			map[string]string{"type": "nfs", "o": "addr=10.0.0.1,rw", "device": ":/exports"},
+			false,
+		},
+		{"empty value", "com.example.flag=", map[string]string{"com.example.flag": ""}, false},
+		{"missing separator", "type", nil, true},
+		{"missing key", "=value", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseKeyValuePairs(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseKeyValuePairs(%q) error = %v; wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("ParseKeyValuePairs(%q) = %v; want %v", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
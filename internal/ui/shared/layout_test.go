@@ -173,4 +173,8 @@ func TestRatioConstants(t *testing.T) {
 	if RatioLargeOverlay.width != 0.8 || RatioLargeOverlay.height != 0.8 {
 		t.Errorf("RatioLargeOverlay = (%f, %f); want (0.8, 0.8)", RatioLargeOverlay.width, RatioLargeOverlay.height)
 	}
+
+	if RatioForm.width != 0.6 || RatioForm.height != 0.8 {
+		t.Errorf("RatioForm = (%f, %f); want (0.6, 0.8)", RatioForm.width, RatioForm.height)
+	}
 }
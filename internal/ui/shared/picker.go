@@ -0,0 +1,144 @@
+package shared
+
+import (
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/givensuman/containertui/internal/colors"
+	"github.com/givensuman/containertui/internal/context"
+)
+
+// PickerItem is an option of a Picker.
+type PickerItem struct {
+	Label  string
+	Detail string
+	Value  any // Sent as the Payload of the Picker's action.
+}
+
+var _ list.DefaultItem = (*PickerItem)(nil)
+
+func (item PickerItem) Title() string       { return item.Label }
+func (item PickerItem) Description() string { return item.Detail }
+func (item PickerItem) FilterValue() string { return item.Label }
+
+// Picker is a modal list to choose one item from. Choosing an item sends a
+// ConfirmationMessage with the action, whose Payload is the Value of the item.
+type Picker struct {
+	Component
+	style  lipgloss.Style
+	title  string
+	list   list.Model
+	action SmartDialogAction
+}
+
+var (
+	_ tea.Model      = (*Picker)(nil)
+	_ ComponentModel = (*Picker)(nil)
+)
+
+// NewPicker creates a picker which sends the given action with the chosen item's Value.
+func NewPicker(title string, items []PickerItem, action SmartDialogAction) Picker {
+	width, height := context.GetWindowSize()
+
+	style := lipgloss.NewStyle().
+		Padding(1, 2).
+		Border(lipgloss.RoundedBorder(), true, true).
+		BorderForeground(colors.Primary())
+
+	listItems := make([]list.Item, 0, len(items))
+	for _, item := range items {
+		listItems = append(listItems, item)
+	}
+
+	listModel := list.New(listItems, ChangeDelegateStyles(list.NewDefaultDelegate()), 0, 0)
+	listModel.SetShowHelp(false)
+	listModel.SetShowTitle(false)
+	listModel.SetShowStatusBar(false)
+	listModel.SetFilteringEnabled(true)
+	listModel.KeyMap.Quit.SetEnabled(false)
+	listModel.Styles.FilterPrompt = lipgloss.NewStyle().Foreground(colors.Primary())
+	listModel.Styles.FilterCursor = lipgloss.NewStyle().Foreground(colors.Primary())
+	listModel.FilterInput.PromptStyle = lipgloss.NewStyle().Foreground(colors.Primary())
+	listModel.FilterInput.Cursor.Style = lipgloss.NewStyle().Foreground(colors.Primary())
+
+	picker := Picker{
+		style:  style,
+		title:  title,
+		list:   listModel,
+		action: action,
+	}
+	picker.UpdateWindowDimensions(tea.WindowSizeMsg{Width: width, Height: height})
+
+	return picker
+}
+
+func (picker *Picker) UpdateWindowDimensions(msg tea.WindowSizeMsg) {
+	picker.WindowWidth = msg.Width
+	picker.WindowHeight = msg.Height
+
+	layoutManager := NewLayoutManager(msg.Width, msg.Height)
+	dimensions := layoutManager.Calculate(RatioForm, picker.style)
+
+	picker.style = picker.style.Width(dimensions.Width)
+	picker.list.SetSize(Max(dimensions.ContentWidth, 0), Max(dimensions.ContentHeight-4, 0)) // Leave room for the title and footer.
+}
+
+// IsFiltering reports whether a filter is being typed.
+func (picker Picker) IsFiltering() bool {
+	return picker.list.FilterState() == list.Filtering
+}
+
+func (picker Picker) Init() tea.Cmd {
+	return nil
+}
+
+func (picker Picker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		picker.UpdateWindowDimensions(msg)
+		return picker, nil
+
+	case tea.KeyMsg:
+		if picker.list.FilterState() == list.Filtering {
+			break
+		}
+
+		switch msg.String() {
+		case "esc":
+			if picker.list.FilterState() == list.FilterApplied {
+				break
+			}
+			return picker, func() tea.Msg { return CloseDialogMessage{} }
+
+		case "enter":
+			item, ok := picker.list.SelectedItem().(PickerItem)
+			if !ok {
+				return picker, nil
+			}
+			action := picker.action
+			action.Payload = item.Value
+			return picker, func() tea.Msg { return ConfirmationMessage{Action: action} }
+		}
+	}
+
+	var cmd tea.Cmd
+	picker.list, cmd = picker.list.Update(msg)
+	return picker, cmd
+}
+
+func (picker Picker) View() string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(colors.Primary())
+	hintStyle := lipgloss.NewStyle().Foreground(colors.Muted())
+
+	content := picker.list.View()
+	if len(picker.list.Items()) == 0 {
+		content = hintStyle.Render("Nothing to choose from.")
+	}
+
+	return picker.style.Render(lipgloss.JoinVertical(lipgloss.Left,
+		titleStyle.Render(picker.title),
+		"",
+		content,
+		hintStyle.Render("enter choose • / filter • esc cancel"),
+	))
+}
@@ -0,0 +1,21 @@
+package shared
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/givensuman/containertui/internal/colors"
+)
+
+// RenderProgressBar renders a bar of the given width, filled by fraction, which is clamped to [0, 1].
+func RenderProgressBar(width int, fraction float64) string {
+	if width <= 0 {
+		return ""
+	}
+
+	fraction = min(max(fraction, 0), 1)
+	filled := int(fraction * float64(width))
+
+	return lipgloss.NewStyle().Foreground(colors.Primary()).Render(strings.Repeat("█", filled)) +
+		lipgloss.NewStyle().Foreground(colors.Muted()).Render(strings.Repeat("░", width-filled))
+}
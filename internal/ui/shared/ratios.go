@@ -21,4 +21,5 @@ var (
 	RatioFullscreen   = WindowRatio{1.0, 1.0}
 	RatioModal        = WindowRatio{0.4, 0.2}
 	RatioLargeOverlay = WindowRatio{0.8, 0.8}
+	RatioForm         = WindowRatio{0.6, 0.8}
 )
@@ -14,6 +14,19 @@ type ComponentModel interface {
 	UpdateWindowDimensions(msg tea.WindowSizeMsg)
 }
 
+// InputCapturer is implemented by components which can be typing into a text
+// input, while which global keybindings must not be handled.
+type InputCapturer interface {
+	IsCapturingInput() bool
+}
+
+// BackgroundMessage is implemented by messages of work which continues while
+// the tab which started it is inactive, such as polling. They are delivered to
+// every tab, which ignore those of others.
+type BackgroundMessage interface {
+	IsBackground()
+}
+
 // Dialog-related messages
 
 // SmartDialogAction defines the action to take upon confirmation
@@ -29,3 +42,9 @@ type ConfirmationMessage struct {
 
 // CloseDialogMessage is sent when the dialog is cancelled
 type CloseDialogMessage struct{}
+
+// FormSubmitMessage is sent when a form is submitted
+type FormSubmitMessage struct {
+	Action SmartDialogAction
+	Values map[string]string // Trimmed input values, keyed by FormField.Key
+}
@@ -0,0 +1,454 @@
+package system
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/docker/go-units"
+	"github.com/givensuman/containertui/internal/client"
+	"github.com/givensuman/containertui/internal/colors"
+	"github.com/givensuman/containertui/internal/context"
+	"github.com/givensuman/containertui/internal/ui/shared"
+)
+
+// MsgBuildCache contains freshly loaded build cache records.
+type MsgBuildCache struct {
+	Records []client.BuildCacheRecord
+	Err     error
+}
+
+type buildCacheSortOrder int
+
+const (
+	sortBySize buildCacheSortOrder = iota
+	sortByLastUsed
+	sortByType
+)
+
+func (order buildCacheSortOrder) String() string {
+	return [...]string{
+		"size",
+		"last used",
+		"type",
+	}[order]
+}
+
+type buildCacheKeybindings struct {
+	toggleSelection      key.Binding
+	toggleSelectionOfAll key.Binding
+	sort                 key.Binding
+	remove               key.Binding
+	prune                key.Binding
+	refresh              key.Binding
+	back                 key.Binding
+}
+
+func newBuildCacheKeybindings() *buildCacheKeybindings {
+	return &buildCacheKeybindings{
+		toggleSelection: key.NewBinding(
+			key.WithKeys(tea.KeySpace.String()),
+			key.WithHelp("space", "toggle selection"),
+		),
+		toggleSelectionOfAll: key.NewBinding(
+			key.WithKeys(tea.KeyCtrlA.String()),
+			key.WithHelp("ctrl+a", "toggle selection of all"),
+		),
+		sort: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "change sort order"),
+		),
+		remove: key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", "remove selected"),
+		),
+		prune: key.NewBinding(
+			key.WithKeys("p"),
+			key.WithHelp("p", "prune with filters"),
+		),
+		refresh: key.NewBinding(
+			key.WithKeys("ctrl+r"),
+			key.WithHelp("ctrl+r", "refresh"),
+		),
+		back: key.NewBinding(
+			key.WithKeys("esc", "backspace"),
+			key.WithHelp("esc", "back to disk usage"),
+		),
+	}
+}
+
+type BuildCacheItem struct {
+	Record     client.BuildCacheRecord
+	isSelected bool
+}
+
+var (
+	_ list.Item        = (*BuildCacheItem)(nil)
+	_ list.DefaultItem = (*BuildCacheItem)(nil)
+)
+
+func (buildCacheItem BuildCacheItem) getIsSelectedIcon() string {
+	switch context.GetConfig().NoNerdFonts {
+	case true: // Don't use nerd fonts.
+		switch buildCacheItem.isSelected {
+		case true:
+			return "[x]"
+		case false:
+			return "[ ]"
+		}
+	case false: // Use nerd fonts.
+		switch buildCacheItem.isSelected {
+		case true:
+			return " "
+		case false:
+			return " "
+		}
+	}
+
+	return "[ ]"
+}
+
+func (buildCacheItem BuildCacheItem) Title() string {
+	description := buildCacheItem.Record.Description
+	if description == "" {
+		description = shortID(buildCacheItem.Record.ID)
+	}
+
+	titleColor := colors.Muted()
+	if buildCacheItem.Record.InUse {
+		titleColor = colors.Success()
+	}
+	title := lipgloss.NewStyle().
+		Foreground(titleColor).
+		Render(description)
+
+	var isSelectedColor lipgloss.Color
+	switch buildCacheItem.isSelected {
+	case true:
+		isSelectedColor = colors.Selected()
+	case false:
+		isSelectedColor = colors.Text()
+	}
+	statusIcon := lipgloss.NewStyle().
+		Foreground(isSelectedColor).
+		Render(buildCacheItem.getIsSelectedIcon())
+
+	return fmt.Sprintf("%s %s", statusIcon, title)
+}
+
+func (buildCacheItem BuildCacheItem) Description() string {
+	details := []string{
+		units.HumanSize(float64(buildCacheItem.Record.Size)),
+		buildCacheItem.Record.Type,
+		"used " + formatLastUsed(buildCacheItem.Record),
+	}
+	if buildCacheItem.Record.Shared {
+		details = append(details, "shared")
+	}
+	if buildCacheItem.Record.InUse {
+		details = append(details, "in use")
+	}
+
+	return "   " + strings.Join(details, " • ")
+}
+
+func (buildCacheItem BuildCacheItem) FilterValue() string {
+	return buildCacheItem.Record.Description + " " + buildCacheItem.Record.Type
+}
+
+// BuildCacheList is a sortable, multi-selectable list of build cache records.
+type BuildCacheList struct {
+	list        list.Model
+	sortOrder   buildCacheSortOrder
+	selections  map[string]struct{}
+	keybindings *buildCacheKeybindings
+}
+
+func newBuildCacheList(width, height int) BuildCacheList {
+	delegate := newDefaultDelegate()
+	listModel := list.New([]list.Item{}, delegate, width, height)
+	listModel.SetShowHelp(false)
+	listModel.SetShowTitle(false)
+	listModel.SetShowStatusBar(false)
+	listModel.SetFilteringEnabled(true)
+	listModel.Styles.FilterPrompt = lipgloss.NewStyle().Foreground(colors.Primary())
+	listModel.Styles.FilterCursor = lipgloss.NewStyle().Foreground(colors.Primary())
+	listModel.FilterInput.PromptStyle = lipgloss.NewStyle().Foreground(colors.Primary())
+	listModel.FilterInput.Cursor.Style = lipgloss.NewStyle().Foreground(colors.Primary())
+	listModel.KeyMap.Quit.SetKeys("q")
+
+	buildCacheKeybindings := newBuildCacheKeybindings()
+	listModel.AdditionalFullHelpKeys = func() []key.Binding {
+		return []key.Binding{
+			buildCacheKeybindings.toggleSelection,
+			buildCacheKeybindings.toggleSelectionOfAll,
+			buildCacheKeybindings.sort,
+			buildCacheKeybindings.remove,
+			buildCacheKeybindings.prune,
+			buildCacheKeybindings.refresh,
+			buildCacheKeybindings.back,
+		}
+	}
+
+	return BuildCacheList{
+		list:        listModel,
+		sortOrder:   sortBySize,
+		selections:  make(map[string]struct{}),
+		keybindings: buildCacheKeybindings,
+	}
+}
+
+func loadBuildCache() tea.Msg {
+	records, err := context.GetClient().GetBuildCache()
+	return MsgBuildCache{Records: records, Err: err}
+}
+
+// setRecords replaces the records in the list, keeping the selection of records which still exist.
+func (buildCacheList *BuildCacheList) setRecords(records []client.BuildCacheRecord) {
+	sortBuildCacheRecords(records, buildCacheList.sortOrder)
+
+	exists := make(map[string]struct{}, len(records))
+	items := make([]list.Item, 0, len(records))
+	for _, record := range records {
+		_, isSelected := buildCacheList.selections[record.ID]
+		exists[record.ID] = struct{}{}
+		items = append(items, BuildCacheItem{Record: record, isSelected: isSelected})
+	}
+
+	for id := range buildCacheList.selections {
+		if _, ok := exists[id]; !ok {
+			delete(buildCacheList.selections, id)
+		}
+	}
+
+	buildCacheList.list.SetItems(items)
+}
+
+func (buildCacheList *BuildCacheList) records() []client.BuildCacheRecord {
+	items := buildCacheList.list.Items()
+	records := make([]client.BuildCacheRecord, 0, len(items))
+	for _, item := range items {
+		if buildCacheItem, ok := item.(BuildCacheItem); ok {
+			records = append(records, buildCacheItem.Record)
+		}
+	}
+	return records
+}
+
+func (buildCacheList *BuildCacheList) cycleSortOrder() {
+	buildCacheList.sortOrder = (buildCacheList.sortOrder + 1) % 3
+	buildCacheList.setRecords(buildCacheList.records())
+	buildCacheList.list.Select(0)
+}
+
+func (buildCacheList *BuildCacheList) handleToggleSelection() {
+	index := buildCacheList.list.Index()
+	selectedItem, ok := buildCacheList.list.SelectedItem().(BuildCacheItem)
+	if !ok {
+		return
+	}
+
+	if selectedItem.isSelected {
+		delete(buildCacheList.selections, selectedItem.Record.ID)
+	} else {
+		buildCacheList.selections[selectedItem.Record.ID] = struct{}{}
+	}
+
+	selectedItem.isSelected = !selectedItem.isSelected
+	buildCacheList.list.SetItem(index, selectedItem)
+}
+
+func (buildCacheList *BuildCacheList) handleToggleSelectionOfAll() {
+	items := buildCacheList.list.Items()
+	allSelected := len(buildCacheList.selections) == len(items)
+
+	buildCacheList.selections = make(map[string]struct{})
+	for index, item := range items {
+		if buildCacheItem, ok := item.(BuildCacheItem); ok {
+			buildCacheItem.isSelected = !allSelected
+			if buildCacheItem.isSelected {
+				buildCacheList.selections[buildCacheItem.Record.ID] = struct{}{}
+			}
+			buildCacheList.list.SetItem(index, buildCacheItem)
+		}
+	}
+}
+
+// requestedRecords returns the selected records, or the highlighted one if nothing is selected.
+func (buildCacheList *BuildCacheList) requestedRecords() []client.BuildCacheRecord {
+	if len(buildCacheList.selections) == 0 {
+		if buildCacheItem, ok := buildCacheList.list.SelectedItem().(BuildCacheItem); ok {
+			return []client.BuildCacheRecord{buildCacheItem.Record}
+		}
+		return nil
+	}
+
+	var requested []client.BuildCacheRecord
+	for _, record := range buildCacheList.records() {
+		if _, ok := buildCacheList.selections[record.ID]; ok {
+			requested = append(requested, record)
+		}
+	}
+	return requested
+}
+
+func sortBuildCacheRecords(records []client.BuildCacheRecord, order buildCacheSortOrder) {
+	sort.SliceStable(records, func(i, j int) bool {
+		switch order {
+		case sortByLastUsed:
+			// Least recently used first, as those are the best candidates for pruning.
+			return records[i].LastUsedAt.Before(records[j].LastUsedAt)
+		case sortByType:
+			if records[i].Type != records[j].Type {
+				return records[i].Type < records[j].Type
+			}
+		}
+		return records[i].Size > records[j].Size
+	})
+}
+
+// newRemoveRecordsDialog builds a confirmation dialog listing the records which will be removed.
+func newRemoveRecordsDialog(records []client.BuildCacheRecord) shared.SmartDialog {
+	var removable []client.BuildCacheRecord
+	var size int64
+	for _, record := range records {
+		if !record.InUse {
+			removable = append(removable, record)
+			size += record.Size
+		}
+	}
+
+	if len(removable) == 0 {
+		return shared.NewSmartDialog(
+			"The selected build cache records are in use and cannot be removed.",
+			[]shared.DialogButton{
+				{Label: "OK", IsSafe: true},
+			},
+		)
+	}
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("Remove %d build cache records (%s)?\n\n", len(removable), units.HumanSize(float64(size))))
+
+	ids := make([]string, 0, len(removable))
+	for index, record := range removable {
+		ids = append(ids, record.ID)
+		if index < maxPruneListItems {
+			builder.WriteString(fmt.Sprintf("%s (%s)\n", recordName(record), units.HumanSize(float64(record.Size))))
+		} else if index == maxPruneListItems {
+			builder.WriteString(fmt.Sprintf("...and %d more\n", len(removable)-maxPruneListItems))
+		}
+	}
+
+	return shared.NewSmartDialog(
+		strings.TrimSuffix(builder.String(), "\n"),
+		[]shared.DialogButton{
+			{Label: "Cancel", IsSafe: true},
+			{Label: "Remove", IsSafe: false, Action: shared.SmartDialogAction{Type: "PruneBuildCache", Payload: client.BuildCachePruneOptions{IDs: ids}}},
+		},
+	)
+}
+
+func newPruneBuildCacheForm() shared.Form {
+	return shared.NewForm(
+		"Prune build cache",
+		[]shared.FormField{
+			{Key: "unusedFor", Label: "Unused for at least", Placeholder: "e.g. 24h, 7d (empty for any age)"},
+			{Key: "keepStorage", Label: "Keep storage", Placeholder: "e.g. 10GB (empty to keep nothing)"},
+		},
+		shared.SmartDialogAction{Type: "PruneBuildCacheWithFilters"},
+	)
+}
+
+// parsePruneOptions validates the values of the prune form.
+func parsePruneOptions(values map[string]string) (client.BuildCachePruneOptions, error) {
+	var options client.BuildCachePruneOptions
+
+	if value := values["unusedFor"]; value != "" {
+		unusedFor, err := parseAge(value)
+		if err != nil {
+			return options, err
+		}
+		options.UnusedFor = unusedFor
+	}
+
+	if value := values["keepStorage"]; value != "" {
+		keepStorage, err := units.RAMInBytes(value)
+		if err != nil {
+			return options, fmt.Errorf("invalid storage size %q", value)
+		}
+		options.KeepStorage = keepStorage
+	}
+
+	return options, nil
+}
+
+// parseAge parses a duration, additionally accepting days ("7d") and weeks ("2w").
+func parseAge(value string) (time.Duration, error) {
+	multipliers := map[string]time.Duration{
+		"d": 24 * time.Hour,
+		"w": 7 * 24 * time.Hour,
+	}
+
+	for suffix, multiplier := range multipliers {
+		if amount, ok := strings.CutSuffix(value, suffix); ok {
+			count, err := strconv.Atoi(amount)
+			if err != nil || count < 0 {
+				return 0, fmt.Errorf("invalid age %q", value)
+			}
+			return time.Duration(count) * multiplier, nil
+		}
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < 0 {
+		return 0, fmt.Errorf("invalid age %q", value)
+	}
+	return duration, nil
+}
+
+func formatLastUsed(record client.BuildCacheRecord) string {
+	if record.LastUsedAt.IsZero() {
+		return "never"
+	}
+	return units.HumanDuration(time.Since(record.LastUsedAt)) + " ago"
+}
+
+func recordName(record client.BuildCacheRecord) string {
+	if record.Description != "" {
+		return record.Description
+	}
+	return shortID(record.ID)
+}
+
+func shortID(id string) string {
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
+}
+
+// formatBuildCacheRecord renders the details of a build cache record.
+func formatBuildCacheRecord(record client.BuildCacheRecord) string {
+	var builder strings.Builder
+
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(colors.Primary()).MarginBottom(1)
+	builder.WriteString(headerStyle.Render(recordName(record)) + "\n")
+
+	builder.WriteString(fmt.Sprintf("ID: %s\n", record.ID))
+	builder.WriteString(fmt.Sprintf("Type: %s\n", record.Type))
+	builder.WriteString(fmt.Sprintf("Size: %s\n", units.HumanSize(float64(record.Size))))
+	builder.WriteString(fmt.Sprintf("Created: %s ago\n", units.HumanDuration(time.Since(record.CreatedAt))))
+	builder.WriteString(fmt.Sprintf("Last used: %s\n", formatLastUsed(record)))
+	builder.WriteString(fmt.Sprintf("Usage count: %d\n", record.UsageCount))
+	builder.WriteString(fmt.Sprintf("Shared: %t\n", record.Shared))
+	builder.WriteString(fmt.Sprintf("In use: %t\n", record.InUse))
+
+	return builder.String()
+}
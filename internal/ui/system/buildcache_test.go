@@ -0,0 +1,71 @@
+package system
+
+import (
+	"testing"
+	"time"
+
+	"github.com/givensuman/containertui/internal/client"
+)
+
+func TestParseAge(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected time.Duration
+	}{
+		{"0", 0},
+		{"90m", 90 * time.Minute},
+		{"36h", 36 * time.Hour},
+		{"1h30m", 90 * time.Minute},
+		{"7d", 7 * 24 * time.Hour},
+		{"0d", 0},
+		{"2w", 14 * 24 * time.Hour},
+	}
+	for _, tt := range tests {
+		if age, err := parseAge(tt.value); err != nil || age != tt.expected {
+			t.Errorf("parseAge(%q) = %v, %v; want %v", tt.value, age, err, tt.expected)
+		}
+	}
+
+	invalid := []string{"", "week", "d", "1.5d", "-3d", "-1h", "3 days", "2y"}
+	for _, value := range invalid {
+		if _, err := parseAge(value); err == nil {
+			t.Errorf("parseAge(%q) expected error, got nil", value)
+		}
+	}
+}
+
+func TestParsePruneOptions(t *testing.T) {
+	tests := []struct {
+		values   map[string]string
+		expected client.BuildCachePruneOptions
+	}{
+		{map[string]string{}, client.BuildCachePruneOptions{}},
+		{map[string]string{"unusedFor": "", "keepStorage": ""}, client.BuildCachePruneOptions{}},
+		{map[string]string{"unusedFor": "7d"}, client.BuildCachePruneOptions{UnusedFor: 7 * 24 * time.Hour}},
+		{map[string]string{"keepStorage": "10GB"}, client.BuildCachePruneOptions{KeepStorage: 10 << 30}},
+		{map[string]string{"unusedFor": "12h", "keepStorage": "512m"}, client.BuildCachePruneOptions{UnusedFor: 12 * time.Hour, KeepStorage: 512 << 20}},
+	}
+	for _, tt := range tests {
+		options, err := parsePruneOptions(tt.values)
+		if err != nil {
+			t.Errorf("parsePruneOptions(%v) returned error: %v", tt.values, err)
+			continue
+		}
+		if options.UnusedFor != tt.expected.UnusedFor || options.KeepStorage != tt.expected.KeepStorage || len(options.IDs) != 0 {
+			t.Errorf("parsePruneOptions(%v) = %+v; want %+v", tt.values, options, tt.expected)
+		}
+	}
+
+	invalid := []map[string]string{
+		{"unusedFor": "soon"},
+		{"unusedFor": "-1d"},
+		{"keepStorage": "lots"},
+		{"keepStorage": "10XB"},
+		{"unusedFor": "1d", "keepStorage": "-5GB"},
+	}
+	for _, values := range invalid {
+		if _, err := parsePruneOptions(values); err == nil {
+			t.Errorf("parsePruneOptions(%v) expected error, got nil", values)
+		}
+	}
+}
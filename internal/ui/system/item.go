@@ -0,0 +1,130 @@
+package system
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/docker/go-units"
+	"github.com/givensuman/containertui/internal/client"
+	"github.com/givensuman/containertui/internal/colors"
+	"github.com/givensuman/containertui/internal/context"
+	"github.com/givensuman/containertui/internal/ui/shared"
+)
+
+type category int
+
+const (
+	categoryImages category = iota
+	categoryContainers
+	categoryVolumes
+	categoryBuildCache
+)
+
+func (c category) String() string {
+	return [...]string{
+		"Images",
+		"Containers",
+		"Local Volumes",
+		"Build Cache",
+	}[c]
+}
+
+// itemNoun returns the lowercase plural noun for the objects in the category.
+func (c category) itemNoun() string {
+	return [...]string{
+		"images",
+		"stopped containers",
+		"unused volumes",
+		"unused build cache records",
+	}[c]
+}
+
+// usage returns the part of the disk usage report which belongs to the category.
+func (c category) usage(diskUsage client.DiskUsage) client.DiskUsageCategory {
+	switch c {
+	case categoryImages:
+		return diskUsage.Images
+	case categoryContainers:
+		return diskUsage.Containers
+	case categoryVolumes:
+		return diskUsage.Volumes
+	case categoryBuildCache:
+		return diskUsage.BuildCache
+	}
+
+	return client.DiskUsageCategory{}
+}
+
+type CategoryItem struct {
+	category category
+	Usage    client.DiskUsageCategory
+}
+
+var (
+	_ list.Item        = (*CategoryItem)(nil)
+	_ list.DefaultItem = (*CategoryItem)(nil)
+)
+
+func newDefaultDelegate() list.DefaultDelegate {
+	delegate := list.NewDefaultDelegate()
+	delegate = shared.ChangeDelegateStyles(delegate)
+
+	return delegate
+}
+
+func (categoryItem CategoryItem) getTitleOrnament() string {
+	switch context.GetConfig().NoNerdFonts {
+	case true: // Don't use nerd fonts.
+		return ""
+	case false: // Use nerd fonts.
+		switch categoryItem.category {
+		case categoryImages:
+			return " "
+		case categoryContainers:
+			return " "
+		case categoryVolumes:
+			return " "
+		case categoryBuildCache:
+			return " "
+		}
+	}
+
+	return ""
+}
+
+func (categoryItem CategoryItem) Title() string {
+	titleOrnament := categoryItem.getTitleOrnament()
+
+	title := fmt.Sprintf("%s %s", titleOrnament, categoryItem.category)
+	title = lipgloss.NewStyle().
+		Foreground(colors.Text()).
+		Render(title)
+
+	activeCount := lipgloss.NewStyle().
+		Foreground(colors.Muted()).
+		Render(fmt.Sprintf("(%d/%d active)", categoryItem.Usage.Active, categoryItem.Usage.Total))
+
+	return fmt.Sprintf("%s %s", title, activeCount)
+}
+
+func (categoryItem CategoryItem) Description() string {
+	return fmt.Sprintf("   %s, %s reclaimable",
+		units.HumanSize(float64(categoryItem.Usage.Size)),
+		formatReclaimable(categoryItem.Usage),
+	)
+}
+
+func (categoryItem CategoryItem) FilterValue() string {
+	return categoryItem.category.String()
+}
+
+// formatReclaimable renders the reclaimable size along with its share of the total, like `docker system df`.
+func formatReclaimable(usage client.DiskUsageCategory) string {
+	reclaimable := units.HumanSize(float64(usage.Reclaimable))
+	if usage.Size > 0 {
+		reclaimable += fmt.Sprintf(" (%d%%)", usage.Reclaimable*100/usage.Size)
+	}
+
+	return reclaimable
+}
@@ -0,0 +1,656 @@
+// Package system defines the system component, a disk usage dashboard.
+package system
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/docker/go-units"
+	"github.com/givensuman/containertui/internal/client"
+	"github.com/givensuman/containertui/internal/colors"
+	"github.com/givensuman/containertui/internal/context"
+	"github.com/givensuman/containertui/internal/ui/notifications"
+	"github.com/givensuman/containertui/internal/ui/shared"
+	overlay "github.com/rmhubbert/bubbletea-overlay"
+)
+
+// maxLargestItems is the number of items shown in the drill-down of a category.
+const maxLargestItems = 25
+
+// maxPruneListItems is the number of items listed in the prune confirmation dialog.
+const maxPruneListItems = 8
+
+// MsgDiskUsage contains a freshly loaded disk usage report.
+type MsgDiskUsage struct {
+	Usage client.DiskUsage
+	Err   error
+}
+
+func (MsgDiskUsage) IsBackground() {}
+
+// MsgPruneResult contains the result of pruning a category.
+type MsgPruneResult struct {
+	Category       category
+	SpaceReclaimed uint64
+	Err            error
+}
+
+type detailsKeybindings struct {
+	Up     key.Binding
+	Down   key.Binding
+	Switch key.Binding
+}
+
+func newDetailsKeybindings() detailsKeybindings {
+	return detailsKeybindings{
+		Up: key.NewBinding(
+			key.WithKeys("up", "k"),
+			key.WithHelp("↑/k", "up"),
+		),
+		Down: key.NewBinding(
+			key.WithKeys("down", "j"),
+			key.WithHelp("↓/j", "down"),
+		),
+		Switch: key.NewBinding(
+			key.WithKeys("tab"),
+			key.WithHelp("tab", "switch focus"),
+		),
+	}
+}
+
+type keybindings struct {
+	drillDown key.Binding
+	prune     key.Binding
+	refresh   key.Binding
+	switchTab key.Binding
+}
+
+func newKeybindings() *keybindings {
+	return &keybindings{
+		drillDown: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "show largest items"),
+		),
+		prune: key.NewBinding(
+			key.WithKeys("p"),
+			key.WithHelp("p", "prune"),
+		),
+		refresh: key.NewBinding(
+			key.WithKeys("ctrl+r"),
+			key.WithHelp("ctrl+r", "refresh"),
+		),
+		switchTab: key.NewBinding(
+			key.WithKeys("1", "2", "3", "4", "5", "6", "7", "tab", "shift+tab"),
+			key.WithHelp("1-7/tab", "switch tab"),
+		),
+	}
+}
+
+type sessionState int
+
+const (
+	viewMain sessionState = iota
+	viewOverlay
+)
+
+const (
+	focusList = iota
+	focusDetails
+)
+
+type subview int
+
+const (
+	subviewDiskUsage subview = iota
+	subviewBuildCache
+)
+
+// Model represents the system component state.
+type Model struct {
+	shared.Component
+	style       lipgloss.Style
+	list        list.Model
+	viewport    viewport.Model
+	keybindings *keybindings
+
+	err       error
+	isLoading bool // Until the first disk usage report arrives.
+
+	subview    subview
+	buildCache BuildCacheList
+
+	sessionState       sessionState
+	focusedView        int
+	detailsKeybindings detailsKeybindings
+	foreground         tea.Model
+	overlayModel       *overlay.Model
+}
+
+var (
+	_ tea.Model             = (*Model)(nil)
+	_ shared.ComponentModel = (*Model)(nil)
+)
+
+// New returns the model without disk usage, which the daemon can take long to
+// measure, so it is loaded by Init.
+func New() Model {
+	width, height := context.GetWindowSize()
+	style := lipgloss.NewStyle().
+		Width(width).
+		Height(height).
+		PaddingTop(1)
+
+	delegate := newDefaultDelegate()
+	listModel := list.New(newCategoryItems(client.DiskUsage{}), delegate, width, height)
+	listModel.SetShowHelp(false)
+	listModel.SetShowTitle(false)
+	listModel.SetShowStatusBar(false)
+	listModel.SetFilteringEnabled(false)
+
+	systemKeybindings := newKeybindings()
+	listModel.AdditionalFullHelpKeys = func() []key.Binding {
+		return []key.Binding{
+			systemKeybindings.drillDown,
+			systemKeybindings.prune,
+			systemKeybindings.refresh,
+			systemKeybindings.switchTab,
+		}
+	}
+
+	detailViewport := viewport.New(0, 0)
+
+	model := Model{
+		style:              style,
+		list:               listModel,
+		viewport:           detailViewport,
+		keybindings:        systemKeybindings,
+		isLoading:          true,
+		subview:            subviewDiskUsage,
+		buildCache:         newBuildCacheList(width, height),
+		sessionState:       viewMain,
+		focusedView:        focusList,
+		detailsKeybindings: newDetailsKeybindings(),
+	}
+
+	model.overlayModel = overlay.New(nil, model.list, overlay.Center, overlay.Center, 0, 0)
+	return model
+}
+
+func newCategoryItems(diskUsage client.DiskUsage) []list.Item {
+	categories := []category{categoryImages, categoryContainers, categoryVolumes, categoryBuildCache}
+
+	items := make([]list.Item, 0, len(categories))
+	for _, c := range categories {
+		items = append(items, CategoryItem{category: c, Usage: c.usage(diskUsage)})
+	}
+
+	return items
+}
+
+func loadDiskUsage() tea.Msg {
+	diskUsage, err := context.GetClient().DiskUsage()
+	return MsgDiskUsage{Usage: diskUsage, Err: err}
+}
+
+func pruneBuildCache(options client.BuildCachePruneOptions) tea.Cmd {
+	return func() tea.Msg {
+		spaceReclaimed, err := context.GetClient().PruneBuildCache(options)
+		return MsgPruneResult{Category: categoryBuildCache, SpaceReclaimed: spaceReclaimed, Err: err}
+	}
+}
+
+func prune(c category) tea.Cmd {
+	return func() tea.Msg {
+		var spaceReclaimed uint64
+		var err error
+		switch c {
+		case categoryImages:
+			spaceReclaimed, err = context.GetClient().PruneUnusedImages()
+		case categoryContainers:
+			spaceReclaimed, err = context.GetClient().PruneContainers()
+		case categoryVolumes:
+			spaceReclaimed, err = context.GetClient().PruneUnusedVolumes()
+		case categoryBuildCache:
+			spaceReclaimed, err = context.GetClient().PruneBuildCache(client.BuildCachePruneOptions{})
+		}
+		return MsgPruneResult{Category: c, SpaceReclaimed: spaceReclaimed, Err: err}
+	}
+}
+
+func (model Model) Init() tea.Cmd {
+	return loadDiskUsage
+}
+
+func (model Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmds []tea.Cmd
+
+	switch msg := msg.(type) {
+	case MsgDiskUsage:
+		model.err = msg.Err
+		model.isLoading = false
+		if msg.Err == nil {
+			model.list.SetItems(newCategoryItems(msg.Usage))
+		} else {
+			cmds = append(cmds, notifications.ShowError(msg.Err))
+		}
+
+	case MsgPruneResult:
+		if msg.Err != nil {
+			cmds = append(cmds, notifications.ShowError(msg.Err))
+		} else {
+			cmds = append(cmds, notifications.ShowSuccess(fmt.Sprintf(
+				"Pruned %s, reclaimed %s",
+				msg.Category.itemNoun(),
+				units.HumanSize(float64(msg.SpaceReclaimed)),
+			)))
+		}
+		cmds = append(cmds, loadDiskUsage)
+		if model.subview == subviewBuildCache {
+			cmds = append(cmds, loadBuildCache)
+		}
+
+	case MsgBuildCache:
+		if msg.Err != nil {
+			cmds = append(cmds, notifications.ShowError(msg.Err))
+		} else {
+			model.buildCache.setRecords(msg.Records)
+		}
+	}
+
+	switch model.sessionState {
+	case viewOverlay:
+		foregroundModel, foregroundCmd := model.foreground.Update(msg)
+		model.foreground = foregroundModel
+		cmds = append(cmds, foregroundCmd)
+
+		if _, ok := msg.(shared.CloseDialogMessage); ok {
+			model.sessionState = viewMain
+			model.foreground = nil
+		} else if confirmMsg, ok := msg.(shared.ConfirmationMessage); ok {
+			switch confirmMsg.Action.Type {
+			case "PruneCategory":
+				cmds = append(cmds, prune(confirmMsg.Action.Payload.(category)))
+			case "PruneBuildCache":
+				cmds = append(cmds, pruneBuildCache(confirmMsg.Action.Payload.(client.BuildCachePruneOptions)))
+			}
+			model.sessionState = viewMain
+			model.foreground = nil
+		} else if submitMsg, ok := msg.(shared.FormSubmitMessage); ok {
+			if submitMsg.Action.Type == "PruneBuildCacheWithFilters" {
+				options, err := parsePruneOptions(submitMsg.Values)
+				if err != nil {
+					if form, ok := model.foreground.(shared.Form); ok {
+						form.SetError(err)
+						model.foreground = form
+					}
+					break
+				}
+				cmds = append(cmds, pruneBuildCache(options))
+			}
+			model.sessionState = viewMain
+			model.foreground = nil
+		}
+	case viewMain:
+		if model.subview == subviewBuildCache {
+			cmds = append(cmds, model.updateBuildCacheView(msg)...)
+			break
+		}
+
+		if keyMsg, ok := msg.(tea.KeyMsg); ok {
+			if keyMsg.String() == "tab" {
+				if model.focusedView == focusList {
+					model.focusedView = focusDetails
+				} else {
+					model.focusedView = focusList
+				}
+				return model, nil
+			}
+		}
+
+		isKeyMessage := false
+		if _, ok := msg.(tea.KeyMsg); ok {
+			isKeyMessage = true
+		}
+
+		if !isKeyMessage || model.focusedView == focusList {
+			switch msg := msg.(type) {
+			case tea.WindowSizeMsg:
+				model.UpdateWindowDimensions(msg)
+			case tea.KeyMsg:
+				switch {
+				case key.Matches(msg, model.keybindings.switchTab):
+					return model, nil
+				case key.Matches(msg, model.keybindings.drillDown):
+					if categoryItem, ok := model.list.SelectedItem().(CategoryItem); ok && categoryItem.category == categoryBuildCache {
+						model.subview = subviewBuildCache
+						model.focusedView = focusList
+						return model, loadBuildCache
+					}
+					model.focusedView = focusDetails
+					model.viewport.GotoTop()
+					return model, nil
+				case key.Matches(msg, model.keybindings.refresh):
+					cmds = append(cmds, loadDiskUsage)
+				case key.Matches(msg, model.keybindings.prune):
+					if categoryItem, ok := model.list.SelectedItem().(CategoryItem); ok {
+						model.foreground = newPruneDialog(categoryItem)
+						model.sessionState = viewOverlay
+					}
+				}
+			}
+			updatedList, listCmd := model.list.Update(msg)
+			model.list = updatedList
+			cmds = append(cmds, listCmd)
+		}
+
+		if categoryItem, ok := model.list.SelectedItem().(CategoryItem); ok {
+			model.viewport.SetContent(formatCategory(categoryItem, model.viewport.Width))
+		}
+
+		if !isKeyMessage || model.focusedView == focusDetails {
+			updatedViewport, viewportCmd := model.viewport.Update(msg)
+			model.viewport = updatedViewport
+			cmds = append(cmds, viewportCmd)
+		}
+	}
+
+	model.overlayModel.Foreground = model.foreground
+	model.overlayModel.Background = model.activeList()
+
+	return model, tea.Batch(cmds...)
+}
+
+func (model *Model) updateBuildCacheView(msg tea.Msg) []tea.Cmd {
+	var cmds []tea.Cmd
+
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && keyMsg.String() == "tab" {
+		if model.focusedView == focusList {
+			model.focusedView = focusDetails
+		} else {
+			model.focusedView = focusList
+		}
+		return nil
+	}
+
+	isKeyMessage := false
+	if _, ok := msg.(tea.KeyMsg); ok {
+		isKeyMessage = true
+	}
+
+	buildCacheList := &model.buildCache
+	if !isKeyMessage || model.focusedView == focusList {
+		switch msg := msg.(type) {
+		case tea.WindowSizeMsg:
+			model.UpdateWindowDimensions(msg)
+		case tea.KeyMsg:
+			if buildCacheList.list.FilterState() == list.Filtering {
+				break
+			}
+
+			switch {
+			case key.Matches(msg, model.keybindings.switchTab):
+				return nil
+			case key.Matches(msg, buildCacheList.keybindings.back):
+				if buildCacheList.list.FilterState() == list.FilterApplied {
+					break
+				}
+				model.subview = subviewDiskUsage
+				if categoryItem, ok := model.list.SelectedItem().(CategoryItem); ok {
+					model.viewport.SetContent(formatCategory(categoryItem, model.viewport.Width))
+				}
+				return nil
+			case key.Matches(msg, buildCacheList.keybindings.sort):
+				buildCacheList.cycleSortOrder()
+				cmds = append(cmds, notifications.ShowInfo("Sorting build cache by "+buildCacheList.sortOrder.String()))
+			case key.Matches(msg, buildCacheList.keybindings.toggleSelection):
+				buildCacheList.handleToggleSelection()
+			case key.Matches(msg, buildCacheList.keybindings.toggleSelectionOfAll):
+				buildCacheList.handleToggleSelectionOfAll()
+			case key.Matches(msg, buildCacheList.keybindings.refresh):
+				cmds = append(cmds, loadBuildCache, loadDiskUsage)
+			case key.Matches(msg, buildCacheList.keybindings.remove):
+				if requested := buildCacheList.requestedRecords(); len(requested) > 0 {
+					model.foreground = newRemoveRecordsDialog(requested)
+					model.sessionState = viewOverlay
+				}
+			case key.Matches(msg, buildCacheList.keybindings.prune):
+				model.foreground = newPruneBuildCacheForm()
+				model.sessionState = viewOverlay
+				cmds = append(cmds, model.foreground.Init())
+			}
+		}
+
+		updatedList, listCmd := buildCacheList.list.Update(msg)
+		buildCacheList.list = updatedList
+		cmds = append(cmds, listCmd)
+	}
+
+	if buildCacheItem, ok := buildCacheList.list.SelectedItem().(BuildCacheItem); ok {
+		model.viewport.SetContent(formatBuildCacheRecord(buildCacheItem.Record))
+	} else {
+		model.viewport.SetContent(lipgloss.NewStyle().Foreground(colors.Muted()).Render("No build cache records."))
+	}
+
+	if !isKeyMessage || model.focusedView == focusDetails {
+		updatedViewport, viewportCmd := model.viewport.Update(msg)
+		model.viewport = updatedViewport
+		cmds = append(cmds, viewportCmd)
+	}
+
+	return cmds
+}
+
+// activeList returns the list of the current subview.
+func (model Model) activeList() list.Model {
+	if model.subview == subviewBuildCache {
+		return model.buildCache.list
+	}
+	return model.list
+}
+
+// newPruneDialog builds a confirmation dialog listing what pruning the category will remove.
+func newPruneDialog(categoryItem CategoryItem) shared.SmartDialog {
+	var prunable []client.DiskUsageItem
+	for _, item := range categoryItem.Usage.Items {
+		if !item.Active {
+			prunable = append(prunable, item)
+		}
+	}
+
+	if len(prunable) == 0 {
+		return shared.NewSmartDialog(
+			fmt.Sprintf("There are no %s to prune.", categoryItem.category.itemNoun()),
+			[]shared.DialogButton{
+				{Label: "OK", IsSafe: true},
+			},
+		)
+	}
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("Prune %d %s, reclaiming up to %s?\n\n",
+		len(prunable),
+		categoryItem.category.itemNoun(),
+		units.HumanSize(float64(categoryItem.Usage.Reclaimable)),
+	))
+	for index, item := range prunable {
+		if index == maxPruneListItems {
+			builder.WriteString(fmt.Sprintf("...and %d more\n", len(prunable)-maxPruneListItems))
+			break
+		}
+		builder.WriteString(fmt.Sprintf("%s (%s)\n", item.Name, units.HumanSize(float64(item.Size))))
+	}
+
+	return shared.NewSmartDialog(
+		strings.TrimSuffix(builder.String(), "\n"),
+		[]shared.DialogButton{
+			{Label: "Cancel", IsSafe: true},
+			{Label: "Prune", IsSafe: false, Action: shared.SmartDialogAction{Type: "PruneCategory", Payload: categoryItem.category}},
+		},
+	)
+}
+
+// formatCategory renders the summary and the largest items of a category.
+func formatCategory(categoryItem CategoryItem, viewportWidth int) string {
+	var builder strings.Builder
+
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(colors.Primary()).MarginBottom(1)
+	builder.WriteString(headerStyle.Render(categoryItem.category.String()) + "\n")
+
+	builder.WriteString(fmt.Sprintf("Active: %d / %d\n", categoryItem.Usage.Active, categoryItem.Usage.Total))
+	builder.WriteString(fmt.Sprintf("Size: %s\n", units.HumanSize(float64(categoryItem.Usage.Size))))
+	builder.WriteString(fmt.Sprintf("Reclaimable: %s\n", formatReclaimable(categoryItem.Usage)))
+
+	sectionHeader := lipgloss.NewStyle().Bold(true).Foreground(colors.Primary()).Underline(true).MarginTop(1).MarginBottom(0)
+	builder.WriteString("\n" + sectionHeader.Render("Largest Items") + "\n")
+
+	if len(categoryItem.Usage.Items) == 0 {
+		builder.WriteString(lipgloss.NewStyle().Foreground(colors.Muted()).Render("Nothing here."))
+		return builder.String()
+	}
+
+	activeStyle := lipgloss.NewStyle().Foreground(colors.Success())
+	inactiveStyle := lipgloss.NewStyle().Foreground(colors.Muted())
+
+	nameWidth := viewportWidth - 12
+	for index, item := range categoryItem.Usage.Items {
+		if index == maxLargestItems {
+			builder.WriteString(inactiveStyle.Render(fmt.Sprintf("...and %d more", len(categoryItem.Usage.Items)-maxLargestItems)) + "\n")
+			break
+		}
+
+		name := item.Name
+		if nameWidth > 0 && len(name) > nameWidth {
+			name = name[:shared.Max(nameWidth-1, 0)] + "…"
+		}
+
+		style := inactiveStyle
+		if item.Active {
+			style = activeStyle
+		}
+		builder.WriteString(fmt.Sprintf("%10s %s\n", units.HumanSize(float64(item.Size)), style.Render(name)))
+	}
+
+	return builder.String()
+}
+
+func (model Model) View() string {
+	if model.sessionState == viewOverlay && model.foreground != nil {
+		return model.overlayModel.View()
+	}
+
+	layoutManager := shared.NewLayoutManager(model.WindowWidth, model.WindowHeight)
+	_, detailLayout := layoutManager.CalculateMasterDetail(lipgloss.NewStyle())
+
+	listView := model.style.Render(model.activeList().View())
+
+	borderColor := colors.Muted()
+	if model.focusedView == focusDetails {
+		borderColor = colors.Primary()
+	}
+
+	detailStyle := lipgloss.NewStyle().
+		Width(detailLayout.Width - 2).
+		Height(detailLayout.Height).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(borderColor).
+		Padding(1)
+
+	var detailContent string
+	switch {
+	case model.err != nil:
+		detailContent = lipgloss.NewStyle().Foreground(colors.Error()).Render("Failed to load disk usage: " + model.err.Error())
+	case model.isLoading && model.subview == subviewDiskUsage:
+		detailContent = lipgloss.NewStyle().Foreground(colors.Muted()).Render("Loading disk usage...")
+	case model.activeList().SelectedItem() != nil, model.subview == subviewBuildCache:
+		detailContent = model.viewport.View()
+	default:
+		detailContent = lipgloss.NewStyle().Foreground(colors.Muted()).Render("No category selected.")
+	}
+
+	detailView := detailStyle.Render(detailContent)
+
+	return lipgloss.JoinHorizontal(lipgloss.Top, listView, detailView)
+}
+
+func (model *Model) UpdateWindowDimensions(msg tea.WindowSizeMsg) {
+	model.WindowWidth = msg.Width
+	model.WindowHeight = msg.Height
+
+	layoutManager := shared.NewLayoutManager(msg.Width, msg.Height)
+	masterLayout, detailLayout := layoutManager.CalculateMasterDetail(model.style)
+
+	model.style = model.style.Width(masterLayout.Width).Height(masterLayout.Height)
+
+	viewportWidth := detailLayout.Width - 4
+	viewportHeight := detailLayout.Height - 2
+	if viewportWidth < 0 {
+		viewportWidth = 0
+	}
+	if viewportHeight < 0 {
+		viewportHeight = 0
+	}
+	model.viewport.Width = viewportWidth
+	model.viewport.Height = viewportHeight
+
+	switch model.sessionState {
+	case viewMain:
+		if model.list.Width() != masterLayout.ContentWidth || model.list.Height() != masterLayout.ContentHeight {
+			model.list.SetWidth(masterLayout.ContentWidth)
+			model.list.SetHeight(masterLayout.ContentHeight)
+		}
+		model.buildCache.list.SetWidth(masterLayout.ContentWidth)
+		model.buildCache.list.SetHeight(masterLayout.ContentHeight)
+	case viewOverlay:
+		switch foregroundModel := model.foreground.(type) {
+		case shared.SmartDialog:
+			foregroundModel.UpdateWindowDimensions(msg)
+			model.foreground = foregroundModel
+		case shared.Form:
+			foregroundModel.UpdateWindowDimensions(msg)
+			model.foreground = foregroundModel
+		}
+	}
+}
+
+// IsCapturingInput reports whether keys are being typed into a filter or a form.
+func (model Model) IsCapturingInput() bool {
+	if _, ok := model.foreground.(shared.Form); ok && model.sessionState == viewOverlay {
+		return true
+	}
+	return model.activeList().FilterState() == list.Filtering
+}
+
+func (model Model) ShortHelp() []key.Binding {
+	switch model.focusedView {
+	case focusList:
+		return model.activeList().ShortHelp()
+	case focusDetails:
+		return []key.Binding{
+			model.detailsKeybindings.Up,
+			model.detailsKeybindings.Down,
+			model.detailsKeybindings.Switch,
+		}
+	}
+	return nil
+}
+
+func (model Model) FullHelp() [][]key.Binding {
+	switch model.focusedView {
+	case focusList:
+		return model.activeList().FullHelp()
+	case focusDetails:
+		return [][]key.Binding{
+			{
+				model.detailsKeybindings.Up,
+				model.detailsKeybindings.Down,
+				model.detailsKeybindings.Switch,
+			},
+		}
+	}
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/givensuman/containertui/internal/colors"
+	"github.com/givensuman/containertui/internal/context"
 	"github.com/givensuman/containertui/internal/ui/shared"
 )
 
@@ -18,6 +19,9 @@ const (
 	Images
 	Volumes
 	Networks
+	System
+	Compose
+	Events
 )
 
 func (t Tab) String() string {
@@ -26,6 +30,9 @@ func (t Tab) String() string {
 		"Images",
 		"Volumes",
 		"Networks",
+		"System",
+		"Compose",
+		"Events",
 	}[t]
 }
 
@@ -34,6 +41,9 @@ type KeyMap struct {
 	SwitchToImages     key.Binding
 	SwitchToVolumes    key.Binding
 	SwitchToNetworks   key.Binding
+	SwitchToSystem     key.Binding
+	SwitchToCompose    key.Binding
+	SwitchToEvents     key.Binding
 }
 
 func NewKeyMap() KeyMap {
@@ -54,6 +64,18 @@ func NewKeyMap() KeyMap {
 			key.WithKeys("4"),
 			key.WithHelp("4", "networks"),
 		),
+		SwitchToSystem: key.NewBinding(
+			key.WithKeys("5"),
+			key.WithHelp("5", "system"),
+		),
+		SwitchToCompose: key.NewBinding(
+			key.WithKeys("6"),
+			key.WithHelp("6", "compose"),
+		),
+		SwitchToEvents: key.NewBinding(
+			key.WithKeys("7"),
+			key.WithHelp("7", "events"),
+		),
 	}
 }
 
@@ -65,9 +87,14 @@ type Model struct {
 }
 
 func New() Model {
+	activeTab := Containers
+	if context.GetConfig().ComposeFile != "" {
+		activeTab = Compose
+	}
+
 	return Model{
-		ActiveTab: Containers,
-		Tabs:      []Tab{Containers, Images, Volumes, Networks},
+		ActiveTab: activeTab,
+		Tabs:      []Tab{Containers, Images, Volumes, Networks, System, Compose, Events},
 		KeyMap:    NewKeyMap(),
 	}
 }
@@ -88,6 +115,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.ActiveTab = Volumes
 		case key.Matches(msg, m.KeyMap.SwitchToNetworks):
 			m.ActiveTab = Networks
+		case key.Matches(msg, m.KeyMap.SwitchToSystem):
+			m.ActiveTab = System
+		case key.Matches(msg, m.KeyMap.SwitchToCompose):
+			m.ActiveTab = Compose
+		case key.Matches(msg, m.KeyMap.SwitchToEvents):
+			m.ActiveTab = Events
 		}
 	case tea.WindowSizeMsg:
 		m.WindowWidth = msg.Width
@@ -12,10 +12,15 @@ import (
 
 	"github.com/givensuman/containertui/internal/colors"
 	"github.com/givensuman/containertui/internal/context"
+	"github.com/givensuman/containertui/internal/ui/compose"
 	"github.com/givensuman/containertui/internal/ui/containers"
+	"github.com/givensuman/containertui/internal/ui/daemon"
+	"github.com/givensuman/containertui/internal/ui/events"
 	"github.com/givensuman/containertui/internal/ui/images"
 	"github.com/givensuman/containertui/internal/ui/networks"
 	"github.com/givensuman/containertui/internal/ui/notifications"
+	"github.com/givensuman/containertui/internal/ui/shared"
+	"github.com/givensuman/containertui/internal/ui/system"
 	"github.com/givensuman/containertui/internal/ui/tabs"
 	"github.com/givensuman/containertui/internal/ui/volumes"
 )
@@ -29,9 +34,15 @@ type Model struct {
 	imagesModel        images.Model
 	volumesModel       volumes.Model
 	networksModel      networks.Model
+	systemModel        system.Model
+	composeModel       compose.Model
+	eventsModel        events.Model
 	notificationsModel notifications.Model
 	overlayModel       *overlay.Model
 	help               help.Model
+
+	daemonPanel       daemon.Panel
+	isDaemonPanelOpen bool // The panel covers every tab, and receives the keys.
 }
 
 func NewModel() Model {
@@ -42,6 +53,9 @@ func NewModel() Model {
 	imagesModel := images.New()
 	volumesModel := volumes.New()
 	networksModel := networks.New()
+	systemModel := system.New()
+	composeModel := compose.New()
+	eventsModel := events.New()
 	notificationsModel := notifications.New()
 
 	overlayModel := overlay.New(notificationsModel, containersModel, overlay.Right, overlay.Top, 0, 0)
@@ -55,6 +69,9 @@ func NewModel() Model {
 		imagesModel:        imagesModel,
 		volumesModel:       volumesModel,
 		networksModel:      networksModel,
+		systemModel:        systemModel,
+		composeModel:       composeModel,
+		eventsModel:        eventsModel,
 		notificationsModel: notificationsModel,
 		overlayModel:       overlayModel,
 		help:               helpModel,
@@ -62,7 +79,7 @@ func NewModel() Model {
 }
 
 func (model Model) Init() tea.Cmd {
-	return nil
+	return tea.Batch(model.containersModel.Init(), model.volumesModel.Init(), model.networksModel.Init(), model.systemModel.Init(), model.composeModel.Init(), model.eventsModel.Init())
 }
 
 func (model Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -102,14 +119,46 @@ func (model Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		updatedNetworks, _ := model.networksModel.Update(contentMsg)
 		model.networksModel = updatedNetworks.(networks.Model)
 
+		updatedSystem, _ := model.systemModel.Update(contentMsg)
+		model.systemModel = updatedSystem.(system.Model)
+
+		updatedCompose, _ := model.composeModel.Update(contentMsg)
+		model.composeModel = updatedCompose.(compose.Model)
+
+		updatedEvents, _ := model.eventsModel.Update(contentMsg)
+		model.eventsModel = updatedEvents.(events.Model)
+
+		model.daemonPanel.UpdateWindowDimensions(contentMsg)
+
 		model.help.Width = msg.Width
 
+	case daemon.CloseMsg:
+		model.isDaemonPanelOpen = false
+
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "ctrl+c", "ctrl+d":
 			return model, tea.Quit
 		}
 
+		if model.isDaemonPanelOpen {
+			updatedPanel, panelCmd := model.daemonPanel.Update(msg)
+			model.daemonPanel = updatedPanel.(daemon.Panel)
+			model.overlayModel.Background = model.withDaemonPanel(model.activeModel())
+			return model, panelCmd
+		}
+
+		if inputCapturer, ok := model.activeModel().(shared.InputCapturer); ok && inputCapturer.IsCapturingInput() {
+			break
+		}
+
+		if msg.String() == "I" {
+			model.daemonPanel = daemon.New()
+			model.daemonPanel.UpdateWindowDimensions(tea.WindowSizeMsg{Width: model.width, Height: shared.Max(model.height-4, 0)})
+			model.isDaemonPanelOpen = true
+			return model, model.daemonPanel.Init()
+		}
+
 		updatedTabs, tabsCmd := model.tabsModel.Update(msg)
 		model.tabsModel = updatedTabs.(tabs.Model)
 		if tabsCmd != nil {
@@ -159,10 +208,46 @@ func (model Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			cmds = append(cmds, networksCmd)
 			activeView = model.networksModel
 		}
+	case tabs.System:
+		activeView = model.systemModel
+		if _, ok := msg.(tea.WindowSizeMsg); !ok {
+			updatedSystem, systemCmd := model.systemModel.Update(msg)
+			model.systemModel = updatedSystem.(system.Model)
+			cmds = append(cmds, systemCmd)
+			activeView = model.systemModel
+		}
+	case tabs.Compose:
+		activeView = model.composeModel
+		if _, ok := msg.(tea.WindowSizeMsg); !ok {
+			updatedCompose, composeCmd := model.composeModel.Update(msg)
+			model.composeModel = updatedCompose.(compose.Model)
+			cmds = append(cmds, composeCmd)
+			activeView = model.composeModel
+		}
+	case tabs.Events:
+		activeView = model.eventsModel
+		if _, ok := msg.(tea.WindowSizeMsg); !ok {
+			updatedEvents, eventsCmd := model.eventsModel.Update(msg)
+			model.eventsModel = updatedEvents.(events.Model)
+			cmds = append(cmds, eventsCmd)
+			activeView = model.eventsModel
+		}
+	}
+
+	if _, ok := msg.(shared.BackgroundMessage); ok {
+		cmds = append(cmds, model.updateInactiveTabs(msg)...)
+	}
+
+	if model.isDaemonPanelOpen {
+		if _, ok := msg.(tea.WindowSizeMsg); !ok {
+			updatedPanel, panelCmd := model.daemonPanel.Update(msg)
+			model.daemonPanel = updatedPanel.(daemon.Panel)
+			cmds = append(cmds, panelCmd)
+		}
 	}
 
 	model.overlayModel.Foreground = model.notificationsModel
-	model.overlayModel.Background = activeView
+	model.overlayModel.Background = model.withDaemonPanel(activeView)
 
 	updatedOverlay, overlayCmd := model.overlayModel.Update(overlayMsg)
 	if ov, ok := updatedOverlay.(*overlay.Model); ok {
@@ -173,6 +258,80 @@ func (model Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return model, tea.Batch(cmds...)
 }
 
+// updateInactiveTabs delivers a message to every tab but the active one,
+// which has already received it.
+func (model *Model) updateInactiveTabs(msg tea.Msg) []tea.Cmd {
+	var cmds []tea.Cmd
+
+	if model.tabsModel.ActiveTab != tabs.Containers {
+		updatedContainers, containersCmd := model.containersModel.Update(msg)
+		model.containersModel = updatedContainers.(containers.Model)
+		cmds = append(cmds, containersCmd)
+	}
+	if model.tabsModel.ActiveTab != tabs.Images {
+		updatedImages, imagesCmd := model.imagesModel.Update(msg)
+		model.imagesModel = updatedImages.(images.Model)
+		cmds = append(cmds, imagesCmd)
+	}
+	if model.tabsModel.ActiveTab != tabs.Volumes {
+		updatedVolumes, volumesCmd := model.volumesModel.Update(msg)
+		model.volumesModel = updatedVolumes.(volumes.Model)
+		cmds = append(cmds, volumesCmd)
+	}
+	if model.tabsModel.ActiveTab != tabs.Networks {
+		updatedNetworks, networksCmd := model.networksModel.Update(msg)
+		model.networksModel = updatedNetworks.(networks.Model)
+		cmds = append(cmds, networksCmd)
+	}
+	if model.tabsModel.ActiveTab != tabs.System {
+		updatedSystem, systemCmd := model.systemModel.Update(msg)
+		model.systemModel = updatedSystem.(system.Model)
+		cmds = append(cmds, systemCmd)
+	}
+	if model.tabsModel.ActiveTab != tabs.Compose {
+		updatedCompose, composeCmd := model.composeModel.Update(msg)
+		model.composeModel = updatedCompose.(compose.Model)
+		cmds = append(cmds, composeCmd)
+	}
+	if model.tabsModel.ActiveTab != tabs.Events {
+		updatedEvents, eventsCmd := model.eventsModel.Update(msg)
+		model.eventsModel = updatedEvents.(events.Model)
+		cmds = append(cmds, eventsCmd)
+	}
+
+	return cmds
+}
+
+// withDaemonPanel covers the view of the active tab with the daemon panel, when it is open.
+func (model Model) withDaemonPanel(activeView tea.Model) tea.Model {
+	if !model.isDaemonPanelOpen {
+		return activeView
+	}
+	return overlay.New(model.daemonPanel, activeView, overlay.Center, overlay.Center, 0, 0)
+}
+
+// activeModel returns the model of the active tab.
+func (model Model) activeModel() tea.Model {
+	switch model.tabsModel.ActiveTab {
+	case tabs.Containers:
+		return model.containersModel
+	case tabs.Images:
+		return model.imagesModel
+	case tabs.Volumes:
+		return model.volumesModel
+	case tabs.Networks:
+		return model.networksModel
+	case tabs.System:
+		return model.systemModel
+	case tabs.Compose:
+		return model.composeModel
+	case tabs.Events:
+		return model.eventsModel
+	}
+
+	return nil
+}
+
 type helpProvider interface {
 	ShortHelp() []key.Binding
 	FullHelp() [][]key.Binding
@@ -194,6 +353,16 @@ func (model Model) View() string {
 		currentHelp = model.volumesModel
 	case tabs.Networks:
 		currentHelp = model.networksModel
+	case tabs.System:
+		currentHelp = model.systemModel
+	case tabs.Compose:
+		currentHelp = model.composeModel
+	case tabs.Events:
+		currentHelp = model.eventsModel
+	}
+
+	if model.isDaemonPanelOpen {
+		currentHelp = model.daemonPanel
 	}
 
 	if currentHelp != nil {
@@ -0,0 +1,418 @@
+package volumes
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/docker/go-units"
+	"github.com/givensuman/containertui/internal/client"
+	"github.com/givensuman/containertui/internal/colors"
+	"github.com/givensuman/containertui/internal/context"
+	"github.com/givensuman/containertui/internal/ui/shared"
+)
+
+// previewLimit is the number of bytes of a file shown in the preview.
+const previewLimit = 64 * 1024
+
+// browserHelperMsg is sent once the helper container of a browser started,
+// even if the tab is no longer active, so that it is never left running.
+type browserHelperMsg struct {
+	volumeName string
+	helperID   string
+	err        error
+}
+
+func (browserHelperMsg) IsBackground() {}
+
+// browserFilesMsg and browserPreviewMsg are delivered even if the tab is no
+// longer active, so that the browser does not keep loading when coming back.
+type browserFilesMsg struct {
+	helperID string
+	dir      string
+	files    []client.VolumeFile
+	selected string // Name of the file to select, e.g. the directory we came from.
+	err      error
+}
+
+func (browserFilesMsg) IsBackground() {}
+
+type browserPreviewMsg struct {
+	helperID string
+	file     client.VolumeFile
+	content  []byte
+	err      error
+}
+
+func (browserPreviewMsg) IsBackground() {}
+
+// closeBrowserMsg is sent when the user leaves the browser.
+type closeBrowserMsg struct {
+	helperID string
+}
+
+type browserKeybindings struct {
+	open        key.Binding
+	parent      key.Binding
+	close       key.Binding
+	switchFocus key.Binding
+}
+
+func newBrowserKeybindings() browserKeybindings {
+	return browserKeybindings{
+		open: key.NewBinding(
+			key.WithKeys("enter", "right", "l"),
+			key.WithHelp("enter", "open"),
+		),
+		parent: key.NewBinding(
+			key.WithKeys("backspace", "left", "h"),
+			key.WithHelp("backspace", "parent directory"),
+		),
+		close: key.NewBinding(
+			key.WithKeys("esc", "q"),
+			key.WithHelp("esc", "close browser"),
+		),
+		switchFocus: key.NewBinding(
+			key.WithKeys("tab"),
+			key.WithHelp("tab", "switch focus"),
+		),
+	}
+}
+
+// FileItem is an entry of the directory shown in the browser.
+type FileItem struct {
+	File client.VolumeFile
+}
+
+var (
+	_ list.Item        = (*FileItem)(nil)
+	_ list.DefaultItem = (*FileItem)(nil)
+)
+
+func (fileItem FileItem) getTitleOrnament() string {
+	if context.GetConfig().NoNerdFonts {
+		return ""
+	}
+
+	if fileItem.File.Mode.IsDir() {
+		return "\uf07b "
+	}
+	return "\uf15b "
+}
+
+func (fileItem FileItem) Title() string {
+	name := fileItem.File.Name
+	color := colors.Text()
+	if fileItem.File.Mode.IsDir() {
+		name += "/"
+		color = colors.Primary()
+	}
+
+	return lipgloss.NewStyle().
+		Foreground(color).
+		Render(fileItem.getTitleOrnament() + name)
+}
+
+func (fileItem FileItem) Description() string {
+	size := units.HumanSize(float64(fileItem.File.Size))
+	if fileItem.File.Mode.IsDir() {
+		size = "directory"
+	}
+
+	return fmt.Sprintf("   %s • %s • %s", size, fileItem.File.Mode, fileItem.File.ModTime.Format("2006-01-02 15:04"))
+}
+
+func (fileItem FileItem) FilterValue() string {
+	return fileItem.File.Name
+}
+
+// Browser lists the files of a volume through a helper container, and previews text files.
+type Browser struct {
+	shared.Component
+	style       lipgloss.Style
+	volumeName  string
+	helperID    string
+	dir         string
+	list        list.Model
+	viewport    viewport.Model
+	focusedView int
+	keybindings browserKeybindings
+	status      string
+}
+
+func newBrowser(volumeName string) Browser {
+	width, height := context.GetWindowSize()
+
+	listModel := list.New([]list.Item{}, newDefaultDelegate(), width, height)
+	listModel.SetShowHelp(false)
+	listModel.SetShowTitle(false)
+	listModel.SetShowStatusBar(false)
+	listModel.SetFilteringEnabled(true)
+	listModel.KeyMap.Quit.SetEnabled(false)
+	listModel.Styles.FilterPrompt = lipgloss.NewStyle().Foreground(colors.Primary())
+	listModel.Styles.FilterCursor = lipgloss.NewStyle().Foreground(colors.Primary())
+	listModel.FilterInput.PromptStyle = lipgloss.NewStyle().Foreground(colors.Primary())
+	listModel.FilterInput.Cursor.Style = lipgloss.NewStyle().Foreground(colors.Primary())
+
+	browserKeybindings := newBrowserKeybindings()
+	listModel.AdditionalFullHelpKeys = func() []key.Binding {
+		return []key.Binding{
+			browserKeybindings.open,
+			browserKeybindings.parent,
+			browserKeybindings.close,
+		}
+	}
+
+	browser := Browser{
+		style:       lipgloss.NewStyle().PaddingTop(1),
+		volumeName:  volumeName,
+		dir:         client.VolumeHelperMountPath,
+		list:        listModel,
+		viewport:    viewport.New(0, 0),
+		focusedView: focusList,
+		keybindings: browserKeybindings,
+		status:      "Starting helper container...",
+	}
+	browser.UpdateWindowDimensions(tea.WindowSizeMsg{Width: width, Height: height})
+
+	return browser
+}
+
+func (browser Browser) Init() tea.Cmd {
+	volumeName := browser.volumeName
+	return func() tea.Msg {
+		helperID, err := context.GetClient().StartVolumeHelper(volumeName, true)
+		return browserHelperMsg{volumeName: volumeName, helperID: helperID, err: err}
+	}
+}
+
+func (browser Browser) listDirectory(dir, selected string) tea.Cmd {
+	helperID := browser.helperID
+	return func() tea.Msg {
+		files, err := context.GetClient().ListVolumeDirectory(helperID, dir)
+		return browserFilesMsg{helperID: helperID, dir: dir, files: files, selected: selected, err: err}
+	}
+}
+
+func (browser Browser) readFile(file client.VolumeFile) tea.Cmd {
+	helperID := browser.helperID
+	return func() tea.Msg {
+		content, err := context.GetClient().ReadVolumeFile(helperID, file.Path, previewLimit)
+		return browserPreviewMsg{helperID: helperID, file: file, content: content, err: err}
+	}
+}
+
+func (browser Browser) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmds []tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		browser.UpdateWindowDimensions(msg)
+		return browser, nil
+
+	case browserHelperMsg:
+		if msg.err != nil {
+			browser.status = "Could not start helper container: " + msg.err.Error()
+			return browser, nil
+		}
+		browser.helperID = msg.helperID
+		browser.status = "Loading..."
+		return browser, browser.listDirectory(browser.dir, "")
+
+	case browserFilesMsg:
+		if msg.helperID != browser.helperID {
+			return browser, nil // Listed for a browser closed in the meantime.
+		}
+		if msg.err != nil {
+			browser.status = msg.err.Error()
+			return browser, nil
+		}
+		browser.dir = msg.dir
+		browser.status = ""
+		browser.viewport.SetContent("")
+		browser.list.ResetFilter()
+
+		items := fileItems(msg.files)
+		cmd := browser.list.SetItems(items)
+		browser.list.Select(0)
+		for index, item := range items {
+			if item.(FileItem).File.Name == msg.selected {
+				browser.list.Select(index)
+				break
+			}
+		}
+		return browser, cmd
+
+	case browserPreviewMsg:
+		if msg.helperID != browser.helperID {
+			return browser, nil
+		}
+		browser.viewport.SetContent(formatPreview(msg))
+		browser.viewport.GotoTop()
+		return browser, nil
+
+	case tea.KeyMsg:
+		if browser.list.FilterState() == list.Filtering {
+			break
+		}
+
+		switch {
+		case key.Matches(msg, browser.keybindings.close):
+			if browser.list.FilterState() == list.FilterApplied && msg.String() == "esc" {
+				break
+			}
+			helperID := browser.helperID
+			return browser, func() tea.Msg { return closeBrowserMsg{helperID: helperID} }
+
+		case key.Matches(msg, browser.keybindings.switchFocus):
+			if browser.focusedView == focusList {
+				browser.focusedView = focusDetails
+			} else {
+				browser.focusedView = focusList
+			}
+			return browser, nil
+
+		case browser.helperID == "":
+			return browser, nil
+
+		case browser.focusedView == focusList && key.Matches(msg, browser.keybindings.open):
+			fileItem, ok := browser.list.SelectedItem().(FileItem)
+			if !ok {
+				return browser, nil
+			}
+			if fileItem.File.Mode.IsDir() {
+				return browser, browser.listDirectory(fileItem.File.Path, "")
+			}
+			browser.viewport.SetContent(lipgloss.NewStyle().Foreground(colors.Muted()).Render("Loading..."))
+			return browser, browser.readFile(fileItem.File)
+
+		case browser.focusedView == focusList && key.Matches(msg, browser.keybindings.parent):
+			if browser.dir == client.VolumeHelperMountPath {
+				return browser, nil
+			}
+			return browser, browser.listDirectory(path.Dir(browser.dir), path.Base(browser.dir))
+		}
+	}
+
+	if _, ok := msg.(tea.KeyMsg); !ok || browser.focusedView == focusList {
+		updatedList, listCmd := browser.list.Update(msg)
+		browser.list = updatedList
+		cmds = append(cmds, listCmd)
+	}
+
+	if _, ok := msg.(tea.KeyMsg); !ok || browser.focusedView == focusDetails {
+		updatedViewport, viewportCmd := browser.viewport.Update(msg)
+		browser.viewport = updatedViewport
+		cmds = append(cmds, viewportCmd)
+	}
+
+	return browser, tea.Batch(cmds...)
+}
+
+func (browser *Browser) UpdateWindowDimensions(msg tea.WindowSizeMsg) {
+	browser.WindowWidth = msg.Width
+	browser.WindowHeight = msg.Height
+
+	layoutManager := shared.NewLayoutManager(msg.Width, msg.Height)
+	masterLayout, detailLayout := layoutManager.CalculateMasterDetail(browser.style)
+
+	browser.style = browser.style.Width(masterLayout.Width).Height(masterLayout.Height)
+	browser.list.SetWidth(masterLayout.ContentWidth)
+	browser.list.SetHeight(shared.Max(masterLayout.ContentHeight-1, 0)) // Leave room for the path.
+
+	browser.viewport.Width = shared.Max(detailLayout.Width-4, 0)
+	browser.viewport.Height = shared.Max(detailLayout.Height-2, 0)
+}
+
+func (browser Browser) View() string {
+	layoutManager := shared.NewLayoutManager(browser.WindowWidth, browser.WindowHeight)
+	_, detailLayout := layoutManager.CalculateMasterDetail(lipgloss.NewStyle())
+
+	relativePath := strings.TrimPrefix(browser.dir, client.VolumeHelperMountPath)
+	header := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(colors.Primary()).
+		Render(fmt.Sprintf("%s:/%s", browser.volumeName, strings.TrimPrefix(relativePath, "/")))
+
+	listContent := browser.list.View()
+	if browser.status != "" {
+		listContent = lipgloss.NewStyle().Foreground(colors.Muted()).Render(browser.status)
+	}
+	listView := browser.style.Render(lipgloss.JoinVertical(lipgloss.Left, header, listContent))
+
+	borderColor := colors.Muted()
+	if browser.focusedView == focusDetails {
+		borderColor = colors.Primary()
+	}
+
+	detailStyle := lipgloss.NewStyle().
+		Width(detailLayout.Width - 2).
+		Height(detailLayout.Height).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(borderColor).
+		Padding(1)
+
+	detailContent := browser.viewport.View()
+	if browser.viewport.TotalLineCount() <= 1 && strings.TrimSpace(detailContent) == "" {
+		detailContent = lipgloss.NewStyle().Foreground(colors.Muted()).Render("Press enter on a file to preview it.")
+	}
+
+	return lipgloss.JoinHorizontal(lipgloss.Top, listView, detailStyle.Render(detailContent))
+}
+
+// fileItems sorts the files, directories first, and converts them to list items.
+func fileItems(files []client.VolumeFile) []list.Item {
+	sort.Slice(files, func(i, j int) bool {
+		if files[i].Mode.IsDir() != files[j].Mode.IsDir() {
+			return files[i].Mode.IsDir()
+		}
+		return files[i].Name < files[j].Name
+	})
+
+	items := make([]list.Item, 0, len(files))
+	for _, file := range files {
+		items = append(items, FileItem{File: file})
+	}
+
+	return items
+}
+
+func formatPreview(msg browserPreviewMsg) string {
+	mutedStyle := lipgloss.NewStyle().Foreground(colors.Muted())
+
+	if msg.err != nil {
+		return lipgloss.NewStyle().Foreground(colors.Error()).Render(msg.err.Error())
+	}
+
+	content := msg.content
+	isTruncated := msg.file.Size > int64(len(content))
+	if isTruncated {
+		// The limit may have split the last character.
+		for index := 0; index < utf8.UTFMax-1 && len(content) > 0 && !utf8.Valid(content); index++ {
+			content = content[:len(content)-1]
+		}
+	}
+
+	if bytes.IndexByte(content, 0) >= 0 || !utf8.Valid(content) {
+		return mutedStyle.Render(fmt.Sprintf("%s is a binary file (%s).", msg.file.Name, units.HumanSize(float64(msg.file.Size))))
+	}
+
+	preview := strings.ReplaceAll(string(content), "\t", "    ")
+	if isTruncated {
+		preview += "\n" + mutedStyle.Render(fmt.Sprintf(
+			"... showing the first %s of %s",
+			units.HumanSize(float64(len(content))),
+			units.HumanSize(float64(msg.file.Size)),
+		))
+	}
+
+	return preview
+}
@@ -0,0 +1,41 @@
+package volumes
+
+import (
+	"fmt"
+
+	"github.com/givensuman/containertui/internal/client"
+	"github.com/givensuman/containertui/internal/ui/shared"
+)
+
+func newCreateVolumeForm() shared.Form {
+	return shared.NewForm(
+		"Create volume",
+		[]shared.FormField{
+			{Key: "name", Label: "Name", Placeholder: "generated when empty"},
+			{Key: "driver", Label: "Driver", Placeholder: "local"},
+			{Key: "driverOpts", Label: "Driver options", Placeholder: "type=nfs o=addr=10.0.0.1,rw device=:/exports", Hint: "space separated key=value"},
+			{Key: "labels", Label: "Labels", Placeholder: "com.example.team=backend", Hint: "space separated key=value"},
+		},
+		shared.SmartDialogAction{Type: "CreateVolume"},
+	)
+}
+
+// parseCreateVolumeOptions converts the values of the create volume form.
+func parseCreateVolumeOptions(values map[string]string) (client.CreateVolumeOptions, error) {
+	driverOpts, err := shared.ParseKeyValuePairs(values["driverOpts"])
+	if err != nil {
+		return client.CreateVolumeOptions{}, fmt.Errorf("driver options: %w", err)
+	}
+
+	labels, err := shared.ParseKeyValuePairs(values["labels"])
+	if err != nil {
+		return client.CreateVolumeOptions{}, fmt.Errorf("labels: %w", err)
+	}
+
+	return client.CreateVolumeOptions{
+		Name:       values["name"],
+		Driver:     values["driver"],
+		DriverOpts: driverOpts,
+		Labels:     labels,
+	}, nil
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/docker/go-units"
 	"github.com/givensuman/containertui/internal/client"
 	"github.com/givensuman/containertui/internal/colors"
 	"github.com/givensuman/containertui/internal/context"
@@ -84,7 +85,31 @@ func (volumeItem VolumeItem) Title() string {
 }
 
 func (volumeItem VolumeItem) Description() string {
-	return "   " + volumeItem.Volume.Driver
+	return fmt.Sprintf("   %s • %s • %s",
+		volumeItem.Volume.Driver,
+		formatSize(volumeItem.Volume.Size),
+		formatRefCount(volumeItem.Volume.RefCount),
+	)
+}
+
+func formatSize(size int64) string {
+	if size < 0 {
+		return "size unknown"
+	}
+	return units.HumanSize(float64(size))
+}
+
+func formatRefCount(refCount int64) string {
+	switch {
+	case refCount < 0:
+		return "usage unknown"
+	case refCount == 0:
+		return "unused"
+	case refCount == 1:
+		return "1 container"
+	default:
+		return fmt.Sprintf("%d containers", refCount)
+	}
 }
 
 func (volumeItem VolumeItem) FilterValue() string {
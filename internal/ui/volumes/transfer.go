@@ -0,0 +1,218 @@
+package volumes
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/docker/go-units"
+	"github.com/givensuman/containertui/internal/colors"
+	"github.com/givensuman/containertui/internal/context"
+	"github.com/givensuman/containertui/internal/ui/shared"
+)
+
+type transferKind int
+
+const (
+	transferBackup transferKind = iota
+	transferRestore
+)
+
+func (kind transferKind) String() string {
+	return [...]string{"Backing up", "Restoring"}[kind]
+}
+
+type transferProgressMsg struct {
+	transferred int64
+	total       int64
+}
+
+type transferDoneMsg struct {
+	kind       transferKind
+	volumeName string
+	path       string
+	err        error
+}
+
+// Transfer shows the progress of a volume backup or restore.
+type Transfer struct {
+	shared.Component
+	style       lipgloss.Style
+	kind        transferKind
+	volumeName  string
+	file        *os.File
+	transferred int64
+	total       int64
+	updates     chan tea.Msg
+}
+
+var (
+	_ tea.Model             = (*Transfer)(nil)
+	_ shared.ComponentModel = (*Transfer)(nil)
+)
+
+// newTransfer creates a transfer between the volume and the file, which it closes when done.
+func newTransfer(kind transferKind, volumeName string, file *os.File) Transfer {
+	width, height := context.GetWindowSize()
+
+	transfer := Transfer{
+		style: lipgloss.NewStyle().
+			Padding(1, 2).
+			Border(lipgloss.RoundedBorder(), true, true).
+			BorderForeground(colors.Primary()),
+		kind:       kind,
+		volumeName: volumeName,
+		file:       file,
+		updates:    make(chan tea.Msg, 1),
+	}
+	transfer.UpdateWindowDimensions(tea.WindowSizeMsg{Width: width, Height: height})
+
+	return transfer
+}
+
+func (transfer *Transfer) UpdateWindowDimensions(msg tea.WindowSizeMsg) {
+	transfer.WindowWidth = msg.Width
+	transfer.WindowHeight = msg.Height
+
+	layoutManager := shared.NewLayoutManager(msg.Width, msg.Height)
+	dimensions := layoutManager.Calculate(shared.RatioForm, transfer.style)
+	transfer.style = transfer.style.Width(dimensions.Width)
+}
+
+// run performs the transfer, reporting progress and completion through updates.
+func (transfer Transfer) run() tea.Msg {
+	progress := func(transferred, total int64) {
+		select {
+		case transfer.updates <- transferProgressMsg{transferred: transferred, total: total}:
+		default: // The previous update has not been rendered yet, skip this one.
+		}
+	}
+
+	var err error
+	switch transfer.kind {
+	case transferBackup:
+		err = context.GetClient().BackupVolume(transfer.volumeName, transfer.file, progress)
+		err = errors.Join(err, transfer.file.Close())
+		if err != nil {
+			os.Remove(transfer.file.Name())
+		}
+	case transferRestore:
+		var size int64
+		if info, statErr := transfer.file.Stat(); statErr == nil {
+			size = info.Size()
+		}
+		err = context.GetClient().RestoreVolume(transfer.volumeName, transfer.file, size, progress)
+		transfer.file.Close()
+	}
+
+	// Make room for the final message, a pending progress update is stale by now.
+	select {
+	case <-transfer.updates:
+	default:
+	}
+	transfer.updates <- transferDoneMsg{
+		kind:       transfer.kind,
+		volumeName: transfer.volumeName,
+		path:       transfer.file.Name(),
+		err:        err,
+	}
+
+	return nil
+}
+
+func waitForTransfer(updates <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		return <-updates
+	}
+}
+
+func (transfer Transfer) Init() tea.Cmd {
+	return tea.Batch(transfer.run, waitForTransfer(transfer.updates))
+}
+
+func (transfer Transfer) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		transfer.UpdateWindowDimensions(msg)
+	case transferProgressMsg:
+		transfer.transferred = msg.transferred
+		transfer.total = msg.total
+		return transfer, waitForTransfer(transfer.updates)
+	}
+
+	return transfer, nil
+}
+
+func (transfer Transfer) View() string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(colors.Primary())
+	mutedStyle := lipgloss.NewStyle().Foreground(colors.Muted())
+
+	title := fmt.Sprintf("%s volume %s", transfer.kind, transfer.volumeName)
+	direction := "to " + transfer.file.Name()
+	if transfer.kind == transferRestore {
+		direction = "from " + transfer.file.Name()
+	}
+
+	transferred := units.HumanSize(float64(transfer.transferred))
+	var bar string
+	if transfer.total > 0 {
+		fraction := float64(transfer.transferred) / float64(transfer.total)
+		bar = shared.RenderProgressBar(shared.Max(transfer.style.GetWidth()-6, 0), fraction)
+		transferred += " / " + units.HumanSize(float64(transfer.total))
+	}
+
+	rows := []string{titleStyle.Render(title), mutedStyle.Render(direction), ""}
+	if bar != "" {
+		rows = append(rows, bar)
+	}
+	rows = append(rows, transferred, "", mutedStyle.Render("Please wait until the transfer completes."))
+
+	return transfer.style.Render(lipgloss.JoinVertical(lipgloss.Left, rows...))
+}
+
+func newBackupForm(volumeName string) shared.Form {
+	defaultPath := fmt.Sprintf("%s-%s.tar.gz", volumeName, time.Now().Format("20060102-150405"))
+
+	return shared.NewForm(
+		"Back up volume "+volumeName,
+		[]shared.FormField{
+			{Key: "path", Label: "Archive", Value: defaultPath, Hint: "gzip compressed tar, must not exist"},
+		},
+		shared.SmartDialogAction{Type: "BackupVolume", Payload: volumeName},
+	)
+}
+
+func newRestoreForm(volumeName string) shared.Form {
+	return shared.NewForm(
+		"Restore volume "+volumeName,
+		[]shared.FormField{
+			{Key: "path", Label: "Archive", Placeholder: volumeName + ".tar.gz", Hint: "existing files with the same paths are overwritten"},
+		},
+		shared.SmartDialogAction{Type: "RestoreVolume", Payload: volumeName},
+	)
+}
+
+// openTransferFile opens the archive of a backup or restore, so that errors surface in the form.
+func openTransferFile(kind transferKind, path string) (*os.File, error) {
+	if path == "" {
+		return nil, errors.New("an archive path is required")
+	}
+
+	switch kind {
+	case transferBackup:
+		return os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	default:
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		if info, err := file.Stat(); err == nil && info.IsDir() {
+			file.Close()
+			return nil, fmt.Errorf("%s is a directory", path)
+		}
+		return file, nil
+	}
+}
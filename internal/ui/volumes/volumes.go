@@ -3,15 +3,20 @@ package volumes
 
 import (
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/docker/go-units"
 	"github.com/givensuman/containertui/internal/client"
 	"github.com/givensuman/containertui/internal/colors"
 	"github.com/givensuman/containertui/internal/context"
+	"github.com/givensuman/containertui/internal/ui/notifications"
 	"github.com/givensuman/containertui/internal/ui/shared"
 	overlay "github.com/rmhubbert/bubbletea-overlay"
 )
@@ -43,6 +48,13 @@ type keybindings struct {
 	toggleSelection      key.Binding
 	toggleSelectionOfAll key.Binding
 	remove               key.Binding
+	create               key.Binding
+	browse               key.Binding
+	backup               key.Binding
+	restore              key.Binding
+	sort                 key.Binding
+	toggleUnusedOnly     key.Binding
+	refresh              key.Binding
 	switchTab            key.Binding
 }
 
@@ -60,9 +72,37 @@ func newKeybindings() *keybindings {
 			key.WithKeys("r"),
 			key.WithHelp("r", "remove"),
 		),
+		create: key.NewBinding(
+			key.WithKeys("n"),
+			key.WithHelp("n", "new volume"),
+		),
+		browse: key.NewBinding(
+			key.WithKeys("b"),
+			key.WithHelp("b", "browse files"),
+		),
+		backup: key.NewBinding(
+			key.WithKeys("B"),
+			key.WithHelp("B", "back up"),
+		),
+		restore: key.NewBinding(
+			key.WithKeys("R"),
+			key.WithHelp("R", "restore"),
+		),
+		sort: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "sort by name/size"),
+		),
+		toggleUnusedOnly: key.NewBinding(
+			key.WithKeys("U"),
+			key.WithHelp("U", "toggle unused only"),
+		),
+		refresh: key.NewBinding(
+			key.WithKeys("ctrl+r"),
+			key.WithHelp("ctrl+r", "refresh"),
+		),
 		switchTab: key.NewBinding(
-			key.WithKeys("1", "2", "3", "4", "tab", "shift+tab"),
-			key.WithHelp("1-4/tab", "switch tab"),
+			key.WithKeys("1", "2", "3", "4", "5", "6", "7", "tab", "shift+tab"),
+			key.WithHelp("1-7/tab", "switch tab"),
 		),
 	}
 }
@@ -86,11 +126,23 @@ func (selectedVolumes selectedVolumes) unselectVolumeInList(name string) {
 	delete(selectedVolumes.selections, name)
 }
 
+type sortOrder int
+
+const (
+	sortByName sortOrder = iota
+	sortBySize
+)
+
+func (order sortOrder) String() string {
+	return [...]string{"name", "size"}[order]
+}
+
 type sessionState int
 
 const (
 	viewMain sessionState = iota
 	viewOverlay
+	viewBrowser
 )
 
 const (
@@ -106,12 +158,17 @@ type Model struct {
 	viewport        viewport.Model
 	selectedVolumes *selectedVolumes
 	keybindings     *keybindings
+	volumes         []client.Volume
+	usage           map[string]client.VolumeUsage // Last loaded usage of the volumes, by name.
+	sortOrder       sortOrder
+	showUnusedOnly  bool
 
 	sessionState       sessionState
 	focusedView        int
 	detailsKeybindings detailsKeybindings
 	foreground         tea.Model
 	overlayModel       *overlay.Model
+	browser            Browser
 }
 
 var (
@@ -124,10 +181,6 @@ func New() Model {
 	if err != nil {
 		volumeList = []client.Volume{}
 	}
-	items := make([]list.Item, 0, len(volumeList))
-	for _, volume := range volumeList {
-		items = append(items, VolumeItem{Volume: volume})
-	}
 
 	width, height := context.GetWindowSize()
 	style := lipgloss.NewStyle().
@@ -136,7 +189,7 @@ func New() Model {
 		PaddingTop(1)
 
 	delegate := newDefaultDelegate()
-	listModel := list.New(items, delegate, width, height)
+	listModel := list.New([]list.Item{}, delegate, width, height)
 	listModel.SetShowHelp(false)
 	listModel.SetShowTitle(false)
 	listModel.SetShowStatusBar(false)
@@ -152,6 +205,13 @@ func New() Model {
 			volumeKeybindings.toggleSelection,
 			volumeKeybindings.toggleSelectionOfAll,
 			volumeKeybindings.remove,
+			volumeKeybindings.create,
+			volumeKeybindings.browse,
+			volumeKeybindings.backup,
+			volumeKeybindings.restore,
+			volumeKeybindings.sort,
+			volumeKeybindings.toggleUnusedOnly,
+			volumeKeybindings.refresh,
 			volumeKeybindings.switchTab,
 		}
 	}
@@ -164,23 +224,62 @@ func New() Model {
 		viewport:           detailViewport,
 		selectedVolumes:    newSelectedVolumes(),
 		keybindings:        volumeKeybindings,
+		volumes:            volumeList,
 		sessionState:       viewMain,
 		focusedView:        focusList,
 		detailsKeybindings: newDetailsKeybindings(),
 	}
 
+	model.setItems()
+
 	model.overlayModel = overlay.New(nil, model.list, overlay.Center, overlay.Center, 0, 0)
 	return model
 }
 
 func (model Model) Init() tea.Cmd {
-	return nil
+	return loadVolumeUsage
 }
 
 func (model Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 
+	if doneMsg, ok := msg.(transferDoneMsg); ok {
+		if _, ok := model.foreground.(Transfer); ok {
+			model.sessionState = viewMain
+			model.foreground = nil
+		}
+		if doneMsg.kind == transferRestore {
+			cmds = append(cmds, model.reloadVolumes())
+		}
+		cmds = append(cmds, notifyTransferDone(doneMsg))
+	}
+
+	// Usage can be unsupported by the daemon, the sizes are then left unknown.
+	if usageMsg, ok := msg.(volumeUsageMsg); ok && usageMsg.err == nil {
+		model.usage = usageMsg.usage
+		model.applyUsage()
+		cmds = append(cmds, model.setItems())
+	}
+
+	// The browser the helper was started for may have been closed in the meantime.
+	if helperMsg, ok := msg.(browserHelperMsg); ok && helperMsg.err == nil {
+		if model.sessionState != viewBrowser || model.browser.volumeName != helperMsg.volumeName || model.browser.helperID != "" {
+			return model, removeVolumeHelper(helperMsg.helperID)
+		}
+	}
+
 	switch model.sessionState {
+	case viewBrowser:
+		if closeMsg, ok := msg.(closeBrowserMsg); ok {
+			model.sessionState = viewMain
+			model.browser = Browser{}
+			cmds = append(cmds, removeVolumeHelper(closeMsg.helperID))
+			break
+		}
+
+		updatedBrowser, browserCmd := model.browser.Update(msg)
+		model.browser = updatedBrowser.(Browser)
+		cmds = append(cmds, browserCmd)
 	case viewOverlay:
 		foregroundModel, foregroundCmd := model.foreground.Update(msg)
 		model.foreground = foregroundModel
@@ -196,9 +295,36 @@ func (model Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if err != nil {
 					break
 				}
+				model.removeVolume(volumeName)
 			}
 			model.sessionState = viewMain
 			model.foreground = nil
+		} else if submitMsg, ok := msg.(shared.FormSubmitMessage); ok {
+			switch submitMsg.Action.Type {
+			case "CreateVolume":
+				volume, err := createVolume(submitMsg.Values)
+				if err != nil {
+					model.foreground = shared.SetFormError(model.foreground, err)
+					break
+				}
+				cmds = append(cmds, model.insertVolume(volume))
+				cmds = append(cmds, notifications.ShowSuccess("Created volume "+volume.Name))
+				model.sessionState = viewMain
+				model.foreground = nil
+			case "BackupVolume", "RestoreVolume":
+				kind := transferBackup
+				if submitMsg.Action.Type == "RestoreVolume" {
+					kind = transferRestore
+				}
+				file, err := openTransferFile(kind, submitMsg.Values["path"])
+				if err != nil {
+					model.foreground = shared.SetFormError(model.foreground, err)
+					break
+				}
+				transfer := newTransfer(kind, submitMsg.Action.Payload.(string), file)
+				model.foreground = transfer
+				cmds = append(cmds, transfer.Init())
+			}
 		}
 	case viewMain:
 		if keyMsg, ok := msg.(tea.KeyMsg); ok {
@@ -233,6 +359,46 @@ func (model Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					model.handleToggleSelection()
 				case key.Matches(msg, model.keybindings.toggleSelectionOfAll):
 					model.handleToggleSelectionOfAll()
+				case key.Matches(msg, model.keybindings.sort):
+					model.sortOrder = (model.sortOrder + 1) % 2
+					model.setItems()
+					cmds = append(cmds, notifications.ShowInfo("Sorting volumes by "+model.sortOrder.String()))
+				case key.Matches(msg, model.keybindings.toggleUnusedOnly):
+					model.showUnusedOnly = !model.showUnusedOnly
+					model.setItems()
+					if model.showUnusedOnly {
+						cmds = append(cmds, notifications.ShowInfo("Showing unused volumes only"))
+					} else {
+						cmds = append(cmds, notifications.ShowInfo("Showing all volumes"))
+					}
+				case key.Matches(msg, model.keybindings.refresh):
+					cmds = append(cmds, model.reloadVolumes())
+				case key.Matches(msg, model.keybindings.browse):
+					if volumeItem, ok := model.list.SelectedItem().(VolumeItem); ok {
+						model.browser = newBrowser(volumeItem.Volume.Name)
+						model.sessionState = viewBrowser
+						cmds = append(cmds, model.browser.Init())
+						return model, tea.Batch(cmds...)
+					}
+				case key.Matches(msg, model.keybindings.backup):
+					if volumeItem, ok := model.list.SelectedItem().(VolumeItem); ok {
+						form := newBackupForm(volumeItem.Volume.Name)
+						model.foreground = form
+						model.sessionState = viewOverlay
+						cmds = append(cmds, form.Init())
+					}
+				case key.Matches(msg, model.keybindings.restore):
+					if volumeItem, ok := model.list.SelectedItem().(VolumeItem); ok {
+						form := newRestoreForm(volumeItem.Volume.Name)
+						model.foreground = form
+						model.sessionState = viewOverlay
+						cmds = append(cmds, form.Init())
+					}
+				case key.Matches(msg, model.keybindings.create):
+					form := newCreateVolumeForm()
+					model.foreground = form
+					model.sessionState = viewOverlay
+					cmds = append(cmds, form.Init())
 				case key.Matches(msg, model.keybindings.remove):
 					selectedItem := model.list.SelectedItem()
 					if selectedItem != nil {
@@ -270,11 +436,7 @@ func (model Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		selectedItem := model.list.SelectedItem()
 		if selectedItem != nil {
 			if volumeItem, ok := selectedItem.(VolumeItem); ok {
-				detailsContent := fmt.Sprintf(
-					"Name: %s\nDriver: %s\nMountpoint: %s",
-					volumeItem.Volume.Name, volumeItem.Volume.Driver, volumeItem.Volume.Mountpoint,
-				)
-				model.viewport.SetContent(detailsContent)
+				model.viewport.SetContent(formatVolume(volumeItem.Volume))
 			}
 		}
 
@@ -291,6 +453,191 @@ func (model Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return model, tea.Batch(cmds...)
 }
 
+func removeVolumeHelper(helperID string) tea.Cmd {
+	if helperID == "" {
+		return nil
+	}
+	return func() tea.Msg {
+		_ = context.GetClient().RemoveVolumeHelper(helperID)
+		return nil
+	}
+}
+
+func notifyTransferDone(msg transferDoneMsg) tea.Cmd {
+	if msg.err != nil {
+		return notifications.ShowError(fmt.Errorf("%s volume %s failed: %w", strings.ToLower(msg.kind.String()), msg.volumeName, msg.err))
+	}
+
+	switch msg.kind {
+	case transferBackup:
+		return notifications.ShowSuccess(fmt.Sprintf("Backed up volume %s to %s", msg.volumeName, msg.path))
+	default:
+		return notifications.ShowSuccess(fmt.Sprintf("Restored volume %s from %s", msg.volumeName, msg.path))
+	}
+}
+
+func createVolume(values map[string]string) (client.Volume, error) {
+	options, err := parseCreateVolumeOptions(values)
+	if err != nil {
+		return client.Volume{}, err
+	}
+
+	return context.GetClient().CreateVolume(options)
+}
+
+// insertVolume adds a newly created volume to the list and selects it.
+func (model *Model) insertVolume(volume client.Volume) tea.Cmd {
+	model.list.ResetFilter()
+	model.volumes = append(model.volumes, volume)
+	cmd := model.setItems()
+
+	for index, item := range model.list.Items() {
+		if volumeItem, ok := item.(VolumeItem); ok && volumeItem.Volume.Name == volume.Name {
+			model.list.Select(index)
+			model.viewport.SetContent(formatVolume(volume))
+			break
+		}
+	}
+
+	return cmd
+}
+
+// removeVolume drops a removed volume from the list.
+func (model *Model) removeVolume(name string) {
+	for index, volume := range model.volumes {
+		if volume.Name == name {
+			model.volumes = append(model.volumes[:index], model.volumes[index+1:]...)
+			break
+		}
+	}
+	model.selectedVolumes.unselectVolumeInList(name)
+	model.setItems()
+}
+
+// volumeUsageMsg carries the usage of the volumes, which is loaded in the
+// background as the daemon can take long to measure them.
+type volumeUsageMsg struct {
+	usage map[string]client.VolumeUsage
+	err   error
+}
+
+func (volumeUsageMsg) IsBackground() {}
+
+func loadVolumeUsage() tea.Msg {
+	usage, err := context.GetClient().GetVolumeUsage()
+	return volumeUsageMsg{usage: usage, err: err}
+}
+
+// reloadVolumes fetches the volumes again, showing the last loaded usage
+// until their current usage arrives.
+func (model *Model) reloadVolumes() tea.Cmd {
+	volumeList, err := context.GetClient().GetVolumes()
+	if err != nil {
+		return nil
+	}
+	model.volumes = volumeList
+	model.applyUsage()
+	return tea.Batch(model.setItems(), loadVolumeUsage)
+}
+
+// applyUsage fills in the size and reference count of the volumes from the last loaded usage.
+func (model *Model) applyUsage() {
+	for index, volume := range model.volumes {
+		if usage, ok := model.usage[volume.Name]; ok {
+			model.volumes[index].Size = usage.Size
+			model.volumes[index].RefCount = usage.RefCount
+		}
+	}
+}
+
+// setItems fills the list with the volumes, applying the sort order and the
+// unused filter, and keeping the selections of volumes which are still shown.
+// The filter keeps volumes whose usage is not known yet, shown as unknown.
+func (model *Model) setItems() tea.Cmd {
+	volumes := make([]client.Volume, 0, len(model.volumes))
+	for _, volume := range model.volumes {
+		if model.showUnusedOnly && volume.RefCount > 0 {
+			continue
+		}
+		volumes = append(volumes, volume)
+	}
+	sortVolumes(volumes, model.sortOrder)
+
+	selections := model.selectedVolumes.selections
+	model.selectedVolumes = newSelectedVolumes()
+
+	items := make([]list.Item, 0, len(volumes))
+	for index, volume := range volumes {
+		_, isSelected := selections[volume.Name]
+		if isSelected {
+			model.selectedVolumes.selectVolumeInList(volume.Name, index)
+		}
+		items = append(items, VolumeItem{Volume: volume, isSelected: isSelected})
+	}
+
+	var currentName string
+	if volumeItem, ok := model.list.SelectedItem().(VolumeItem); ok {
+		currentName = volumeItem.Volume.Name
+	}
+
+	cmd := model.list.SetItems(items)
+
+	// Keep the cursor on the same volume when it moves.
+	for index, volume := range volumes {
+		if volume.Name == currentName {
+			model.list.Select(index)
+			break
+		}
+	}
+
+	return cmd
+}
+
+// sortVolumes sorts by name, or by size with the largest first and unknown sizes last.
+func sortVolumes(volumes []client.Volume, order sortOrder) {
+	sort.SliceStable(volumes, func(i, j int) bool {
+		if order == sortBySize && volumes[i].Size != volumes[j].Size {
+			return volumes[i].Size > volumes[j].Size
+		}
+		return volumes[i].Name < volumes[j].Name
+	})
+}
+
+func formatVolume(volume client.Volume) string {
+	var builder strings.Builder
+
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(colors.Primary())
+	mutedStyle := lipgloss.NewStyle().Foreground(colors.Muted())
+
+	builder.WriteString(headerStyle.Render(volume.Name) + "\n\n")
+	builder.WriteString(fmt.Sprintf("Driver: %s\n", volume.Driver))
+	builder.WriteString(fmt.Sprintf("Scope: %s\n", volume.Scope))
+	builder.WriteString(fmt.Sprintf("Mountpoint: %s\n", volume.Mountpoint))
+	if !volume.CreatedAt.IsZero() {
+		builder.WriteString(fmt.Sprintf("Created: %s (%s ago)\n",
+			volume.CreatedAt.Local().Format("2006-01-02 15:04:05"),
+			units.HumanDuration(time.Since(volume.CreatedAt)),
+		))
+	}
+	builder.WriteString(fmt.Sprintf("Size: %s\n", formatSize(volume.Size)))
+	builder.WriteString(fmt.Sprintf("Usage: %s\n", formatRefCount(volume.RefCount)))
+
+	builder.WriteString("\n" + headerStyle.Render("Labels") + "\n")
+	if len(volume.Labels) == 0 {
+		builder.WriteString(mutedStyle.Render("No labels.") + "\n")
+	}
+	labelKeys := make([]string, 0, len(volume.Labels))
+	for labelKey := range volume.Labels {
+		labelKeys = append(labelKeys, labelKey)
+	}
+	sort.Strings(labelKeys)
+	for _, labelKey := range labelKeys {
+		builder.WriteString(fmt.Sprintf("%s=%s\n", labelKey, volume.Labels[labelKey]))
+	}
+
+	return builder.String()
+}
+
 func (model *Model) handleToggleSelection() {
 	currentIndex := model.list.Index()
 	selectedItem, ok := model.list.SelectedItem().(VolumeItem)
@@ -350,6 +697,10 @@ func (model Model) View() string {
 		return model.overlayModel.View()
 	}
 
+	if model.sessionState == viewBrowser {
+		return model.browser.View()
+	}
+
 	layoutManager := shared.NewLayoutManager(model.WindowWidth, model.WindowHeight)
 	_, detailLayout := layoutManager.CalculateMasterDetail(lipgloss.NewStyle())
 
@@ -406,14 +757,47 @@ func (model *Model) UpdateWindowDimensions(msg tea.WindowSizeMsg) {
 			model.list.SetHeight(masterLayout.ContentHeight)
 		}
 	case viewOverlay:
-		if smartDialog, ok := model.foreground.(shared.SmartDialog); ok {
-			smartDialog.UpdateWindowDimensions(msg)
-			model.foreground = smartDialog
+		switch foregroundModel := model.foreground.(type) {
+		case shared.SmartDialog:
+			foregroundModel.UpdateWindowDimensions(msg)
+			model.foreground = foregroundModel
+		case shared.Form:
+			foregroundModel.UpdateWindowDimensions(msg)
+			model.foreground = foregroundModel
+		case Transfer:
+			foregroundModel.UpdateWindowDimensions(msg)
+			model.foreground = foregroundModel
 		}
+	case viewBrowser:
+		model.browser.UpdateWindowDimensions(msg)
 	}
 }
 
+// IsCapturingInput reports whether keys are being typed into a filter or a form,
+// or a transfer is in progress.
+func (model Model) IsCapturingInput() bool {
+	switch model.sessionState {
+	case viewOverlay:
+		switch model.foreground.(type) {
+		case shared.Form, Transfer:
+			return true
+		}
+	case viewBrowser:
+		return model.browser.list.FilterState() == list.Filtering
+	}
+	return model.list.FilterState() == list.Filtering
+}
+
 func (model Model) ShortHelp() []key.Binding {
+	if model.sessionState == viewBrowser {
+		return []key.Binding{
+			model.browser.keybindings.open,
+			model.browser.keybindings.parent,
+			model.browser.keybindings.switchFocus,
+			model.browser.keybindings.close,
+		}
+	}
+
 	switch model.focusedView {
 	case focusList:
 		return model.list.ShortHelp()
@@ -428,6 +812,10 @@ func (model Model) ShortHelp() []key.Binding {
 }
 
 func (model Model) FullHelp() [][]key.Binding {
+	if model.sessionState == viewBrowser {
+		return model.browser.list.FullHelp()
+	}
+
 	switch model.focusedView {
 	case focusList:
 		return model.list.FullHelp()